@@ -1,7 +1,11 @@
 package digit
 
 import (
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
 
 	"github.com/go-resty/resty/v2"
 )
@@ -11,8 +15,10 @@ type TenantRequest struct {
 	Tenant Tenant `json:"tenant"`
 }
 
-// Tenant represents the tenant information
+// Tenant represents the tenant information; ID and Code are assigned by the server
 type Tenant struct {
+	ID                   string                 `json:"id,omitempty"`
+	Code                 string                 `json:"code,omitempty"`
 	Name                 string                 `json:"name"`
 	Email                string                 `json:"email"`
 	IsActive             bool                   `json:"isActive"`
@@ -60,6 +66,175 @@ func CreateAccount(serverURL, clientID, name, email string, active bool) (string
 		return "", fmt.Errorf("failed to make API request: %w", err)
 	}
 
+	// Check for successful response
+	if resp.StatusCode() < 200 || resp.StatusCode() >= 300 {
+		return "", fmt.Errorf("failed to create account: HTTP %d - %s", resp.StatusCode(), string(resp.Body()))
+	}
+
+	// Return the raw response body as string
+	return string(resp.Body()), nil
+}
+
+// TenantConfigRequest represents the request structure for creating or updating a tenant configuration
+type TenantConfigRequest struct {
+	TenantConfig TenantConfig `json:"tenantConfig"`
+}
+
+// TenantConfig represents the login, language and document settings of a tenant
+type TenantConfig struct {
+	ID                   string                 `json:"id,omitempty"`
+	Code                 string                 `json:"code"`
+	Name                 string                 `json:"name,omitempty"`
+	DefaultLoginType     string                 `json:"defaultLoginType,omitempty"`
+	OtpLength            string                 `json:"otpLength,omitempty"`
+	EnableUserBasedLogin bool                   `json:"enableUserBasedLogin"`
+	AdditionalAttributes map[string]interface{} `json:"additionalAttributes,omitempty"`
+	Documents            []TenantDocument       `json:"documents,omitempty"`
+	IsActive             bool                   `json:"isActive"`
+	Languages            []string               `json:"languages,omitempty"`
+}
+
+// UnmarshalJSON reads a tenant configuration, accepting otpLength as a number as well as the string the API documents
+func (c *TenantConfig) UnmarshalJSON(data []byte) error {
+	type plain TenantConfig
+	var raw struct {
+		plain
+		OtpLength interface{} `json:"otpLength"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*c = TenantConfig(raw.plain)
+	switch otpLength := raw.OtpLength.(type) {
+	case string:
+		c.OtpLength = otpLength
+	case float64:
+		c.OtpLength = strconv.FormatFloat(otpLength, 'f', -1, 64)
+	}
+	return nil
+}
+
+// TenantDocument is a document attached to a tenant configuration, such as a logo
+type TenantDocument struct {
+	ID             string `json:"id,omitempty"`
+	TenantID       string `json:"tenantId,omitempty"`
+	TenantConfigID string `json:"tenantConfigId,omitempty"`
+	Type           string `json:"type"`
+	FileStoreID    string `json:"fileStoreId,omitempty"`
+	URL            string `json:"url,omitempty"`
+	IsActive       bool   `json:"isActive"`
+}
+
+// SearchTenantByCode fetches the tenant with the given code
+// Returns the raw response body as string and any error encountered
+func SearchTenantByCode(serverURL, jwtToken, clientID, code string) (string, error) {
+	if code == "" {
+		return "", fmt.Errorf("code cannot be empty")
+	}
+	return accountRequest("GET", serverURL+"/account/v1?code="+url.QueryEscape(code), jwtToken, clientID, nil)
+}
+
+// UpdateTenant replaces the details of an existing tenant
+// Returns the raw response body as string and any error encountered
+func UpdateTenant(serverURL, jwtToken, clientID, tenantID string, tenant Tenant) (string, error) {
+	if tenantID == "" {
+		return "", fmt.Errorf("tenantID cannot be empty")
+	}
+	tenant.ID = tenantID
+	return accountRequest("PUT", serverURL+"/account/v1/"+url.PathEscape(tenantID), jwtToken, clientID, TenantRequest{Tenant: tenant})
+}
+
+// ParseTenants parses the tenants of a tenant search, create or update response
+func ParseTenants(responseBody string) ([]Tenant, error) {
+	var response struct {
+		Tenants []Tenant        `json:"tenants"`
+		Tenant  json.RawMessage `json:"tenant"`
+	}
+	if err := json.Unmarshal([]byte(responseBody), &response); err != nil {
+		return nil, fmt.Errorf("failed to parse tenant response: %w", err)
+	}
+	if len(response.Tenants) == 0 && len(response.Tenant) > 0 && string(response.Tenant) != "null" {
+		var tenant Tenant
+		if err := json.Unmarshal(response.Tenant, &tenant); err != nil {
+			return nil, fmt.Errorf("failed to parse tenant response: %w", err)
+		}
+		return []Tenant{tenant}, nil
+	}
+	return response.Tenants, nil
+}
+
+// CreateTenantConfig creates the configuration of a tenant
+// Returns the raw response body as string and any error encountered
+func CreateTenantConfig(serverURL, jwtToken, clientID string, config TenantConfig) (string, error) {
+	if config.Code == "" {
+		return "", fmt.Errorf("tenant config code cannot be empty")
+	}
+	return accountRequest("POST", serverURL+"/account/v1/config", jwtToken, clientID, TenantConfigRequest{TenantConfig: config})
+}
+
+// SearchTenantConfigByCode fetches the configuration of the tenant with the given code
+// Returns the raw response body as string and any error encountered
+func SearchTenantConfigByCode(serverURL, jwtToken, clientID, code string) (string, error) {
+	if code == "" {
+		return "", fmt.Errorf("code cannot be empty")
+	}
+	return accountRequest("GET", serverURL+"/account/v1/config?code="+url.QueryEscape(code), jwtToken, clientID, nil)
+}
+
+// UpdateTenantConfig replaces an existing tenant configuration
+// Returns the raw response body as string and any error encountered
+func UpdateTenantConfig(serverURL, jwtToken, clientID, configID string, config TenantConfig) (string, error) {
+	if configID == "" {
+		return "", fmt.Errorf("configID cannot be empty")
+	}
+	config.ID = configID
+	return accountRequest("PUT", serverURL+"/account/v1/config/"+url.PathEscape(configID), jwtToken, clientID, TenantConfigRequest{TenantConfig: config})
+}
+
+// ParseTenantConfigs parses the tenant configurations of a search, create or update response
+func ParseTenantConfigs(responseBody string) ([]TenantConfig, error) {
+	var response struct {
+		TenantConfigs []TenantConfig  `json:"tenantConfigs"`
+		TenantConfig  json.RawMessage `json:"tenantConfig"`
+	}
+	if err := json.Unmarshal([]byte(responseBody), &response); err != nil {
+		return nil, fmt.Errorf("failed to parse tenant config response: %w", err)
+	}
+	if len(response.TenantConfigs) == 0 && len(response.TenantConfig) > 0 && string(response.TenantConfig) != "null" {
+		var config TenantConfig
+		if err := json.Unmarshal(response.TenantConfig, &config); err != nil {
+			return nil, fmt.Errorf("failed to parse tenant config response: %w", err)
+		}
+		return []TenantConfig{config}, nil
+	}
+	return response.TenantConfigs, nil
+}
+
+// accountRequest sends an authenticated request to the account API, with body as JSON when not nil
+func accountRequest(method, requestURL, jwtToken, clientID string, body interface{}) (string, error) {
+	if clientID == "" {
+		return "", fmt.Errorf("clientID cannot be empty")
+	}
+
+	// Create HTTP client
+	client := resty.New()
+
+	req := client.R().
+		SetHeader("X-Client-Id", clientID).
+		SetHeader("Authorization", "Bearer "+jwtToken)
+	if body != nil {
+		req.SetHeader("Content-Type", "application/json").SetBody(body)
+	}
+	resp, err := req.Execute(method, requestURL)
+	if err != nil {
+		return "", fmt.Errorf("failed to make API request: %w", err)
+	}
+
+	// Check for successful response
+	if resp.StatusCode() != http.StatusOK && resp.StatusCode() != http.StatusCreated {
+		return "", fmt.Errorf("API request failed: HTTP %d - %s", resp.StatusCode(), string(resp.Body()))
+	}
+
 	// Return the raw response body as string
 	return string(resp.Body()), nil
 }
@@ -6,8 +6,40 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+
+	"github.com/digitnxt/digit3/code/libraries/digit-library/geometry"
 )
 
+// Boundary is a boundary entity: a code with its GeoJSON geometry and additional details.
+// ID is assigned by the server and required for updates.
+type Boundary struct {
+	ID                string                 `json:"id,omitempty"`
+	TenantID          string                 `json:"tenantId,omitempty"`
+	Code              string                 `json:"code"`
+	Geometry          *geometry.Geometry     `json:"geometry,omitempty"`
+	AdditionalDetails map[string]interface{} `json:"additionalDetails,omitempty"`
+}
+
+// BoundaryHierarchyLevel is one boundary type in a hierarchy definition, such as a District under a State.
+// The top level has an empty ParentBoundaryType.
+type BoundaryHierarchyLevel struct {
+	BoundaryType       string `json:"boundaryType"`
+	ParentBoundaryType string `json:"parentBoundaryType,omitempty"`
+	Active             bool   `json:"active"`
+}
+
+// BoundaryRelationship links a boundary to its parent within a hierarchy.
+// Parent is empty for a root boundary; ID is assigned by the server and required for updates.
+type BoundaryRelationship struct {
+	ID            string `json:"id,omitempty"`
+	TenantID      string `json:"tenantId"`
+	Code          string `json:"code"`
+	HierarchyType string `json:"hierarchyType"`
+	BoundaryType  string `json:"boundaryType"`
+	Parent        string `json:"parent,omitempty"`
+}
+
 // CreateBoundaries creates boundaries using the boundary API
 func CreateBoundaries(serverURL, jwtToken, tenantID, clientID string, boundaryData []map[string]interface{}) (string, error) {
 	// Prepare the request payload
@@ -53,4 +85,192 @@ func CreateBoundaries(serverURL, jwtToken, tenantID, clientID string, boundaryDa
 	}
 	
 	return string(responseBody), nil
-}
\ No newline at end of file
+}
+
+// SearchBoundariesByCodes fetches the boundaries with the given codes; codes that do not exist are left out
+func SearchBoundariesByCodes(serverURL, jwtToken, tenantID, clientID string, codes []string) (string, error) {
+	if len(codes) == 0 {
+		return "", fmt.Errorf("at least one boundary code is required")
+	}
+
+	query := url.Values{}
+	for _, code := range codes {
+		if code == "" {
+			return "", fmt.Errorf("boundary code cannot be empty")
+		}
+		query.Add("codes", code)
+	}
+	return boundaryRequest("GET", serverURL+"/boundary/v1?"+query.Encode(), jwtToken, tenantID, clientID, nil)
+}
+
+// ParseBoundaries parses the boundaries of a boundary search or update response
+func ParseBoundaries(responseBody string) ([]Boundary, error) {
+	var response struct {
+		Boundary []Boundary `json:"boundary"`
+	}
+	if err := json.Unmarshal([]byte(responseBody), &response); err != nil {
+		return nil, fmt.Errorf("failed to parse boundary response: %w", err)
+	}
+	return response.Boundary, nil
+}
+
+// IsValidBoundariesByCodes reports whether boundaries exist for all of the given codes
+func IsValidBoundariesByCodes(serverURL, jwtToken, tenantID, clientID string, codes []string) (bool, error) {
+	responseBody, err := SearchBoundariesByCodes(serverURL, jwtToken, tenantID, clientID, codes)
+	if err != nil {
+		return false, err
+	}
+	boundaries, err := ParseBoundaries(responseBody)
+	if err != nil {
+		return false, err
+	}
+
+	found := map[string]bool{}
+	for _, boundary := range boundaries {
+		found[boundary.Code] = true
+	}
+	for _, code := range codes {
+		if !found[code] {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// UpdateBoundary replaces the geometry and additional details of an existing boundary
+func UpdateBoundary(serverURL, jwtToken, tenantID, clientID, boundaryID string, boundary Boundary) (string, error) {
+	if boundaryID == "" {
+		return "", fmt.Errorf("boundary ID cannot be empty")
+	}
+	if boundary.Code == "" {
+		return "", fmt.Errorf("boundary code cannot be empty")
+	}
+	if boundary.TenantID == "" {
+		boundary.TenantID = tenantID
+	}
+	boundary.ID = boundaryID
+
+	payload := map[string]interface{}{
+		"boundary": []Boundary{boundary},
+	}
+	return boundaryRequest("PUT", serverURL+"/boundary/v1/"+url.PathEscape(boundaryID), jwtToken, tenantID, clientID, payload)
+}
+
+// CreateBoundaryHierarchy creates a boundary hierarchy definition, e.g. State > District > Ward
+func CreateBoundaryHierarchy(serverURL, jwtToken, tenantID, clientID, hierarchyType string, levels []BoundaryHierarchyLevel) (string, error) {
+	if hierarchyType == "" {
+		return "", fmt.Errorf("hierarchy type cannot be empty")
+	}
+	if len(levels) == 0 {
+		return "", fmt.Errorf("at least one boundary hierarchy level is required")
+	}
+
+	payload := map[string]interface{}{
+		"hierarchy": map[string]interface{}{
+			"tenantId":          tenantID,
+			"hierarchyType":     hierarchyType,
+			"boundaryHierarchy": levels,
+		},
+	}
+	return boundaryRequest("POST", serverURL+"/boundary/v1/boundary-hierarchy-definition", jwtToken, tenantID, clientID, payload)
+}
+
+// SearchBoundaryHierarchy fetches the boundary hierarchy definition of a hierarchy type
+func SearchBoundaryHierarchy(serverURL, jwtToken, tenantID, clientID, hierarchyType string) (string, error) {
+	if hierarchyType == "" {
+		return "", fmt.Errorf("hierarchy type cannot be empty")
+	}
+
+	query := url.Values{}
+	query.Set("hierarchyType", hierarchyType)
+	return boundaryRequest("GET", serverURL+"/boundary/v1/boundary-hierarchy-definition?"+query.Encode(), jwtToken, tenantID, clientID, nil)
+}
+
+// CreateBoundaryRelationships links boundaries to their parents within a hierarchy
+func CreateBoundaryRelationships(serverURL, jwtToken, tenantID, clientID string, relationships []BoundaryRelationship) (string, error) {
+	if len(relationships) == 0 {
+		return "", fmt.Errorf("at least one boundary relationship is required")
+	}
+	for i, relationship := range relationships {
+		if relationship.Code == "" || relationship.HierarchyType == "" || relationship.BoundaryType == "" {
+			return "", fmt.Errorf("boundary relationship %d: code, hierarchy type and boundary type are required", i)
+		}
+		if relationship.TenantID == "" {
+			relationships[i].TenantID = tenantID
+		}
+	}
+
+	payload := map[string]interface{}{
+		"relationship": relationships,
+	}
+	return boundaryRequest("POST", serverURL+"/boundary/v1/boundary-relationships", jwtToken, tenantID, clientID, payload)
+}
+
+// SearchBoundaryRelationships fetches the boundaries of a hierarchy, optionally limited to one boundary type.
+// With includeChildren the boundaries are returned with their descendants.
+func SearchBoundaryRelationships(serverURL, jwtToken, tenantID, clientID, hierarchyType, boundaryType string, includeChildren bool) (string, error) {
+	if hierarchyType == "" {
+		return "", fmt.Errorf("hierarchy type cannot be empty")
+	}
+
+	query := url.Values{}
+	query.Set("hierarchyType", hierarchyType)
+	if boundaryType != "" {
+		query.Set("boundaryType", boundaryType)
+	}
+	query.Set("includeChildren", fmt.Sprintf("%t", includeChildren))
+	return boundaryRequest("GET", serverURL+"/boundary/v1/boundary-relationships?"+query.Encode(), jwtToken, tenantID, clientID, nil)
+}
+
+// UpdateBoundaryRelationship updates an existing boundary relationship, e.g. to move a boundary to another parent
+func UpdateBoundaryRelationship(serverURL, jwtToken, tenantID, clientID string, relationship BoundaryRelationship) (string, error) {
+	if relationship.ID == "" {
+		return "", fmt.Errorf("relationship ID cannot be empty")
+	}
+	if relationship.TenantID == "" {
+		relationship.TenantID = tenantID
+	}
+	return boundaryRequest("PUT", serverURL+"/boundary/v1/boundary-relationships/"+url.PathEscape(relationship.ID), jwtToken, tenantID, clientID, relationship)
+}
+
+// boundaryRequest sends a request to the boundary API, with payload as the JSON body when not nil
+func boundaryRequest(method, requestURL, jwtToken, tenantID, clientID string, payload interface{}) (string, error) {
+	var body io.Reader
+	if payload != nil {
+		payloadBytes, err := json.Marshal(payload)
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal request to JSON: %w", err)
+		}
+		body = bytes.NewBuffer(payloadBytes)
+	}
+
+	req, err := http.NewRequest(method, requestURL, body)
+	if err != nil {
+		return "", fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("X-Tenant-ID", tenantID)
+	req.Header.Set("X-Client-Id", clientID)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", jwtToken))
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to execute HTTP request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	responseBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(responseBody))
+	}
+
+	return string(responseBody), nil
+}
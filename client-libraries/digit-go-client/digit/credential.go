@@ -0,0 +1,127 @@
+package digit
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Required actions a user can be asked to perform at their next login
+const (
+	RequiredActionUpdatePassword = "UPDATE_PASSWORD"
+	RequiredActionVerifyEmail    = "VERIFY_EMAIL"
+	RequiredActionConfigureTOTP  = "CONFIGURE_TOTP"
+	RequiredActionUpdateProfile  = "UPDATE_PROFILE"
+)
+
+// Credential is a credential of a user, such as a password or an OTP device; the secret
+// itself is never returned
+type Credential struct {
+	ID string `json:"id"`
+	// Type is e.g. password or otp
+	Type      string `json:"type"`
+	UserLabel string `json:"userLabel,omitempty"`
+	// CreatedDate is a Unix time in milliseconds
+	CreatedDate int64 `json:"createdDate,omitempty"`
+}
+
+// ParseCredentials parses the credentials of a credential response
+func ParseCredentials(responseBody string) ([]Credential, error) {
+	var credentials []Credential
+	if err := json.Unmarshal([]byte(responseBody), &credentials); err != nil {
+		return nil, fmt.Errorf("failed to parse credential response: %w", err)
+	}
+	return credentials, nil
+}
+
+// Created returns the creation time of the credential, or the zero time when it is not known
+func (c Credential) Created() time.Time {
+	if c.CreatedDate == 0 {
+		return time.Time{}
+	}
+	return time.UnixMilli(c.CreatedDate)
+}
+
+// GetUserCredentials fetches the credentials of a user
+// Returns the raw response body as string and any error encountered
+func GetUserCredentials(serverURL, jwtToken, realm, userID string) (string, error) {
+	if realm == "" {
+		return "", fmt.Errorf("realm cannot be empty")
+	}
+	if userID == "" {
+		return "", fmt.Errorf("userID cannot be empty")
+	}
+	return keycloakRequest("GET", userURL(serverURL, realm, userID)+"/credentials", jwtToken, nil)
+}
+
+// DeleteUserCredential removes a credential of a user, e.g. an OTP device so that the user
+// can log in without it
+// Returns the raw response body as string and any error encountered
+func DeleteUserCredential(serverURL, jwtToken, realm, userID, credentialID string) (string, error) {
+	if realm == "" {
+		return "", fmt.Errorf("realm cannot be empty")
+	}
+	if userID == "" {
+		return "", fmt.Errorf("userID cannot be empty")
+	}
+	if credentialID == "" {
+		return "", fmt.Errorf("credentialID cannot be empty")
+	}
+	return keycloakRequest("DELETE", userURL(serverURL, realm, userID)+"/credentials/"+url.PathEscape(credentialID), jwtToken, nil)
+}
+
+// SetUserPassword replaces the password of a user. A temporary password must be changed at
+// the next login, for which Keycloak adds the UPDATE_PASSWORD required action.
+// Returns the raw response body as string and any error encountered
+func SetUserPassword(serverURL, jwtToken, realm, userID, password string, temporary bool) (string, error) {
+	if realm == "" {
+		return "", fmt.Errorf("realm cannot be empty")
+	}
+	if userID == "" {
+		return "", fmt.Errorf("userID cannot be empty")
+	}
+	if password == "" {
+		return "", fmt.Errorf("password cannot be empty")
+	}
+	credential := UserCredential{Type: "password", Value: password, Temporary: temporary}
+	return keycloakRequest("PUT", userURL(serverURL, realm, userID)+"/reset-password", jwtToken, credential)
+}
+
+// SetUserRequiredActions replaces the actions a user must perform at their next login;
+// no actions clears them
+// Returns the raw response body as string and any error encountered
+func SetUserRequiredActions(serverURL, jwtToken, realm, userID string, actions []string) (string, error) {
+	if realm == "" {
+		return "", fmt.Errorf("realm cannot be empty")
+	}
+	if userID == "" {
+		return "", fmt.Errorf("userID cannot be empty")
+	}
+	if actions == nil {
+		actions = []string{}
+	}
+	body := map[string][]string{"requiredActions": actions}
+	return keycloakRequest("PUT", userURL(serverURL, realm, userID), jwtToken, body)
+}
+
+// SendUserActionsEmail emails a user a link to perform actions, such as UPDATE_PASSWORD,
+// without logging in; the link is valid for lifespan, or the realm default when it is 0
+// Returns the raw response body as string and any error encountered
+func SendUserActionsEmail(serverURL, jwtToken, realm, userID string, actions []string, lifespan time.Duration) (string, error) {
+	if realm == "" {
+		return "", fmt.Errorf("realm cannot be empty")
+	}
+	if userID == "" {
+		return "", fmt.Errorf("userID cannot be empty")
+	}
+	if len(actions) == 0 {
+		return "", fmt.Errorf("actions cannot be empty")
+	}
+	requestURL := userURL(serverURL, realm, userID) + "/execute-actions-email"
+	if lifespan > 0 {
+		requestURL += "?lifespan=" + strconv.Itoa(int(lifespan.Seconds()))
+	}
+	return keycloakRequest("PUT", requestURL, jwtToken, actions)
+}
@@ -0,0 +1,267 @@
+package digit
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// SniffLength is the number of leading bytes CheckDocument uses to detect a file's MIME type
+const SniffLength = 512
+
+// DocumentCategory represents a filestore document category as returned by the server
+type DocumentCategory struct {
+	Type           string
+	Code           string
+	AllowedFormats []string
+	MinSize        int64 // bytes, 0 for no minimum
+	MaxSize        int64 // bytes, 0 for no maximum
+	IsSensitive    bool
+	Description    string
+	IsActive       bool
+}
+
+// ParseDocumentCategory parses a document category response. The category may be the
+// response itself or the first element of a list it wraps; sizes may be numbers or strings.
+func ParseDocumentCategory(responseBody string) (*DocumentCategory, error) {
+	categories, err := ParseDocumentCategories(responseBody)
+	if err != nil {
+		return nil, err
+	}
+	if len(categories) == 0 {
+		return nil, fmt.Errorf("document category response has no category")
+	}
+	return &categories[0], nil
+}
+
+// ParseDocumentCategories parses a document category search response into the categories it contains
+func ParseDocumentCategories(responseBody string) ([]DocumentCategory, error) {
+	var decoded interface{}
+	if err := json.Unmarshal([]byte(responseBody), &decoded); err != nil {
+		return nil, fmt.Errorf("failed to parse document category response: %w", err)
+	}
+
+	var categories []DocumentCategory
+	for _, obj := range findCategoryObjects(decoded, nil) {
+		category := DocumentCategory{IsActive: true}
+		category.Type, _ = obj["type"].(string)
+		category.Code, _ = obj["code"].(string)
+		category.Description, _ = obj["description"].(string)
+		if sensitive, ok := obj["isSensitive"].(bool); ok {
+			category.IsSensitive = sensitive
+		}
+		if active, ok := obj["isActive"].(bool); ok {
+			category.IsActive = active
+		}
+		switch formats := obj["allowedFormats"].(type) {
+		case []interface{}:
+			for _, format := range formats {
+				if f, ok := format.(string); ok {
+					category.AllowedFormats = append(category.AllowedFormats, f)
+				}
+			}
+		case string:
+			category.AllowedFormats = strings.Split(formats, ",")
+		}
+		for i, format := range category.AllowedFormats {
+			category.AllowedFormats[i] = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(format), "."))
+		}
+
+		var err error
+		if category.MinSize, err = sizeValue(obj["minSize"]); err != nil {
+			return nil, fmt.Errorf("category %s: invalid minSize: %w", category.Code, err)
+		}
+		if category.MaxSize, err = sizeValue(obj["maxSize"]); err != nil {
+			return nil, fmt.Errorf("category %s: invalid maxSize: %w", category.Code, err)
+		}
+		categories = append(categories, category)
+	}
+	return categories, nil
+}
+
+// findCategoryObjects collects the objects with a code, searching wrapped lists and objects
+func findCategoryObjects(value interface{}, found []map[string]interface{}) []map[string]interface{} {
+	switch v := value.(type) {
+	case []interface{}:
+		for _, item := range v {
+			found = findCategoryObjects(item, found)
+		}
+	case map[string]interface{}:
+		if _, ok := v["code"].(string); ok {
+			return append(found, v)
+		}
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			found = findCategoryObjects(v[key], found)
+		}
+	}
+	return found
+}
+
+func sizeValue(value interface{}) (int64, error) {
+	switch v := value.(type) {
+	case nil:
+		return 0, nil
+	case float64:
+		return int64(v), nil
+	case string:
+		if v == "" {
+			return 0, nil
+		}
+		return strconv.ParseInt(v, 10, 64)
+	}
+	return 0, fmt.Errorf("unexpected value %v", value)
+}
+
+// formatMIMETypes maps document formats (file extensions) to the MIME types DetectMIMEType reports for them.
+// Allowed formats may also be given directly as one of these MIME types.
+// Office Open XML files are zip archives and legacy Office files are OLE compound documents,
+// so their content alone cannot tell a .docx from an .xlsx.
+var formatMIMETypes = map[string][]string{
+	"pdf":  {"application/pdf"},
+	"jpg":  {"image/jpeg"},
+	"jpeg": {"image/jpeg"},
+	"png":  {"image/png"},
+	"gif":  {"image/gif"},
+	"bmp":  {"image/bmp"},
+	"webp": {"image/webp"},
+	"tif":  {"image/tiff"},
+	"tiff": {"image/tiff"},
+	"svg":  {"image/svg+xml"},
+	"txt":  {"text/plain"},
+	"csv":  {"text/plain", "text/csv"},
+	"json": {"text/plain", "application/json"},
+	"xml":  {"text/xml"},
+	"html": {"text/html"},
+	"htm":  {"text/html"},
+	"zip":  {"application/zip"},
+	"docx": {"application/zip"},
+	"xlsx": {"application/zip"},
+	"pptx": {"application/zip"},
+	"odt":  {"application/zip"},
+	"ods":  {"application/zip"},
+	"doc":  {"application/x-ole-storage"},
+	"xls":  {"application/x-ole-storage"},
+	"ppt":  {"application/x-ole-storage"},
+	"mp3":  {"audio/mpeg"},
+	"wav":  {"audio/wave"},
+	"mp4":  {"video/mp4"},
+	"webm": {"video/webm"},
+}
+
+// ValidateDocumentFormats checks that every format is a known file extension (such as pdf or jpg)
+// or a known MIME type (such as application/pdf) that DetectMIMEType can recognise
+func ValidateDocumentFormats(formats []string) error {
+	var unknown []string
+	for _, format := range formats {
+		if !isKnownFormat(strings.ToLower(strings.TrimPrefix(strings.TrimSpace(format), "."))) {
+			unknown = append(unknown, format)
+		}
+	}
+	if len(unknown) > 0 {
+		return fmt.Errorf("unknown document format(s) %s; known formats are %s or their MIME types",
+			strings.Join(unknown, ", "), strings.Join(KnownDocumentFormats(), ", "))
+	}
+	return nil
+}
+
+// KnownDocumentFormats returns the file extensions that can be used as allowed formats, sorted
+func KnownDocumentFormats() []string {
+	formats := make([]string, 0, len(formatMIMETypes))
+	for format := range formatMIMETypes {
+		formats = append(formats, format)
+	}
+	sort.Strings(formats)
+	return formats
+}
+
+func isKnownFormat(format string) bool {
+	if _, ok := formatMIMETypes[format]; ok {
+		return true
+	}
+	for _, mimeTypes := range formatMIMETypes {
+		for _, mimeType := range mimeTypes {
+			if mimeType == format {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// formatAllows reports whether an allowed format, given as an extension or a MIME type, matches a detected MIME type
+func formatAllows(format, detected string) bool {
+	if strings.Contains(format, "/") {
+		return format == detected
+	}
+	for _, mimeType := range formatMIMETypes[format] {
+		if mimeType == detected {
+			return true
+		}
+	}
+	return false
+}
+
+// DetectMIMEType detects the MIME type of a file from its leading bytes, ignoring its name.
+// It extends http.DetectContentType with TIFF, OLE compound documents and SVG.
+func DetectMIMEType(head []byte) string {
+	switch {
+	case bytes.HasPrefix(head, []byte("II*\x00")), bytes.HasPrefix(head, []byte("MM\x00*")):
+		return "image/tiff"
+	case bytes.HasPrefix(head, []byte("\xD0\xCF\x11\xE0\xA1\xB1\x1A\xE1")):
+		return "application/x-ole-storage"
+	}
+
+	detected := http.DetectContentType(head)
+	mimeType := strings.TrimSpace(strings.SplitN(detected, ";", 2)[0])
+	if (mimeType == "text/xml" || mimeType == "text/plain") && bytes.Contains(bytes.ToLower(head), []byte("<svg")) {
+		return "image/svg+xml"
+	}
+	return mimeType
+}
+
+// CheckDocument checks a file against a document category: the category must be active, the size
+// must be within its bounds, and the MIME type detected from the file's leading bytes must match
+// one of its allowed formats. Only the first SniffLength bytes of head are used; pass the whole
+// file when it is smaller.
+// The file name is not used, so a renamed file cannot bypass the format check.
+// All violations are returned together.
+func CheckDocument(category *DocumentCategory, head []byte, size int64) error {
+	var errs []error
+	if !category.IsActive {
+		errs = append(errs, fmt.Errorf("document category %s is not active", category.Code))
+	}
+	if category.MinSize > 0 && size < category.MinSize {
+		errs = append(errs, fmt.Errorf("file is too small: %d bytes, category %s requires at least %d bytes", size, category.Code, category.MinSize))
+	}
+	if category.MaxSize > 0 && size > category.MaxSize {
+		errs = append(errs, fmt.Errorf("file is too large: %d bytes, category %s allows at most %d bytes", size, category.Code, category.MaxSize))
+	}
+
+	if len(category.AllowedFormats) > 0 {
+		if len(head) > SniffLength {
+			head = head[:SniffLength]
+		}
+		detected := DetectMIMEType(head)
+		allowed := false
+		for _, format := range category.AllowedFormats {
+			if formatAllows(format, detected) {
+				allowed = true
+			}
+		}
+		if !allowed {
+			errs = append(errs, fmt.Errorf("file content is %s, category %s allows only %s", detected, category.Code, strings.Join(category.AllowedFormats, ", ")))
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -1,8 +1,15 @@
 package digit
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
 	"net/http"
+	"net/url"
+	"path/filepath"
 	"strconv"
 
 	"github.com/go-resty/resty/v2"
@@ -75,3 +82,430 @@ func CreateDocumentCategory(serverURL, jwtToken, tenantID, categoryType, code st
 	// Return the raw response body as string
 	return string(resp.Body()), nil
 }
+
+// GetDocumentCategory retrieves a document category by code
+// Returns the raw response body as string and any error encountered
+func GetDocumentCategory(serverURL, jwtToken, tenantID, code string) (string, error) {
+	// Validate required parameters
+	if serverURL == "" {
+		return "", fmt.Errorf("serverURL cannot be empty")
+	}
+	if tenantID == "" {
+		return "", fmt.Errorf("tenantID cannot be empty")
+	}
+	if code == "" {
+		return "", fmt.Errorf("code cannot be empty")
+	}
+
+	// Create HTTP client
+	client := resty.New()
+
+	// Make the API request
+	resp, err := client.R().
+		SetHeader("X-Tenant-ID", tenantID).
+		SetHeader("Authorization", "Bearer "+jwtToken).
+		Get(serverURL + "/filestore/v1/files/document-categories/" + url.PathEscape(code))
+
+	if err != nil {
+		return "", fmt.Errorf("failed to get document category: %w", err)
+	}
+
+	// Check for successful response
+	if resp.StatusCode() != http.StatusOK {
+		return "", fmt.Errorf("failed to get document category %s: HTTP %d - %s", code, resp.StatusCode(), string(resp.Body()))
+	}
+
+	// Return the raw response body as string
+	return string(resp.Body()), nil
+}
+
+// SearchDocumentCategories searches document categories by type and code; empty filters match all categories
+// Returns the raw response body as string and any error encountered
+func SearchDocumentCategories(serverURL, jwtToken, tenantID, categoryType, code string) (string, error) {
+	// Validate required parameters
+	if serverURL == "" {
+		return "", fmt.Errorf("serverURL cannot be empty")
+	}
+	if tenantID == "" {
+		return "", fmt.Errorf("tenantID cannot be empty")
+	}
+
+	// Create HTTP client
+	client := resty.New()
+
+	// Make the API request
+	req := client.R().
+		SetHeader("X-Tenant-ID", tenantID).
+		SetHeader("Authorization", "Bearer "+jwtToken)
+	if categoryType != "" {
+		req.SetQueryParam("type", categoryType)
+	}
+	if code != "" {
+		req.SetQueryParam("code", code)
+	}
+	resp, err := req.Get(serverURL + "/filestore/v1/files/document-categories")
+
+	if err != nil {
+		return "", fmt.Errorf("failed to search document categories: %w", err)
+	}
+
+	// Check for successful response
+	if resp.StatusCode() != http.StatusOK {
+		return "", fmt.Errorf("failed to search document categories: HTTP %d - %s", resp.StatusCode(), string(resp.Body()))
+	}
+
+	// Return the raw response body as string
+	return string(resp.Body()), nil
+}
+
+// UpdateDocumentCategory replaces the configuration of an existing document category
+// Returns the raw response body as string and any error encountered
+func UpdateDocumentCategory(serverURL, jwtToken, tenantID, categoryType, code string, allowedFormats []string, minSize, maxSize int, isSensitive, isActive bool, description string) (string, error) {
+	// Validate required parameters
+	if serverURL == "" {
+		return "", fmt.Errorf("serverURL cannot be empty")
+	}
+	if tenantID == "" {
+		return "", fmt.Errorf("tenantID cannot be empty")
+	}
+	if categoryType == "" {
+		return "", fmt.Errorf("type cannot be empty")
+	}
+	if code == "" {
+		return "", fmt.Errorf("code cannot be empty")
+	}
+	if len(allowedFormats) == 0 {
+		return "", fmt.Errorf("allowedFormats cannot be empty")
+	}
+
+	// Create HTTP client
+	client := resty.New()
+
+	// Build the request payload
+	categoryReq := DocumentCategoryRequest{
+		Type:           categoryType,
+		Code:           code,
+		AllowedFormats: allowedFormats,
+		MinSize:        strconv.Itoa(minSize),
+		MaxSize:        strconv.Itoa(maxSize),
+		IsSensitive:    isSensitive,
+		Description:    description,
+		IsActive:       isActive,
+	}
+
+	// Make the API call
+	resp, err := client.R().
+		SetHeader("X-Tenant-ID", tenantID).
+		SetHeader("Authorization", "Bearer "+jwtToken).
+		SetHeader("Content-Type", "application/json").
+		SetBody(categoryReq).
+		Put(serverURL + "/filestore/v1/files/document-categories/" + url.PathEscape(code))
+
+	if err != nil {
+		return "", fmt.Errorf("failed to update document category: %w", err)
+	}
+
+	// Check for successful response
+	if resp.StatusCode() != http.StatusOK {
+		return "", fmt.Errorf("failed to update document category: HTTP %d - %s", resp.StatusCode(), string(resp.Body()))
+	}
+
+	// Return the raw response body as string
+	return string(resp.Body()), nil
+}
+
+// DeactivateDocumentCategory marks a document category inactive, so that no new files can be uploaded
+// under it. The category is sent back exactly as it was fetched with only isActive changed, so the
+// rest of its configuration is kept as is.
+// Returns the raw response body as string and any error encountered
+func DeactivateDocumentCategory(serverURL, jwtToken, tenantID, code string) (string, error) {
+	responseBody, err := GetDocumentCategory(serverURL, jwtToken, tenantID, code)
+	if err != nil {
+		return "", err
+	}
+	var decoded interface{}
+	if err := json.Unmarshal([]byte(responseBody), &decoded); err != nil {
+		return "", fmt.Errorf("failed to parse document category response: %w", err)
+	}
+	var category map[string]interface{}
+	for _, obj := range findCategoryObjects(decoded, nil) {
+		if obj["code"] == code {
+			category = obj
+			break
+		}
+	}
+	if category == nil {
+		return "", fmt.Errorf("document category %s not found", code)
+	}
+	category["isActive"] = false
+
+	// Make the API call
+	resp, err := resty.New().R().
+		SetHeader("X-Tenant-ID", tenantID).
+		SetHeader("Authorization", "Bearer "+jwtToken).
+		SetHeader("Content-Type", "application/json").
+		SetBody(category).
+		Put(serverURL + "/filestore/v1/files/document-categories/" + url.PathEscape(code))
+
+	if err != nil {
+		return "", fmt.Errorf("failed to deactivate document category: %w", err)
+	}
+
+	// Check for successful response
+	if resp.StatusCode() != http.StatusOK {
+		return "", fmt.Errorf("failed to deactivate document category: HTTP %d - %s", resp.StatusCode(), string(resp.Body()))
+	}
+
+	// Return the raw response body as string
+	return string(resp.Body()), nil
+}
+
+// UploadFile uploads a file of the given size to filestore under the given document category.
+// The category is fetched first and the file is rejected without uploading when its size or
+// content-sniffed MIME type is not allowed (see CheckDocument).
+// The content is streamed as a multipart request, so progress can be tracked by wrapping the reader.
+// Returns the raw response body as string and any error encountered
+func UploadFile(serverURL, jwtToken, tenantID, categoryCode, fileName string, content io.Reader, size int64) (string, error) {
+	// Validate required parameters
+	if serverURL == "" {
+		return "", fmt.Errorf("serverURL cannot be empty")
+	}
+	if tenantID == "" {
+		return "", fmt.Errorf("tenantID cannot be empty")
+	}
+	if categoryCode == "" {
+		return "", fmt.Errorf("categoryCode cannot be empty")
+	}
+	if fileName == "" {
+		return "", fmt.Errorf("fileName cannot be empty")
+	}
+
+	// Enforce the category rules before sending anything
+	categoryBody, err := GetDocumentCategory(serverURL, jwtToken, tenantID, categoryCode)
+	if err != nil {
+		return "", err
+	}
+	category, err := ParseDocumentCategory(categoryBody)
+	if err != nil {
+		return "", err
+	}
+	head := make([]byte, SniffLength)
+	n, err := io.ReadFull(content, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return "", fmt.Errorf("failed to read file: %w", err)
+	}
+	head = head[:n]
+	if err := CheckDocument(category, head, size); err != nil {
+		return "", fmt.Errorf("%s rejected: %w", filepath.Base(fileName), err)
+	}
+	content = io.MultiReader(bytes.NewReader(head), content)
+
+	// Stream the multipart body through a pipe instead of buffering the whole file. The request
+	// is built before the writer starts, so the writer always has a reader to close the pipe.
+	bodyReader, bodyWriter := io.Pipe()
+	form := multipart.NewWriter(bodyWriter)
+	req, err := http.NewRequest("POST", serverURL+"/filestore/v1/files", bodyReader)
+	if err != nil {
+		return "", fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	req.Header.Set("X-Tenant-ID", tenantID)
+	req.Header.Set("Authorization", "Bearer "+jwtToken)
+
+	go func() {
+		err := form.WriteField("tenantId", tenantID)
+		if err == nil {
+			err = form.WriteField("category", categoryCode)
+		}
+		if err == nil {
+			var part io.Writer
+			part, err = form.CreateFormFile("file", filepath.Base(fileName))
+			if err == nil {
+				_, err = io.Copy(part, content)
+			}
+		}
+		if err == nil {
+			err = form.Close()
+		}
+		bodyWriter.CloseWithError(err)
+	}()
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to upload file: %w", err)
+	}
+	defer resp.Body.Close()
+
+	responseBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	// Check for successful response
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		return "", fmt.Errorf("failed to upload file: HTTP %d - %s", resp.StatusCode, string(responseBody))
+	}
+
+	// Return the raw response body as string
+	return string(responseBody), nil
+}
+
+// DownloadFile streams the content of a stored file to w.
+// Returns the file name from the Content-Disposition header, if any, and any error encountered
+func DownloadFile(serverURL, jwtToken, tenantID, fileID string, w io.Writer) (string, error) {
+	// Validate required parameters
+	if serverURL == "" {
+		return "", fmt.Errorf("serverURL cannot be empty")
+	}
+	if tenantID == "" {
+		return "", fmt.Errorf("tenantID cannot be empty")
+	}
+	if fileID == "" {
+		return "", fmt.Errorf("fileID cannot be empty")
+	}
+
+	fileURL := fmt.Sprintf("%s/filestore/v1/files/%s?tenantId=%s", serverURL, url.PathEscape(fileID), url.QueryEscape(tenantID))
+	req, err := http.NewRequest("GET", fileURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+	req.Header.Set("X-Tenant-ID", tenantID)
+	req.Header.Set("Authorization", "Bearer "+jwtToken)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to download file: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Check for successful response
+	if resp.StatusCode != http.StatusOK {
+		responseBody, _ := io.ReadAll(resp.Body)
+		return "", fmt.Errorf("failed to download file: HTTP %d - %s", resp.StatusCode, string(responseBody))
+	}
+
+	if _, err := io.Copy(w, resp.Body); err != nil {
+		return "", fmt.Errorf("failed to download file: %w", err)
+	}
+
+	fileName := ""
+	if _, params, err := mime.ParseMediaType(resp.Header.Get("Content-Disposition")); err == nil {
+		fileName = filepath.Base(params["filename"])
+	}
+	return fileName, nil
+}
+
+// GetFileMetadata retrieves the metadata of a stored file (name, category, size, content type and checksum)
+// Returns the raw response body as string and any error encountered
+func GetFileMetadata(serverURL, jwtToken, tenantID, fileID string) (string, error) {
+	// Validate required parameters
+	if serverURL == "" {
+		return "", fmt.Errorf("serverURL cannot be empty")
+	}
+	if tenantID == "" {
+		return "", fmt.Errorf("tenantID cannot be empty")
+	}
+	if fileID == "" {
+		return "", fmt.Errorf("fileID cannot be empty")
+	}
+
+	// Create HTTP client
+	client := resty.New()
+
+	// Make the API request
+	resp, err := client.R().
+		SetHeader("X-Tenant-ID", tenantID).
+		SetHeader("Authorization", "Bearer "+jwtToken).
+		Get(serverURL + "/filestore/v1/files/" + url.PathEscape(fileID) + "/metadata")
+
+	if err != nil {
+		return "", fmt.Errorf("failed to get file metadata: %w", err)
+	}
+
+	// Check for successful response
+	if resp.StatusCode() != http.StatusOK {
+		return "", fmt.Errorf("failed to get file metadata: HTTP %d - %s", resp.StatusCode(), string(resp.Body()))
+	}
+
+	// Return the raw response body as string
+	return string(resp.Body()), nil
+}
+
+// GetFileURL retrieves a signed URL for downloading a stored file without authentication.
+// expirySeconds of 0 uses the server default.
+// Returns the raw response body as string and any error encountered
+func GetFileURL(serverURL, jwtToken, tenantID, fileID string, expirySeconds int) (string, error) {
+	// Validate required parameters
+	if serverURL == "" {
+		return "", fmt.Errorf("serverURL cannot be empty")
+	}
+	if tenantID == "" {
+		return "", fmt.Errorf("tenantID cannot be empty")
+	}
+	if fileID == "" {
+		return "", fmt.Errorf("fileID cannot be empty")
+	}
+	if expirySeconds < 0 {
+		return "", fmt.Errorf("expirySeconds cannot be negative")
+	}
+
+	// Create HTTP client
+	client := resty.New()
+
+	// Make the API request
+	req := client.R().
+		SetHeader("X-Tenant-ID", tenantID).
+		SetHeader("Authorization", "Bearer "+jwtToken)
+	if expirySeconds > 0 {
+		req.SetQueryParam("expiry", strconv.Itoa(expirySeconds))
+	}
+	resp, err := req.Get(serverURL + "/filestore/v1/files/" + url.PathEscape(fileID) + "/url")
+
+	if err != nil {
+		return "", fmt.Errorf("failed to get file URL: %w", err)
+	}
+
+	// Check for successful response
+	if resp.StatusCode() != http.StatusOK {
+		return "", fmt.Errorf("failed to get file URL: HTTP %d - %s", resp.StatusCode(), string(resp.Body()))
+	}
+
+	// Return the raw response body as string
+	return string(resp.Body()), nil
+}
+
+// DeleteFile deletes a stored file
+// Returns the raw response body as string and any error encountered
+func DeleteFile(serverURL, jwtToken, tenantID, fileID string) (string, error) {
+	// Validate required parameters
+	if serverURL == "" {
+		return "", fmt.Errorf("serverURL cannot be empty")
+	}
+	if tenantID == "" {
+		return "", fmt.Errorf("tenantID cannot be empty")
+	}
+	if fileID == "" {
+		return "", fmt.Errorf("fileID cannot be empty")
+	}
+
+	// Create HTTP client
+	client := resty.New()
+
+	// Make the API request
+	resp, err := client.R().
+		SetHeader("X-Tenant-ID", tenantID).
+		SetHeader("Authorization", "Bearer "+jwtToken).
+		Delete(serverURL + "/filestore/v1/files/" + url.PathEscape(fileID))
+
+	if err != nil {
+		return "", fmt.Errorf("failed to delete file: %w", err)
+	}
+
+	// Check for successful response
+	if resp.StatusCode() != http.StatusOK && resp.StatusCode() != http.StatusNoContent {
+		return "", fmt.Errorf("failed to delete file: HTTP %d - %s", resp.StatusCode(), string(resp.Body()))
+	}
+
+	// Return the raw response body as string
+	return string(resp.Body()), nil
+}
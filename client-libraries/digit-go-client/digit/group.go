@@ -0,0 +1,355 @@
+package digit
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Group is a Keycloak group
+type Group struct {
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name"`
+	// Path is the full path of the group, e.g. /departments/pgr
+	Path        string              `json:"path,omitempty"`
+	Attributes  map[string][]string `json:"attributes,omitempty"`
+	RealmRoles  []string            `json:"realmRoles,omitempty"`
+	ClientRoles map[string][]string `json:"clientRoles,omitempty"`
+	// SubGroups holds the child groups; Keycloak 23 and later leave it empty when listing
+	// groups and only set SubGroupCount, see GetGroupChildren
+	SubGroups     []Group `json:"subGroups,omitempty"`
+	SubGroupCount int     `json:"subGroupCount,omitempty"`
+}
+
+// ParseGroup parses the group of a group response
+func ParseGroup(responseBody string) (*Group, error) {
+	var group Group
+	if err := json.Unmarshal([]byte(responseBody), &group); err != nil {
+		return nil, fmt.Errorf("failed to parse group response: %w", err)
+	}
+	return &group, nil
+}
+
+// ParseGroups parses the groups of a group list response
+func ParseGroups(responseBody string) ([]Group, error) {
+	var groups []Group
+	if err := json.Unmarshal([]byte(responseBody), &groups); err != nil {
+		return nil, fmt.Errorf("failed to parse group response: %w", err)
+	}
+	return groups, nil
+}
+
+// GroupQuery holds the filter and page of a group list
+type GroupQuery struct {
+	// Search matches groups whose name contains it, returned within their parent groups
+	Search string
+	// First and Max page through the top-level groups; Max 0 uses the Keycloak default of 100
+	First int
+	Max   int
+}
+
+// ListGroups lists the top-level groups of a realm with their subgroups
+// Returns the raw response body as string and any error encountered
+func ListGroups(serverURL, jwtToken, realm string, query GroupQuery) (string, error) {
+	if realm == "" {
+		return "", fmt.Errorf("realm cannot be empty")
+	}
+	params := url.Values{}
+	params.Set("briefRepresentation", "false")
+	if query.Search != "" {
+		params.Set("search", query.Search)
+	}
+	if query.First > 0 {
+		params.Set("first", strconv.Itoa(query.First))
+	}
+	if query.Max > 0 {
+		params.Set("max", strconv.Itoa(query.Max))
+	}
+	return keycloakRequest("GET", realmURL(serverURL, realm)+"/groups?"+params.Encode(), jwtToken, nil)
+}
+
+// GetGroup fetches a group by ID
+// Returns the raw response body as string and any error encountered
+func GetGroup(serverURL, jwtToken, realm, groupID string) (string, error) {
+	if realm == "" {
+		return "", fmt.Errorf("realm cannot be empty")
+	}
+	if groupID == "" {
+		return "", fmt.Errorf("groupID cannot be empty")
+	}
+	return keycloakRequest("GET", groupURL(serverURL, realm, groupID), jwtToken, nil)
+}
+
+// GetGroupChildren fetches a page of the child groups of a group; max 0 uses the Keycloak
+// default of 100
+// Returns the raw response body as string and any error encountered
+func GetGroupChildren(serverURL, jwtToken, realm, groupID string, first, max int) (string, error) {
+	if realm == "" {
+		return "", fmt.Errorf("realm cannot be empty")
+	}
+	if groupID == "" {
+		return "", fmt.Errorf("groupID cannot be empty")
+	}
+	params := url.Values{}
+	params.Set("briefRepresentation", "false")
+	if first > 0 {
+		params.Set("first", strconv.Itoa(first))
+	}
+	if max > 0 {
+		params.Set("max", strconv.Itoa(max))
+	}
+	return keycloakRequest("GET", groupURL(serverURL, realm, groupID)+"/children?"+params.Encode(), jwtToken, nil)
+}
+
+// CreateGroup creates a top-level group
+// Returns the raw response body as string and any error encountered
+func CreateGroup(serverURL, jwtToken, realm string, group Group) (string, error) {
+	if realm == "" {
+		return "", fmt.Errorf("realm cannot be empty")
+	}
+	if group.Name == "" {
+		return "", fmt.Errorf("group name cannot be empty")
+	}
+	return keycloakRequest("POST", realmURL(serverURL, realm)+"/groups", jwtToken, group)
+}
+
+// CreateChildGroup creates a group within the group parentID
+// Returns the raw response body as string and any error encountered
+func CreateChildGroup(serverURL, jwtToken, realm, parentID string, group Group) (string, error) {
+	if realm == "" {
+		return "", fmt.Errorf("realm cannot be empty")
+	}
+	if parentID == "" {
+		return "", fmt.Errorf("parentID cannot be empty")
+	}
+	if group.Name == "" {
+		return "", fmt.Errorf("group name cannot be empty")
+	}
+	group.ID = ""
+	return keycloakRequest("POST", groupURL(serverURL, realm, parentID)+"/children", jwtToken, group)
+}
+
+// MoveGroup moves an existing group, with its subgroups and members, into the group parentID,
+// or to the top level when parentID is empty
+// Returns the raw response body as string and any error encountered
+func MoveGroup(serverURL, jwtToken, realm, parentID string, group Group) (string, error) {
+	if realm == "" {
+		return "", fmt.Errorf("realm cannot be empty")
+	}
+	if group.ID == "" {
+		return "", fmt.Errorf("group ID cannot be empty")
+	}
+	requestURL := realmURL(serverURL, realm) + "/groups"
+	if parentID != "" {
+		requestURL = groupURL(serverURL, realm, parentID) + "/children"
+	}
+	return keycloakRequest("POST", requestURL, jwtToken, Group{ID: group.ID, Name: group.Name})
+}
+
+// UpdateGroup replaces the name and attributes of a group
+// Returns the raw response body as string and any error encountered
+func UpdateGroup(serverURL, jwtToken, realm, groupID string, group Group) (string, error) {
+	if realm == "" {
+		return "", fmt.Errorf("realm cannot be empty")
+	}
+	if groupID == "" {
+		return "", fmt.Errorf("groupID cannot be empty")
+	}
+	return keycloakRequest("PUT", groupURL(serverURL, realm, groupID), jwtToken, group)
+}
+
+// DeleteGroup deletes a group with its subgroups; its members are kept but lose the roles of the group
+// Returns the raw response body as string and any error encountered
+func DeleteGroup(serverURL, jwtToken, realm, groupID string) (string, error) {
+	if realm == "" {
+		return "", fmt.Errorf("realm cannot be empty")
+	}
+	if groupID == "" {
+		return "", fmt.Errorf("groupID cannot be empty")
+	}
+	return keycloakRequest("DELETE", groupURL(serverURL, realm, groupID), jwtToken, nil)
+}
+
+// GetGroupMembers fetches a page of the users that are direct members of a group; members of
+// subgroups are not included. max 0 uses the Keycloak default of 100
+// Returns the raw response body as string and any error encountered
+func GetGroupMembers(serverURL, jwtToken, realm, groupID string, first, max int) (string, error) {
+	if realm == "" {
+		return "", fmt.Errorf("realm cannot be empty")
+	}
+	if groupID == "" {
+		return "", fmt.Errorf("groupID cannot be empty")
+	}
+	params := url.Values{}
+	if first > 0 {
+		params.Set("first", strconv.Itoa(first))
+	}
+	if max > 0 {
+		params.Set("max", strconv.Itoa(max))
+	}
+	requestURL := groupURL(serverURL, realm, groupID) + "/members"
+	if len(params) > 0 {
+		requestURL += "?" + params.Encode()
+	}
+	return keycloakRequest("GET", requestURL, jwtToken, nil)
+}
+
+// GetUserGroups fetches the groups a user is a direct member of
+// Returns the raw response body as string and any error encountered
+func GetUserGroups(serverURL, jwtToken, realm, userID string) (string, error) {
+	if realm == "" {
+		return "", fmt.Errorf("realm cannot be empty")
+	}
+	if userID == "" {
+		return "", fmt.Errorf("userID cannot be empty")
+	}
+	return keycloakRequest("GET", userURL(serverURL, realm, userID)+"/groups", jwtToken, nil)
+}
+
+// AddUserToGroup makes a user a member of a group; the user then holds the roles of the group
+// and of its parent groups
+// Returns the raw response body as string and any error encountered
+func AddUserToGroup(serverURL, jwtToken, realm, userID, groupID string) (string, error) {
+	if userID == "" {
+		return "", fmt.Errorf("userID cannot be empty")
+	}
+	if groupID == "" {
+		return "", fmt.Errorf("groupID cannot be empty")
+	}
+	return keycloakRequest("PUT", userURL(serverURL, realm, userID)+"/groups/"+url.PathEscape(groupID), jwtToken, nil)
+}
+
+// RemoveUserFromGroup removes a user from a group
+// Returns the raw response body as string and any error encountered
+func RemoveUserFromGroup(serverURL, jwtToken, realm, userID, groupID string) (string, error) {
+	if userID == "" {
+		return "", fmt.Errorf("userID cannot be empty")
+	}
+	if groupID == "" {
+		return "", fmt.Errorf("groupID cannot be empty")
+	}
+	return keycloakRequest("DELETE", userURL(serverURL, realm, userID)+"/groups/"+url.PathEscape(groupID), jwtToken, nil)
+}
+
+// GetGroupByPath fetches a group by its full path, e.g. /departments/pgr
+// Returns the raw response body as string and any error encountered
+func GetGroupByPath(serverURL, jwtToken, realm, path string) (string, error) {
+	if realm == "" {
+		return "", fmt.Errorf("realm cannot be empty")
+	}
+	path = strings.Trim(path, "/")
+	if path == "" {
+		return "", fmt.Errorf("path cannot be empty")
+	}
+	segments := strings.Split(path, "/")
+	for i := range segments {
+		segments[i] = url.PathEscape(segments[i])
+	}
+	return keycloakRequest("GET", realmURL(serverURL, realm)+"/group-by-path/"+strings.Join(segments, "/"), jwtToken, nil)
+}
+
+// GetRoleGroups fetches a page of the groups a realm role, or a client role when clientUUID is
+// set, is mapped to directly; max 0 uses the Keycloak default of 100
+// Returns the raw response body as string and any error encountered
+func GetRoleGroups(serverURL, jwtToken, realm, clientUUID, roleName string, first, max int) (string, error) {
+	if realm == "" {
+		return "", fmt.Errorf("realm cannot be empty")
+	}
+	if roleName == "" {
+		return "", fmt.Errorf("roleName cannot be empty")
+	}
+	params := url.Values{}
+	if first > 0 {
+		params.Set("first", strconv.Itoa(first))
+	}
+	if max > 0 {
+		params.Set("max", strconv.Itoa(max))
+	}
+	requestURL := roleURL(serverURL, realm, clientUUID, roleName) + "/groups"
+	if len(params) > 0 {
+		requestURL += "?" + params.Encode()
+	}
+	return keycloakRequest("GET", requestURL, jwtToken, nil)
+}
+
+// GetGroupRealmRoles fetches the realm roles mapped directly to a group
+// Returns the raw response body as string and any error encountered
+func GetGroupRealmRoles(serverURL, jwtToken, realm, groupID string) (string, error) {
+	if groupID == "" {
+		return "", fmt.Errorf("groupID cannot be empty")
+	}
+	return keycloakRequest("GET", groupURL(serverURL, realm, groupID)+"/role-mappings/realm", jwtToken, nil)
+}
+
+// AddGroupRealmRoles maps realm roles to a group in one request; its members hold them too
+// Returns the raw response body as string and any error encountered
+func AddGroupRealmRoles(serverURL, jwtToken, realm, groupID string, roles []Role) (string, error) {
+	if groupID == "" {
+		return "", fmt.Errorf("groupID cannot be empty")
+	}
+	if len(roles) == 0 {
+		return "", nil
+	}
+	return keycloakRequest("POST", groupURL(serverURL, realm, groupID)+"/role-mappings/realm", jwtToken, roles)
+}
+
+// RemoveGroupRealmRoles removes realm roles from a group in one request
+// Returns the raw response body as string and any error encountered
+func RemoveGroupRealmRoles(serverURL, jwtToken, realm, groupID string, roles []Role) (string, error) {
+	if groupID == "" {
+		return "", fmt.Errorf("groupID cannot be empty")
+	}
+	if len(roles) == 0 {
+		return "", nil
+	}
+	return keycloakRequest("DELETE", groupURL(serverURL, realm, groupID)+"/role-mappings/realm", jwtToken, roles)
+}
+
+// GetGroupClientRoles fetches the roles of a client mapped directly to a group
+// Returns the raw response body as string and any error encountered
+func GetGroupClientRoles(serverURL, jwtToken, realm, groupID, clientUUID string) (string, error) {
+	if groupID == "" {
+		return "", fmt.Errorf("groupID cannot be empty")
+	}
+	if clientUUID == "" {
+		return "", fmt.Errorf("clientUUID cannot be empty")
+	}
+	return keycloakRequest("GET", groupURL(serverURL, realm, groupID)+"/role-mappings/clients/"+url.PathEscape(clientUUID), jwtToken, nil)
+}
+
+// AddGroupClientRoles maps roles of a client to a group in one request
+// Returns the raw response body as string and any error encountered
+func AddGroupClientRoles(serverURL, jwtToken, realm, groupID, clientUUID string, roles []Role) (string, error) {
+	if groupID == "" {
+		return "", fmt.Errorf("groupID cannot be empty")
+	}
+	if clientUUID == "" {
+		return "", fmt.Errorf("clientUUID cannot be empty")
+	}
+	if len(roles) == 0 {
+		return "", nil
+	}
+	return keycloakRequest("POST", groupURL(serverURL, realm, groupID)+"/role-mappings/clients/"+url.PathEscape(clientUUID), jwtToken, roles)
+}
+
+// RemoveGroupClientRoles removes roles of a client from a group in one request
+// Returns the raw response body as string and any error encountered
+func RemoveGroupClientRoles(serverURL, jwtToken, realm, groupID, clientUUID string, roles []Role) (string, error) {
+	if groupID == "" {
+		return "", fmt.Errorf("groupID cannot be empty")
+	}
+	if clientUUID == "" {
+		return "", fmt.Errorf("clientUUID cannot be empty")
+	}
+	if len(roles) == 0 {
+		return "", nil
+	}
+	return keycloakRequest("DELETE", groupURL(serverURL, realm, groupID)+"/role-mappings/clients/"+url.PathEscape(clientUUID), jwtToken, roles)
+}
+
+// groupURL returns the admin API URL of a group
+func groupURL(serverURL, realm, groupID string) string {
+	return realmURL(serverURL, realm) + "/groups/" + url.PathEscape(groupID)
+}
@@ -39,6 +39,20 @@ type IdGenTemplateRequest struct {
 	Config       IdGenConfig `json:"config"`
 }
 
+// IdGenGenerateRequest represents the request structure for generating an ID from a template
+type IdGenGenerateRequest struct {
+	TemplateCode string            `json:"templateCode"`
+	Variables    map[string]string `json:"variables"`
+}
+
+// GenerateIDResponse represents the response returned by the ID generation endpoint
+type GenerateIDResponse struct {
+	TenantID     string `json:"tenantId"`
+	TemplateCode string `json:"templateCode"`
+	Version      string `json:"Version"`
+	ID           string `json:"id"`
+}
+
 
 // SearchIdGenTemplate searches for an ID generation template by templateCode
 func SearchIdGenTemplate(serverURL, jwtToken, clientID, tenantID, templateCode string) (string, error) {
@@ -182,3 +196,72 @@ func DeleteIdGenTemplate(serverURL, jwtToken, clientID, tenantID, templateCode,
 	// Return the raw response body as string
 	return string(resp.Body()), nil
 }
+
+// GenerateID generates a single ID from an ID generation template
+// Returns the generated ID and any error encountered
+func GenerateID(serverURL, jwtToken, clientID, tenantID, templateCode string, variables map[string]string) (string, error) {
+	// Validate required parameters
+	if serverURL == "" {
+		return "", fmt.Errorf("serverURL cannot be empty")
+	}
+	if clientID == "" {
+		return "", fmt.Errorf("clientID cannot be empty")
+	}
+	if tenantID == "" {
+		return "", fmt.Errorf("tenantID cannot be empty")
+	}
+	if templateCode == "" {
+		return "", fmt.Errorf("templateCode cannot be empty")
+	}
+	if variables == nil {
+		variables = map[string]string{}
+	}
+
+	// Create HTTP client
+	client := resty.New()
+
+	// Make the API call
+	var idResponse GenerateIDResponse
+	resp, err := client.R().
+		SetHeader("X-Client-ID", clientID).
+		SetHeader("X-Tenant-ID", tenantID).
+		SetHeader("Authorization", "Bearer "+jwtToken).
+		SetHeader("Content-Type", "application/json").
+		SetBody(IdGenGenerateRequest{TemplateCode: templateCode, Variables: variables}).
+		SetResult(&idResponse).
+		Post(serverURL + "/idgen/v1/generate")
+
+	if err != nil {
+		return "", fmt.Errorf("failed to generate ID: %w", err)
+	}
+
+	// Check for successful response
+	if resp.StatusCode() != http.StatusOK && resp.StatusCode() != http.StatusCreated {
+		return "", fmt.Errorf("failed to generate ID: HTTP %d - %s", resp.StatusCode(), string(resp.Body()))
+	}
+
+	if idResponse.ID == "" {
+		return "", fmt.Errorf("generated ID is empty: %s", string(resp.Body()))
+	}
+
+	return idResponse.ID, nil
+}
+
+// GenerateIDs generates count IDs from the same template and variables
+// IDs are generated one per request, so on error the IDs generated so far are returned with the error
+func GenerateIDs(serverURL, jwtToken, clientID, tenantID, templateCode string, variables map[string]string, count int) ([]string, error) {
+	if count < 1 {
+		return nil, fmt.Errorf("count must be at least 1")
+	}
+
+	ids := make([]string, 0, count)
+	for i := 0; i < count; i++ {
+		id, err := GenerateID(serverURL, jwtToken, clientID, tenantID, templateCode, variables)
+		if err != nil {
+			return ids, fmt.Errorf("failed after generating %d of %d IDs: %w", len(ids), count, err)
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,431 @@
+package digit
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// IdGenTokenKind identifies the kind of a token in an ID generation template pattern
+type IdGenTokenKind string
+
+const (
+	IdGenTokenLiteral  IdGenTokenKind = "literal"
+	IdGenTokenVariable IdGenTokenKind = "variable"
+	IdGenTokenDate     IdGenTokenKind = "date"
+	IdGenTokenSequence IdGenTokenKind = "sequence"
+	IdGenTokenRandom   IdGenTokenKind = "random"
+)
+
+// IdGenScopes lists the sequence scopes supported by the IdGen service
+var IdGenScopes = []string{"daily", "monthly", "yearly", "global"}
+
+// IdGenToken represents a single token of a parsed template pattern
+type IdGenToken struct {
+	Kind  IdGenTokenKind
+	Value string // literal text, variable name or date format
+}
+
+// IdGenPattern represents a parsed template pattern such as {ORG}-{DATE:yyyyMMdd}-{SEQ}-{RAND}
+type IdGenPattern struct {
+	Template string
+	Tokens   []IdGenToken
+}
+
+// ParseIdGenPattern parses and validates a template pattern without contacting the server
+func ParseIdGenPattern(template string) (*IdGenPattern, error) {
+	if template == "" {
+		return nil, fmt.Errorf("template cannot be empty")
+	}
+
+	pattern := &IdGenPattern{Template: template}
+	var literal strings.Builder
+	flushLiteral := func() {
+		if literal.Len() > 0 {
+			pattern.Tokens = append(pattern.Tokens, IdGenToken{Kind: IdGenTokenLiteral, Value: literal.String()})
+			literal.Reset()
+		}
+	}
+
+	for i := 0; i < len(template); i++ {
+		switch template[i] {
+		case '}':
+			return nil, fmt.Errorf("unexpected '}' at position %d", i+1)
+		case '{':
+			end := strings.IndexByte(template[i:], '}')
+			if end < 0 {
+				return nil, fmt.Errorf("unclosed '{' at position %d", i+1)
+			}
+			body := template[i+1 : i+end]
+			if strings.ContainsRune(body, '{') {
+				return nil, fmt.Errorf("nested '{' in token starting at position %d", i+1)
+			}
+			token, err := parseIdGenToken(body)
+			if err != nil {
+				return nil, fmt.Errorf("invalid token {%s} at position %d: %w", body, i+1, err)
+			}
+			flushLiteral()
+			pattern.Tokens = append(pattern.Tokens, token)
+			i += end
+		default:
+			literal.WriteByte(template[i])
+		}
+	}
+	flushLiteral()
+
+	seqCount := 0
+	for _, token := range pattern.Tokens {
+		if token.Kind == IdGenTokenSequence {
+			seqCount++
+		}
+	}
+	if seqCount > 1 {
+		return nil, fmt.Errorf("template can contain {SEQ} at most once")
+	}
+
+	return pattern, nil
+}
+
+func parseIdGenToken(body string) (IdGenToken, error) {
+	name, arg, hasArg := strings.Cut(body, ":")
+	switch name {
+	case "SEQ", "RAND":
+		if hasArg {
+			return IdGenToken{}, fmt.Errorf("%s does not take an argument", name)
+		}
+		if name == "SEQ" {
+			return IdGenToken{Kind: IdGenTokenSequence}, nil
+		}
+		return IdGenToken{Kind: IdGenTokenRandom}, nil
+	case "DATE":
+		if !hasArg || arg == "" {
+			return IdGenToken{}, fmt.Errorf("DATE requires a format, e.g. {DATE:yyyyMMdd}")
+		}
+		if _, err := formatIdGenDate(arg, time.Time{}); err != nil {
+			return IdGenToken{}, err
+		}
+		return IdGenToken{Kind: IdGenTokenDate, Value: arg}, nil
+	}
+
+	if hasArg {
+		return IdGenToken{}, fmt.Errorf("unknown token %s", name)
+	}
+	if name == "" {
+		return IdGenToken{}, fmt.Errorf("empty token")
+	}
+	for i, r := range name {
+		if !(r == '_' || unicode.IsLetter(r) || (i > 0 && unicode.IsDigit(r))) {
+			return IdGenToken{}, fmt.Errorf("variable names may only contain letters, digits and underscores")
+		}
+	}
+	return IdGenToken{Kind: IdGenTokenVariable, Value: name}, nil
+}
+
+// Variables returns the names of the variables used in the pattern, in order of first use
+func (p *IdGenPattern) Variables() []string {
+	var names []string
+	seen := map[string]bool{}
+	for _, token := range p.Tokens {
+		if token.Kind == IdGenTokenVariable && !seen[token.Value] {
+			seen[token.Value] = true
+			names = append(names, token.Value)
+		}
+	}
+	return names
+}
+
+// Has reports whether the pattern contains a token of the given kind
+func (p *IdGenPattern) Has(kind IdGenTokenKind) bool {
+	for _, token := range p.Tokens {
+		if token.Kind == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateIdGenConfig checks a template configuration the same way the server would before any ID is generated
+func ValidateIdGenConfig(config IdGenConfig) error {
+	var errs []error
+
+	pattern, err := ParseIdGenPattern(config.Template)
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	validScope := false
+	for _, scope := range IdGenScopes {
+		if config.Sequence.Scope == scope {
+			validScope = true
+		}
+	}
+	if !validScope {
+		errs = append(errs, fmt.Errorf("invalid scope %q, must be one of %s", config.Sequence.Scope, strings.Join(IdGenScopes, ", ")))
+	}
+	if config.Sequence.Start < 0 {
+		errs = append(errs, fmt.Errorf("start cannot be negative"))
+	}
+	if config.Sequence.Padding.Length < 0 {
+		errs = append(errs, fmt.Errorf("padding length cannot be negative"))
+	}
+	if config.Sequence.Padding.Length > 0 && len([]rune(config.Sequence.Padding.Char)) != 1 {
+		errs = append(errs, fmt.Errorf("padding char must be a single character"))
+	}
+	if config.Random.Length < 0 {
+		errs = append(errs, fmt.Errorf("random length cannot be negative"))
+	}
+	if _, err := ExpandCharset(config.Random.Charset); config.Random.Length > 0 && err != nil {
+		errs = append(errs, err)
+	}
+
+	if pattern != nil {
+		if pattern.Has(IdGenTokenRandom) && config.Random.Length == 0 {
+			errs = append(errs, fmt.Errorf("template uses {RAND} but random length is 0"))
+		}
+		if !pattern.Has(IdGenTokenSequence) && !pattern.Has(IdGenTokenRandom) {
+			errs = append(errs, fmt.Errorf("template has neither {SEQ} nor {RAND}, so every generated ID would be the same"))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// ExpandCharset expands a charset specification such as "A-Z0-9" into the characters it contains
+func ExpandCharset(charset string) (string, error) {
+	if charset == "" {
+		return "", fmt.Errorf("random charset cannot be empty")
+	}
+
+	runes := []rune(charset)
+	seen := map[rune]bool{}
+	var out []rune
+	add := func(r rune) {
+		if !seen[r] {
+			seen[r] = true
+			out = append(out, r)
+		}
+	}
+	for i := 0; i < len(runes); i++ {
+		if i+2 < len(runes) && runes[i+1] == '-' {
+			from, to := runes[i], runes[i+2]
+			if from > to {
+				return "", fmt.Errorf("invalid charset range %c-%c", from, to)
+			}
+			for r := from; r <= to; r++ {
+				add(r)
+			}
+			i += 2
+			continue
+		}
+		add(runes[i])
+	}
+	return string(out), nil
+}
+
+// Render produces the ID the template would generate for the given date and sequence number.
+// If rnd is nil the random segment is drawn from the shared math/rand source.
+func (p *IdGenPattern) Render(config IdGenConfig, variables map[string]string, date time.Time, seq int, rnd *rand.Rand) (string, error) {
+	var out strings.Builder
+	for _, token := range p.Tokens {
+		switch token.Kind {
+		case IdGenTokenLiteral:
+			out.WriteString(token.Value)
+		case IdGenTokenVariable:
+			value, ok := variables[token.Value]
+			if !ok {
+				return "", fmt.Errorf("variable %s is not set", token.Value)
+			}
+			out.WriteString(value)
+		case IdGenTokenDate:
+			formatted, err := formatIdGenDate(token.Value, date)
+			if err != nil {
+				return "", err
+			}
+			out.WriteString(formatted)
+		case IdGenTokenSequence:
+			out.WriteString(padSequence(seq, config.Sequence.Padding))
+		case IdGenTokenRandom:
+			charset, err := ExpandCharset(config.Random.Charset)
+			if err != nil {
+				return "", err
+			}
+			chars := []rune(charset)
+			for i := 0; i < config.Random.Length; i++ {
+				var n int
+				if rnd != nil {
+					n = rnd.Intn(len(chars))
+				} else {
+					n = rand.Intn(len(chars))
+				}
+				out.WriteRune(chars[n])
+			}
+		}
+	}
+	return out.String(), nil
+}
+
+func padSequence(seq int, padding PaddingConfig) string {
+	digits := strconv.Itoa(seq)
+	if missing := padding.Length - len(digits); missing > 0 && padding.Char != "" {
+		return strings.Repeat(padding.Char, missing) + digits
+	}
+	return digits
+}
+
+// IdGenScopeKey returns the key identifying the sequence period a date falls into.
+// Sequences restart whenever the key changes.
+func IdGenScopeKey(scope string, date time.Time) string {
+	switch scope {
+	case "daily":
+		return date.Format("2006-01-02")
+	case "monthly":
+		return date.Format("2006-01")
+	case "yearly":
+		return date.Format("2006")
+	}
+	return "global"
+}
+
+// CoversScope reports whether the {DATE} tokens of the pattern tell apart the periods of a
+// sequence scope: the year for yearly, also the month for monthly and also the day for daily
+// sequences. When they do not, a pattern with {SEQ} repeats its IDs every time the sequence
+// restarts. The global scope never restarts and is always covered.
+func (p *IdGenPattern) CoversScope(scope string) bool {
+	var needed string
+	switch scope {
+	case "daily":
+		needed = "yMd"
+	case "monthly":
+		needed = "yM"
+	case "yearly":
+		needed = "y"
+	default:
+		return true
+	}
+	fields := map[rune]bool{}
+	for _, token := range p.Tokens {
+		if token.Kind != IdGenTokenDate {
+			continue
+		}
+		quoted := false
+		for _, r := range token.Value {
+			if r == '\'' {
+				quoted = !quoted
+			} else if !quoted {
+				fields[r] = true
+			}
+		}
+	}
+	for _, field := range needed {
+		if !fields[field] {
+			return false
+		}
+	}
+	return true
+}
+
+// SequenceCapacity returns how many sequence numbers fit in the padding before it overflows.
+// Returns -1 when no padding is configured and the sequence is unbounded.
+func SequenceCapacity(sequence SequenceConfig) int64 {
+	if sequence.Padding.Length <= 0 {
+		return -1
+	}
+	if sequence.Padding.Length >= 19 {
+		return math.MaxInt64
+	}
+	max := int64(math.Pow10(sequence.Padding.Length)) - 1
+	if int64(sequence.Start) > max {
+		return 0
+	}
+	return max - int64(sequence.Start) + 1
+}
+
+// RandomCombinations returns the number of distinct values the random segment can take
+func RandomCombinations(random RandomConfig) (float64, error) {
+	charset, err := ExpandCharset(random.Charset)
+	if err != nil {
+		return 0, err
+	}
+	return math.Pow(float64(len([]rune(charset))), float64(random.Length)), nil
+}
+
+// RandomCollisionProbability estimates the probability that at least two of n random
+// segments are equal, using the birthday approximation 1 - e^(-n(n-1)/2N)
+func RandomCollisionProbability(random RandomConfig, n int64) (float64, error) {
+	combinations, err := RandomCombinations(random)
+	if err != nil {
+		return 0, err
+	}
+	if n < 2 {
+		return 0, nil
+	}
+	if float64(n) > combinations {
+		return 1, nil
+	}
+	pairs := float64(n) * float64(n-1) / 2
+	return -math.Expm1(-pairs / combinations), nil
+}
+
+// formatIdGenDate formats a date using Java-style pattern letters (yyyy, yy, MM, MMM, dd, HH, mm, ss, SSS).
+// Text in single quotes is copied verbatim.
+func formatIdGenDate(format string, date time.Time) (string, error) {
+	var out strings.Builder
+	runes := []rune(format)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		if r == '\'' {
+			end := i + 1
+			for end < len(runes) && runes[end] != '\'' {
+				end++
+			}
+			if end == len(runes) {
+				return "", fmt.Errorf("unterminated quote in date format %q", format)
+			}
+			out.WriteString(string(runes[i+1 : end]))
+			i = end + 1
+			continue
+		}
+		if !unicode.IsLetter(r) {
+			out.WriteRune(r)
+			i++
+			continue
+		}
+
+		n := 1
+		for i+n < len(runes) && runes[i+n] == r {
+			n++
+		}
+		i += n
+
+		var value string
+		switch {
+		case r == 'y' && n == 2:
+			value = fmt.Sprintf("%02d", date.Year()%100)
+		case r == 'y' && n == 4:
+			value = fmt.Sprintf("%04d", date.Year())
+		case r == 'M' && n <= 2:
+			value = fmt.Sprintf("%0*d", n, int(date.Month()))
+		case r == 'M' && n == 3:
+			value = date.Format("Jan")
+		case r == 'd' && n <= 2:
+			value = fmt.Sprintf("%0*d", n, date.Day())
+		case r == 'H' && n <= 2:
+			value = fmt.Sprintf("%0*d", n, date.Hour())
+		case r == 'm' && n == 2:
+			value = fmt.Sprintf("%02d", date.Minute())
+		case r == 's' && n == 2:
+			value = fmt.Sprintf("%02d", date.Second())
+		case r == 'S' && n == 3:
+			value = fmt.Sprintf("%03d", date.Nanosecond()/int(time.Millisecond))
+		default:
+			return "", fmt.Errorf("unsupported date pattern %q in %q", strings.Repeat(string(r), n), format)
+		}
+		out.WriteString(value)
+	}
+	return out.String(), nil
+}
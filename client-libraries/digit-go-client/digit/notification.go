@@ -0,0 +1,168 @@
+package digit
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// SMS categories accepted by the notification service
+const (
+	SMSCategoryOTP          = "OTP"
+	SMSCategoryTransaction  = "TRANSACTION"
+	SMSCategoryPromotion    = "PROMOTION"
+	SMSCategoryNotification = "NOTIFICATION"
+	SMSCategoryOthers       = "OTHERS"
+)
+
+// SMSCategories lists the SMS categories accepted by the notification service
+var SMSCategories = []string{SMSCategoryOTP, SMSCategoryTransaction, SMSCategoryPromotion, SMSCategoryNotification, SMSCategoryOthers}
+
+// SendEmailRequest represents the request structure for sending an email from a template
+type SendEmailRequest struct {
+	TemplateID  string                 `json:"templateId"`
+	Version     string                 `json:"version"`
+	EmailIDs    []string               `json:"emailIds"`
+	Enrich      bool                   `json:"enrich"`
+	Payload     map[string]interface{} `json:"payload"`
+	Attachments []string               `json:"attachments"`
+}
+
+// SendSMSRequest represents the request structure for sending an SMS from a template
+type SendSMSRequest struct {
+	TemplateID    string                 `json:"templateId"`
+	Version       string                 `json:"version"`
+	TenantID      string                 `json:"tenantId"`
+	MobileNumbers []string               `json:"mobileNumbers"`
+	Enrich        bool                   `json:"enrich"`
+	Payload       map[string]interface{} `json:"payload"`
+	Category      string                 `json:"category"`
+}
+
+// SendEmail sends an email rendered from a notification template to the given addresses
+// Attachments are filestore IDs. Returns the raw response body as string and any error encountered
+func SendEmail(serverURL, jwtToken, tenantID, templateID, version string, emailIDs []string, payload map[string]interface{}, attachments []string, enrich bool) (string, error) {
+	// Validate required parameters
+	if serverURL == "" {
+		return "", fmt.Errorf("serverURL cannot be empty")
+	}
+	if tenantID == "" {
+		return "", fmt.Errorf("tenantID cannot be empty")
+	}
+	if templateID == "" {
+		return "", fmt.Errorf("templateID cannot be empty")
+	}
+	if version == "" {
+		return "", fmt.Errorf("version cannot be empty")
+	}
+	if len(emailIDs) == 0 {
+		return "", fmt.Errorf("emailIDs cannot be empty")
+	}
+	if payload == nil {
+		payload = map[string]interface{}{}
+	}
+	if attachments == nil {
+		attachments = []string{}
+	}
+
+	// Create the request payload
+	emailReq := SendEmailRequest{
+		TemplateID:  templateID,
+		Version:     version,
+		EmailIDs:    emailIDs,
+		Enrich:      enrich,
+		Payload:     payload,
+		Attachments: attachments,
+	}
+
+	// Create HTTP client
+	client := resty.New()
+
+	// Make the API request
+	resp, err := client.R().
+		SetHeader("Content-Type", "application/json").
+		SetHeader("Authorization", "Bearer "+jwtToken).
+		SetHeader("X-Tenant-ID", tenantID).
+		SetBody(emailReq).
+		Post(serverURL + "/notification/v1/email/send")
+
+	if err != nil {
+		return "", fmt.Errorf("failed to send email: %w", err)
+	}
+
+	// Check for successful response
+	if resp.StatusCode() != http.StatusOK && resp.StatusCode() != http.StatusAccepted && resp.StatusCode() != http.StatusCreated {
+		return "", fmt.Errorf("failed to send email: HTTP %d - %s", resp.StatusCode(), string(resp.Body()))
+	}
+
+	// Return the raw response body as string
+	return string(resp.Body()), nil
+}
+
+// SendSMS sends an SMS rendered from a notification template to the given mobile numbers
+// Returns the raw response body as string and any error encountered
+func SendSMS(serverURL, jwtToken, tenantID, templateID, version string, mobileNumbers []string, payload map[string]interface{}, category string, enrich bool) (string, error) {
+	// Validate required parameters
+	if serverURL == "" {
+		return "", fmt.Errorf("serverURL cannot be empty")
+	}
+	if tenantID == "" {
+		return "", fmt.Errorf("tenantID cannot be empty")
+	}
+	if templateID == "" {
+		return "", fmt.Errorf("templateID cannot be empty")
+	}
+	if version == "" {
+		return "", fmt.Errorf("version cannot be empty")
+	}
+	if len(mobileNumbers) == 0 {
+		return "", fmt.Errorf("mobileNumbers cannot be empty")
+	}
+	validCategory := false
+	for _, c := range SMSCategories {
+		if category == c {
+			validCategory = true
+		}
+	}
+	if !validCategory {
+		return "", fmt.Errorf("invalid SMS category %q", category)
+	}
+	if payload == nil {
+		payload = map[string]interface{}{}
+	}
+
+	// Create the request payload
+	smsReq := SendSMSRequest{
+		TemplateID:    templateID,
+		Version:       version,
+		TenantID:      tenantID,
+		MobileNumbers: mobileNumbers,
+		Enrich:        enrich,
+		Payload:       payload,
+		Category:      category,
+	}
+
+	// Create HTTP client
+	client := resty.New()
+
+	// Make the API request
+	resp, err := client.R().
+		SetHeader("Content-Type", "application/json").
+		SetHeader("Authorization", "Bearer "+jwtToken).
+		SetHeader("X-Tenant-ID", tenantID).
+		SetBody(smsReq).
+		Post(serverURL + "/notification/v1/sms/send")
+
+	if err != nil {
+		return "", fmt.Errorf("failed to send SMS: %w", err)
+	}
+
+	// Check for successful response
+	if resp.StatusCode() != http.StatusOK && resp.StatusCode() != http.StatusAccepted && resp.StatusCode() != http.StatusCreated {
+		return "", fmt.Errorf("failed to send SMS: HTTP %d - %s", resp.StatusCode(), string(resp.Body()))
+	}
+
+	// Return the raw response body as string
+	return string(resp.Body()), nil
+}
@@ -87,6 +87,74 @@ func CreateRegistrySchema(serverURL, jwtToken, tenantID, clientID string, schema
 	return string(responseBody), nil
 }
 
+// UpdateRegistrySchema registers a new version of an existing registry schema
+// Returns the raw response body as string and any error encountered
+func UpdateRegistrySchema(serverURL, jwtToken, tenantID, clientID, schemaCode string, definition map[string]interface{}) (string, error) {
+	// Validate required parameters
+	if serverURL == "" {
+		return "", fmt.Errorf("serverURL cannot be empty")
+	}
+	if tenantID == "" {
+		return "", fmt.Errorf("tenantID cannot be empty")
+	}
+	if clientID == "" {
+		return "", fmt.Errorf("clientID cannot be empty")
+	}
+	if schemaCode == "" {
+		return "", fmt.Errorf("schemaCode cannot be empty")
+	}
+	if definition == nil {
+		return "", fmt.Errorf("definition cannot be empty")
+	}
+
+	// Create the request payload
+	registryReq := RegistrySchemaRequest{
+		SchemaCode: schemaCode,
+		Definition: definition,
+	}
+
+	payloadBytes, err := json.Marshal(registryReq)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal registry schema data to JSON: %w", err)
+	}
+
+	// Make HTTP request to registry API
+	url := fmt.Sprintf("%s/registry/v1/schema/%s", serverURL, schemaCode)
+	req, err := http.NewRequest("PUT", url, bytes.NewBuffer(payloadBytes))
+	if err != nil {
+		return "", fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+
+	// Set headers
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Tenant-ID", tenantID)
+	req.Header.Set("X-Client-ID", clientID)
+	if jwtToken != "" {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", jwtToken))
+	}
+
+	// Execute request
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to execute HTTP request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Read response
+	responseBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	// Check status code
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(responseBody))
+	}
+
+	return string(responseBody), nil
+}
+
 // SearchRegistrySchema searches for a registry schema by schema code and optional version
 // Returns the raw response body as string and any error encountered
 func SearchRegistrySchema(serverURL, jwtToken, tenantID, clientID, schemaCode, version string) (string, error) {
@@ -325,6 +393,138 @@ func SearchRegistryData(serverURL, jwtToken, tenantID, clientID, schemaCode, reg
 	return string(responseBody), nil
 }
 
+// SearchRegistryDataPage fetches one page of the records of a schema, skipping the first
+// offset records and returning at most limit
+// Returns the raw response body as string and any error encountered
+func SearchRegistryDataPage(serverURL, jwtToken, tenantID, clientID, schemaCode string, offset, limit int) (string, error) {
+	// Validate required parameters
+	if serverURL == "" {
+		return "", fmt.Errorf("serverURL cannot be empty")
+	}
+	if tenantID == "" {
+		return "", fmt.Errorf("tenantID cannot be empty")
+	}
+	if clientID == "" {
+		return "", fmt.Errorf("clientID cannot be empty")
+	}
+	if schemaCode == "" {
+		return "", fmt.Errorf("schemaCode cannot be empty")
+	}
+	if offset < 0 {
+		return "", fmt.Errorf("offset cannot be negative")
+	}
+	if limit < 1 {
+		return "", fmt.Errorf("limit must be at least 1")
+	}
+
+	// Build URL with query parameters
+	url := fmt.Sprintf("%s/registry/v1/data/_registry?schemaCode=%s&offset=%d&limit=%d", serverURL, schemaCode, offset, limit)
+
+	// Make HTTP request to registry API
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+
+	// Set headers
+	req.Header.Set("X-Tenant-ID", tenantID)
+	req.Header.Set("X-Client-ID", clientID)
+	if jwtToken != "" {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", jwtToken))
+	}
+
+	// Execute request
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to execute HTTP request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Read response
+	responseBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	// Check status code
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(responseBody))
+	}
+
+	return string(responseBody), nil
+}
+
+// UpdateRegistryData replaces the data of an existing registry record
+// Returns the raw response body as string and any error encountered
+func UpdateRegistryData(serverURL, jwtToken, tenantID, clientID, registryID, schemaCode string, data map[string]interface{}) (string, error) {
+	// Validate required parameters
+	if serverURL == "" {
+		return "", fmt.Errorf("serverURL cannot be empty")
+	}
+	if tenantID == "" {
+		return "", fmt.Errorf("tenantID cannot be empty")
+	}
+	if clientID == "" {
+		return "", fmt.Errorf("clientID cannot be empty")
+	}
+	if registryID == "" {
+		return "", fmt.Errorf("registryID cannot be empty")
+	}
+	if schemaCode == "" {
+		return "", fmt.Errorf("schemaCode cannot be empty")
+	}
+	if data == nil {
+		return "", fmt.Errorf("data cannot be empty")
+	}
+
+	// Create the request payload
+	registryDataReq := RegistryDataRequest{
+		Data: data,
+	}
+
+	payloadBytes, err := json.Marshal(registryDataReq)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal registry data to JSON: %w", err)
+	}
+
+	// Make HTTP request to registry API
+	url := fmt.Sprintf("%s/registry/v1/data/%s?schemaCode=%s", serverURL, registryID, schemaCode)
+	req, err := http.NewRequest("PUT", url, bytes.NewBuffer(payloadBytes))
+	if err != nil {
+		return "", fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+
+	// Set headers
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Tenant-ID", tenantID)
+	req.Header.Set("X-Client-ID", clientID)
+	if jwtToken != "" {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", jwtToken))
+	}
+
+	// Execute request
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to execute HTTP request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Read response
+	responseBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	// Check status code
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(responseBody))
+	}
+
+	return string(responseBody), nil
+}
+
 // DeleteRegistryData deletes registry data by ID and schema code
 // Returns the raw response body as string and any error encountered
 func DeleteRegistryData(serverURL, jwtToken, tenantID, clientID, registryID, schemaCode string) (string, error) {
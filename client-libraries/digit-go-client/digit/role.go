@@ -0,0 +1,282 @@
+package digit
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// Role is a Keycloak realm or client role
+type Role struct {
+	ID          string `json:"id,omitempty"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Composite   bool   `json:"composite"`
+	ClientRole  bool   `json:"clientRole"`
+	// ContainerID is the ID of the realm of a realm role, or of the client of a client role
+	ContainerID string `json:"containerId,omitempty"`
+}
+
+// ParseRole parses the role of a role response
+func ParseRole(responseBody string) (*Role, error) {
+	var role Role
+	if err := json.Unmarshal([]byte(responseBody), &role); err != nil {
+		return nil, fmt.Errorf("failed to parse role response: %w", err)
+	}
+	return &role, nil
+}
+
+// ParseRoles parses the roles of a role list response
+func ParseRoles(responseBody string) ([]Role, error) {
+	var roles []Role
+	if err := json.Unmarshal([]byte(responseBody), &roles); err != nil {
+		return nil, fmt.Errorf("failed to parse role response: %w", err)
+	}
+	return roles, nil
+}
+
+// RoleQuery holds the filter and page of a role list
+type RoleQuery struct {
+	// Search matches role names containing it
+	Search string
+	// First and Max page through the results; Max 0 lists all roles
+	First int
+	Max   int
+}
+
+// GetRole fetches a realm role by name
+// Returns the raw response body as string and any error encountered
+func GetRole(serverURL, jwtToken, realm, roleName string) (string, error) {
+	if realm == "" {
+		return "", fmt.Errorf("realm cannot be empty")
+	}
+	if roleName == "" {
+		return "", fmt.Errorf("roleName cannot be empty")
+	}
+	return keycloakRequest("GET", roleURL(serverURL, realm, "", roleName), jwtToken, nil)
+}
+
+// GetClientRole fetches a role of a client by name; clientUUID is the ID of the client, not its clientId
+// Returns the raw response body as string and any error encountered
+func GetClientRole(serverURL, jwtToken, realm, clientUUID, roleName string) (string, error) {
+	if realm == "" {
+		return "", fmt.Errorf("realm cannot be empty")
+	}
+	if clientUUID == "" {
+		return "", fmt.Errorf("clientUUID cannot be empty")
+	}
+	if roleName == "" {
+		return "", fmt.Errorf("roleName cannot be empty")
+	}
+	return keycloakRequest("GET", roleURL(serverURL, realm, clientUUID, roleName), jwtToken, nil)
+}
+
+// CreateClientRole creates a role of a client
+// Returns the raw response body as string and any error encountered
+func CreateClientRole(serverURL, jwtToken, realm, clientUUID string, role Role) (string, error) {
+	if realm == "" {
+		return "", fmt.Errorf("realm cannot be empty")
+	}
+	if clientUUID == "" {
+		return "", fmt.Errorf("clientUUID cannot be empty")
+	}
+	if role.Name == "" {
+		return "", fmt.Errorf("role name cannot be empty")
+	}
+	return keycloakRequest("POST", rolesURL(serverURL, realm, clientUUID), jwtToken, role)
+}
+
+// ListRoles lists the realm roles, or the roles of a client when clientUUID is set
+// Returns the raw response body as string and any error encountered
+func ListRoles(serverURL, jwtToken, realm, clientUUID string, query RoleQuery) (string, error) {
+	if realm == "" {
+		return "", fmt.Errorf("realm cannot be empty")
+	}
+	params := url.Values{}
+	params.Set("briefRepresentation", "false")
+	if query.Search != "" {
+		params.Set("search", query.Search)
+	}
+	if query.First > 0 {
+		params.Set("first", strconv.Itoa(query.First))
+	}
+	if query.Max > 0 {
+		params.Set("max", strconv.Itoa(query.Max))
+	}
+	return keycloakRequest("GET", rolesURL(serverURL, realm, clientUUID)+"?"+params.Encode(), jwtToken, nil)
+}
+
+// UpdateRole replaces the description of a realm role, or of a client role when clientUUID is set
+// Returns the raw response body as string and any error encountered
+func UpdateRole(serverURL, jwtToken, realm, clientUUID, roleName string, role Role) (string, error) {
+	if realm == "" {
+		return "", fmt.Errorf("realm cannot be empty")
+	}
+	if roleName == "" {
+		return "", fmt.Errorf("roleName cannot be empty")
+	}
+	if role.Name == "" {
+		role.Name = roleName
+	}
+	return keycloakRequest("PUT", roleURL(serverURL, realm, clientUUID, roleName), jwtToken, role)
+}
+
+// DeleteRole deletes a realm role, or a client role when clientUUID is set. Keycloak removes
+// the role from all users, groups and composite roles that have it.
+// Returns the raw response body as string and any error encountered
+func DeleteRole(serverURL, jwtToken, realm, clientUUID, roleName string) (string, error) {
+	if realm == "" {
+		return "", fmt.Errorf("realm cannot be empty")
+	}
+	if roleName == "" {
+		return "", fmt.Errorf("roleName cannot be empty")
+	}
+	return keycloakRequest("DELETE", roleURL(serverURL, realm, clientUUID, roleName), jwtToken, nil)
+}
+
+// GetRoleComposites fetches the realm and client roles a composite role includes
+// Returns the raw response body as string and any error encountered
+func GetRoleComposites(serverURL, jwtToken, realm, clientUUID, roleName string) (string, error) {
+	if realm == "" {
+		return "", fmt.Errorf("realm cannot be empty")
+	}
+	if roleName == "" {
+		return "", fmt.Errorf("roleName cannot be empty")
+	}
+	return keycloakRequest("GET", roleURL(serverURL, realm, clientUUID, roleName)+"/composites", jwtToken, nil)
+}
+
+// AddRoleComposites adds roles to a role, making it a composite role; the roles need their IDs
+// Returns the raw response body as string and any error encountered
+func AddRoleComposites(serverURL, jwtToken, realm, clientUUID, roleName string, roles []Role) (string, error) {
+	if realm == "" {
+		return "", fmt.Errorf("realm cannot be empty")
+	}
+	if roleName == "" {
+		return "", fmt.Errorf("roleName cannot be empty")
+	}
+	if len(roles) == 0 {
+		return "", nil
+	}
+	return keycloakRequest("POST", roleURL(serverURL, realm, clientUUID, roleName)+"/composites", jwtToken, roles)
+}
+
+// RemoveRoleComposites removes roles from a composite role; the roles need their IDs
+// Returns the raw response body as string and any error encountered
+func RemoveRoleComposites(serverURL, jwtToken, realm, clientUUID, roleName string, roles []Role) (string, error) {
+	if realm == "" {
+		return "", fmt.Errorf("realm cannot be empty")
+	}
+	if roleName == "" {
+		return "", fmt.Errorf("roleName cannot be empty")
+	}
+	if len(roles) == 0 {
+		return "", nil
+	}
+	return keycloakRequest("DELETE", roleURL(serverURL, realm, clientUUID, roleName)+"/composites", jwtToken, roles)
+}
+
+// RemoveUserRealmRoles removes realm roles from a user in one request
+// Returns the raw response body as string and any error encountered
+func RemoveUserRealmRoles(serverURL, jwtToken, realm, userID string, roles []Role) (string, error) {
+	if userID == "" {
+		return "", fmt.Errorf("userID cannot be empty")
+	}
+	if len(roles) == 0 {
+		return "", nil
+	}
+	return keycloakRequest("DELETE", userURL(serverURL, realm, userID)+"/role-mappings/realm", jwtToken, roles)
+}
+
+// GetUserClientRoles fetches the roles of a client mapped directly to a user
+// Returns the raw response body as string and any error encountered
+func GetUserClientRoles(serverURL, jwtToken, realm, userID, clientUUID string) (string, error) {
+	if userID == "" {
+		return "", fmt.Errorf("userID cannot be empty")
+	}
+	if clientUUID == "" {
+		return "", fmt.Errorf("clientUUID cannot be empty")
+	}
+	return keycloakRequest("GET", userURL(serverURL, realm, userID)+"/role-mappings/clients/"+url.PathEscape(clientUUID), jwtToken, nil)
+}
+
+// AddUserClientRoles maps roles of a client to a user in one request
+// Returns the raw response body as string and any error encountered
+func AddUserClientRoles(serverURL, jwtToken, realm, userID, clientUUID string, roles []Role) (string, error) {
+	if userID == "" {
+		return "", fmt.Errorf("userID cannot be empty")
+	}
+	if clientUUID == "" {
+		return "", fmt.Errorf("clientUUID cannot be empty")
+	}
+	if len(roles) == 0 {
+		return "", nil
+	}
+	return keycloakRequest("POST", userURL(serverURL, realm, userID)+"/role-mappings/clients/"+url.PathEscape(clientUUID), jwtToken, roles)
+}
+
+// RemoveUserClientRoles removes roles of a client from a user in one request
+// Returns the raw response body as string and any error encountered
+func RemoveUserClientRoles(serverURL, jwtToken, realm, userID, clientUUID string, roles []Role) (string, error) {
+	if userID == "" {
+		return "", fmt.Errorf("userID cannot be empty")
+	}
+	if clientUUID == "" {
+		return "", fmt.Errorf("clientUUID cannot be empty")
+	}
+	if len(roles) == 0 {
+		return "", nil
+	}
+	return keycloakRequest("DELETE", userURL(serverURL, realm, userID)+"/role-mappings/clients/"+url.PathEscape(clientUUID), jwtToken, roles)
+}
+
+// Client is a Keycloak client
+type Client struct {
+	// ID is the internal ID used in admin API paths
+	ID string `json:"id"`
+	// ClientID is the name applications use, e.g. auth-server
+	ClientID string `json:"clientId"`
+	Name     string `json:"name,omitempty"`
+	Enabled  bool   `json:"enabled"`
+}
+
+// GetClients fetches the client with the given clientId, or all clients when clientID is empty
+// Returns the raw response body as string and any error encountered
+func GetClients(serverURL, jwtToken, realm, clientID string) (string, error) {
+	if realm == "" {
+		return "", fmt.Errorf("realm cannot be empty")
+	}
+	requestURL := realmURL(serverURL, realm) + "/clients"
+	if clientID != "" {
+		requestURL += "?clientId=" + url.QueryEscape(clientID)
+	}
+	return keycloakRequest("GET", requestURL, jwtToken, nil)
+}
+
+// ParseClients parses the clients of a client list response
+func ParseClients(responseBody string) ([]Client, error) {
+	var clients []Client
+	if err := json.Unmarshal([]byte(responseBody), &clients); err != nil {
+		return nil, fmt.Errorf("failed to parse client response: %w", err)
+	}
+	return clients, nil
+}
+
+// realmURL returns the admin API URL of a realm
+func realmURL(serverURL, realm string) string {
+	return serverURL + "/keycloak/admin/realms/" + url.PathEscape(realm)
+}
+
+// rolesURL returns the admin API URL of the realm roles, or of the roles of a client when clientUUID is set
+func rolesURL(serverURL, realm, clientUUID string) string {
+	if clientUUID == "" {
+		return realmURL(serverURL, realm) + "/roles"
+	}
+	return realmURL(serverURL, realm) + "/clients/" + url.PathEscape(clientUUID) + "/roles"
+}
+
+// roleURL returns the admin API URL of a realm role, or of a client role when clientUUID is set
+func roleURL(serverURL, realm, clientUUID, roleName string) string {
+	return rolesURL(serverURL, realm, clientUUID) + "/" + url.PathEscape(roleName)
+}
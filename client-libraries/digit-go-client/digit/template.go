@@ -68,6 +68,11 @@ func CreateTemplate(serverURL, jwtToken, tenantID, templateID, version, template
 		return "", fmt.Errorf("failed to make API request: %w", err)
 	}
 
+	// Check for successful response
+	if resp.StatusCode() < 200 || resp.StatusCode() >= 300 {
+		return "", fmt.Errorf("failed to create template: HTTP %d - %s", resp.StatusCode(), string(resp.Body()))
+	}
+
 	// Return the raw response body as string
 	return string(resp.Body()), nil
 }
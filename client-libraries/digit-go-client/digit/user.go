@@ -1,8 +1,13 @@
 package digit
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net/url"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/go-resty/resty/v2"
 )
@@ -11,6 +16,8 @@ import (
 type UserRequest struct {
 	Username      string                 `json:"username"`
 	Email         string                 `json:"email"`
+	FirstName     string                 `json:"firstName,omitempty"`
+	LastName      string                 `json:"lastName,omitempty"`
 	Enabled       bool                   `json:"enabled"`
 	EmailVerified bool                   `json:"emailVerified"`
 	Credentials   []UserCredential       `json:"credentials"`
@@ -77,6 +84,11 @@ func CreateUser(serverURL, jwtToken, realm, username, password, email string) (s
 		return "", fmt.Errorf("failed to make API request: %w", err)
 	}
 
+	// Check for successful response
+	if resp.StatusCode() < 200 || resp.StatusCode() >= 300 {
+		return "", fmt.Errorf("failed to create user: HTTP %d - %s", resp.StatusCode(), string(resp.Body()))
+	}
+
 	// Return the raw response body as string
 	return string(resp.Body()), nil
 }
@@ -409,6 +421,11 @@ func CreateRole(serverURL, jwtToken, realm, roleName, description string) (strin
 		return "", fmt.Errorf("failed to make API request: %w", err)
 	}
 
+	// Check for successful response
+	if resp.StatusCode() < 200 || resp.StatusCode() >= 300 {
+		return "", fmt.Errorf("failed to create role: HTTP %d - %s", resp.StatusCode(), string(resp.Body()))
+	}
+
 	// Return the raw response body as string
 	return string(resp.Body()), nil
 }
@@ -498,6 +515,297 @@ func AssignRoleToUser(serverURL, jwtToken, realm, username, roleName string) (st
 		return "", fmt.Errorf("failed to assign role to user: %w", err)
 	}
 
+	// Check for successful response
+	if resp.StatusCode() < 200 || resp.StatusCode() >= 300 {
+		return "", fmt.Errorf("failed to assign role: HTTP %d - %s", resp.StatusCode(), string(resp.Body()))
+	}
+
+	// Return the raw response body as string
+	return string(resp.Body()), nil
+}
+
+// User is a Keycloak user as returned by the admin API
+type User struct {
+	ID               string              `json:"id"`
+	Username         string              `json:"username"`
+	Email            string              `json:"email,omitempty"`
+	FirstName        string              `json:"firstName,omitempty"`
+	LastName         string              `json:"lastName,omitempty"`
+	Enabled          bool                `json:"enabled"`
+	EmailVerified    bool                `json:"emailVerified"`
+	CreatedTimestamp int64               `json:"createdTimestamp,omitempty"`
+	Attributes       map[string][]string `json:"attributes,omitempty"`
+	RequiredActions  []string            `json:"requiredActions,omitempty"`
+}
+
+// ParseUsers parses the users of a user search response
+func ParseUsers(responseBody string) ([]User, error) {
+	var users []User
+	if err := json.Unmarshal([]byte(responseBody), &users); err != nil {
+		return nil, fmt.Errorf("failed to parse user response: %w", err)
+	}
+	return users, nil
+}
+
+// Created returns the creation time of the user, or the zero time when it is not known
+func (u User) Created() time.Time {
+	if u.CreatedTimestamp == 0 {
+		return time.Time{}
+	}
+	return time.UnixMilli(u.CreatedTimestamp)
+}
+
+// UserQuery holds the filters and page of a user search
+type UserQuery struct {
+	// Username matches usernames containing it, or only the exact username when Exact is set
+	Username string
+	Exact    bool
+	// Email matches email addresses containing it
+	Email string
+	// Search matches usernames, emails and names containing it
+	Search string
+	// Enabled matches only enabled or only disabled users when set
+	Enabled *bool
+	// Role matches only the users the role is mapped to directly; it is a role of the
+	// client with the ID Client when that is set, else a realm role
+	Role   string
+	Client string
+	// CreatedAfter and CreatedBefore match users created in the period when set
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+	// First and Max page through the results; Max 0 uses the Keycloak default of 100
+	First int
+	Max   int
+}
+
+// ServerFiltered reports whether Keycloak applies all the filters of the query, so that
+// First and Max page through the matching users. The creation period, and the other filters
+// together with Role, are applied by EachUser to the users of each page instead.
+func (q UserQuery) ServerFiltered() bool {
+	if !q.CreatedAfter.IsZero() || !q.CreatedBefore.IsZero() {
+		return false
+	}
+	return q.Role == "" || (q.Username == "" && q.Email == "" && q.Search == "" && q.Enabled == nil)
+}
+
+// Matches reports whether a user matches all the filters of the query except Role
+func (q UserQuery) Matches(user User) bool {
+	contains := func(value, part string) bool {
+		return strings.Contains(strings.ToLower(value), strings.ToLower(part))
+	}
+	if q.Username != "" {
+		if q.Exact && !strings.EqualFold(user.Username, q.Username) || !q.Exact && !contains(user.Username, q.Username) {
+			return false
+		}
+	}
+	if q.Email != "" && !contains(user.Email, q.Email) {
+		return false
+	}
+	search := strings.Trim(q.Search, "*")
+	if search != "" && !contains(user.Username, search) && !contains(user.Email, search) &&
+		!contains(user.FirstName, search) && !contains(user.LastName, search) {
+		return false
+	}
+	if q.Enabled != nil && user.Enabled != *q.Enabled {
+		return false
+	}
+	created := user.Created()
+	if !q.CreatedAfter.IsZero() && created.Before(q.CreatedAfter) {
+		return false
+	}
+	if !q.CreatedBefore.IsZero() && !created.Before(q.CreatedBefore) {
+		return false
+	}
+	return true
+}
+
+// SearchUsers fetches a page of the users of a realm. With Role set the page holds the
+// users of the role and the other filters are not applied; see EachUser.
+// Returns the raw response body as string and any error encountered
+func SearchUsers(serverURL, jwtToken, realm string, query UserQuery) (string, error) {
+	if realm == "" {
+		return "", fmt.Errorf("realm cannot be empty")
+	}
+	params := url.Values{}
+	if query.Role == "" {
+		if query.Username != "" {
+			params.Set("username", query.Username)
+		}
+		if query.Exact {
+			params.Set("exact", "true")
+		}
+		if query.Email != "" {
+			params.Set("email", query.Email)
+		}
+		if query.Search != "" {
+			// Keycloak matches search prefixes unless the value is wrapped in *
+			params.Set("search", "*"+strings.Trim(query.Search, "*")+"*")
+		}
+		if query.Enabled != nil {
+			params.Set("enabled", strconv.FormatBool(*query.Enabled))
+		}
+	}
+	if query.First > 0 {
+		params.Set("first", strconv.Itoa(query.First))
+	}
+	if query.Max > 0 {
+		params.Set("max", strconv.Itoa(query.Max))
+	}
+	requestURL := realmURL(serverURL, realm) + "/users"
+	if query.Role != "" {
+		requestURL = roleURL(serverURL, realm, query.Client, query.Role) + "/users"
+	}
+	if len(params) > 0 {
+		requestURL += "?" + params.Encode()
+	}
+	return keycloakRequest("GET", requestURL, jwtToken, nil)
+}
+
+// ErrStop is returned by the function passed to EachUser to stop early without an error
+var ErrStop = errors.New("stop")
+
+// EachUser calls fn for every user matching the query, fetching the users a page of
+// query.Max (default 100) at a time from query.First on, so that large realms are never
+// held in memory at once. Filters Keycloak cannot apply are applied to each page.
+// It stops at the first error of fn; ErrStop stops without an error.
+func EachUser(serverURL, jwtToken, realm string, query UserQuery, fn func(User) error) error {
+	if query.Max <= 0 {
+		query.Max = 100
+	}
+	for {
+		responseBody, err := SearchUsers(serverURL, jwtToken, realm, query)
+		if err != nil {
+			return err
+		}
+		users, err := ParseUsers(responseBody)
+		if err != nil {
+			return err
+		}
+		for _, user := range users {
+			if !query.Matches(user) {
+				continue
+			}
+			if err := fn(user); err != nil {
+				if errors.Is(err, ErrStop) {
+					return nil
+				}
+				return err
+			}
+		}
+		if len(users) < query.Max {
+			return nil
+		}
+		query.First += len(users)
+	}
+}
+
+// CountUsers counts the users of a realm
+// Returns the raw response body as string and any error encountered
+func CountUsers(serverURL, jwtToken, realm string) (string, error) {
+	if realm == "" {
+		return "", fmt.Errorf("realm cannot be empty")
+	}
+	return keycloakRequest("GET", realmURL(serverURL, realm)+"/users/count", jwtToken, nil)
+}
+
+// UpdateUserByID replaces the given fields of a user; empty fields are left unchanged
+// Returns the raw response body as string and any error encountered
+func UpdateUserByID(serverURL, jwtToken, realm, userID string, update UserUpdateRequest) (string, error) {
+	if realm == "" {
+		return "", fmt.Errorf("realm cannot be empty")
+	}
+	if userID == "" {
+		return "", fmt.Errorf("userID cannot be empty")
+	}
+	return keycloakRequest("PUT", userURL(serverURL, realm, userID), jwtToken, update)
+}
+
+// CreateUserFromRequest creates a user in Keycloak with all the details of the request,
+// such as names, enabled state and a temporary password
+// Returns the raw response body as string and any error encountered
+func CreateUserFromRequest(serverURL, jwtToken, realm string, user UserRequest) (string, error) {
+	if realm == "" {
+		return "", fmt.Errorf("realm cannot be empty")
+	}
+	if user.Username == "" {
+		return "", fmt.Errorf("username cannot be empty")
+	}
+	if user.Attributes == nil {
+		user.Attributes = make(map[string]interface{})
+	}
+	return keycloakRequest("POST", realmURL(serverURL, realm)+"/users", jwtToken, user)
+}
+
+// GetUserRealmRoles fetches the realm roles mapped directly to a user
+// Returns the raw response body as string and any error encountered
+func GetUserRealmRoles(serverURL, jwtToken, realm, userID string) (string, error) {
+	if userID == "" {
+		return "", fmt.Errorf("userID cannot be empty")
+	}
+	return keycloakRequest("GET", userURL(serverURL, realm, userID)+"/role-mappings/realm", jwtToken, nil)
+}
+
+// GetUserEffectiveRealmRoles fetches all the realm roles of a user, including those of
+// composite roles and groups
+// Returns the raw response body as string and any error encountered
+func GetUserEffectiveRealmRoles(serverURL, jwtToken, realm, userID string) (string, error) {
+	if userID == "" {
+		return "", fmt.Errorf("userID cannot be empty")
+	}
+	return keycloakRequest("GET", userURL(serverURL, realm, userID)+"/role-mappings/realm/composite", jwtToken, nil)
+}
+
+// AddUserRealmRoles maps realm roles to a user in one request; roles already mapped are left as they are
+// Returns the raw response body as string and any error encountered
+func AddUserRealmRoles(serverURL, jwtToken, realm, userID string, roles []Role) (string, error) {
+	if userID == "" {
+		return "", fmt.Errorf("userID cannot be empty")
+	}
+	if len(roles) == 0 {
+		return "", nil
+	}
+	return keycloakRequest("POST", userURL(serverURL, realm, userID)+"/role-mappings/realm", jwtToken, roles)
+}
+
+// userURL returns the admin API URL of a user
+func userURL(serverURL, realm, userID string) string {
+	return realmURL(serverURL, realm) + "/users/" + url.PathEscape(userID)
+}
+
+// keycloakRequest sends a request to the Keycloak admin API, with body as JSON when not nil
+func keycloakRequest(method, requestURL, jwtToken string, body interface{}) (string, error) {
+	if jwtToken == "" {
+		return "", fmt.Errorf("jwtToken cannot be empty")
+	}
+
+	// Create HTTP client
+	client := resty.New()
+
+	req := client.R().
+		SetHeader("Authorization", "Bearer "+jwtToken)
+	if body != nil {
+		req.SetHeader("Content-Type", "application/json").SetBody(body)
+	}
+	resp, err := req.Execute(method, requestURL)
+	if err != nil {
+		return "", fmt.Errorf("failed to make API request: %w", err)
+	}
+
+	// Check for successful response
+	if resp.StatusCode() < 200 || resp.StatusCode() >= 300 {
+		return "", &KeycloakError{StatusCode: resp.StatusCode(), Body: string(resp.Body())}
+	}
+
 	// Return the raw response body as string
 	return string(resp.Body()), nil
 }
+
+// KeycloakError is an error response of the Keycloak admin API
+type KeycloakError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *KeycloakError) Error() string {
+	return fmt.Sprintf("API request failed: HTTP %d - %s", e.StatusCode, e.Body)
+}
@@ -0,0 +1,77 @@
+package digit
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// UserSession is an active login session of a user
+type UserSession struct {
+	ID        string `json:"id"`
+	Username  string `json:"username"`
+	UserID    string `json:"userId"`
+	IPAddress string `json:"ipAddress"`
+	// Start and LastAccess are Unix times in milliseconds
+	Start      int64 `json:"start"`
+	LastAccess int64 `json:"lastAccess"`
+	RememberMe bool  `json:"rememberMe"`
+	// Clients maps the IDs of the clients the session is logged in to to their clientIds
+	Clients map[string]string `json:"clients,omitempty"`
+}
+
+// ParseUserSessions parses the sessions of a user session response
+func ParseUserSessions(responseBody string) ([]UserSession, error) {
+	var sessions []UserSession
+	if err := json.Unmarshal([]byte(responseBody), &sessions); err != nil {
+		return nil, fmt.Errorf("failed to parse session response: %w", err)
+	}
+	return sessions, nil
+}
+
+// Started returns the time the session started
+func (s UserSession) Started() time.Time {
+	return time.UnixMilli(s.Start)
+}
+
+// LastAccessed returns the time the session was last used
+func (s UserSession) LastAccessed() time.Time {
+	return time.UnixMilli(s.LastAccess)
+}
+
+// GetUserSessions fetches the active sessions of a user
+// Returns the raw response body as string and any error encountered
+func GetUserSessions(serverURL, jwtToken, realm, userID string) (string, error) {
+	if realm == "" {
+		return "", fmt.Errorf("realm cannot be empty")
+	}
+	if userID == "" {
+		return "", fmt.Errorf("userID cannot be empty")
+	}
+	return keycloakRequest("GET", userURL(serverURL, realm, userID)+"/sessions", jwtToken, nil)
+}
+
+// LogoutUser ends all the sessions of a user, logging them out everywhere
+// Returns the raw response body as string and any error encountered
+func LogoutUser(serverURL, jwtToken, realm, userID string) (string, error) {
+	if realm == "" {
+		return "", fmt.Errorf("realm cannot be empty")
+	}
+	if userID == "" {
+		return "", fmt.Errorf("userID cannot be empty")
+	}
+	return keycloakRequest("POST", userURL(serverURL, realm, userID)+"/logout", jwtToken, nil)
+}
+
+// DeleteSession ends one session
+// Returns the raw response body as string and any error encountered
+func DeleteSession(serverURL, jwtToken, realm, sessionID string) (string, error) {
+	if realm == "" {
+		return "", fmt.Errorf("realm cannot be empty")
+	}
+	if sessionID == "" {
+		return "", fmt.Errorf("sessionID cannot be empty")
+	}
+	return keycloakRequest("DELETE", realmURL(serverURL, realm)+"/sessions/"+url.PathEscape(sessionID), jwtToken, nil)
+}
@@ -0,0 +1,246 @@
+// Package geometry provides typed GeoJSON models for boundary geometries, together with
+// validation, repair and simplification of the polygons that boundaries are made of.
+package geometry
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Geometry types supported by GeoJSON
+const (
+	TypePoint              = "Point"
+	TypeMultiPoint         = "MultiPoint"
+	TypeLineString         = "LineString"
+	TypeMultiLineString    = "MultiLineString"
+	TypePolygon            = "Polygon"
+	TypeMultiPolygon       = "MultiPolygon"
+	TypeGeometryCollection = "GeometryCollection"
+)
+
+// Position is a longitude/latitude pair. Altitudes are dropped when decoding.
+type Position [2]float64
+
+// Ring is a sequence of positions: a polygon ring, a line string or the points of a MultiPoint
+type Ring []Position
+
+// Polygon is a list of rings; the first is the exterior ring and any others are holes
+type Polygon []Ring
+
+// Geometry is a GeoJSON geometry object. Only the field matching Type is used.
+type Geometry struct {
+	Type         string
+	Point        Position   // Point
+	Positions    Ring       // MultiPoint, LineString
+	Lines        []Ring     // MultiLineString
+	Polygon      Polygon    // Polygon
+	MultiPolygon []Polygon  // MultiPolygon
+	Geometries   []Geometry // GeometryCollection
+}
+
+// Feature is a GeoJSON feature
+type Feature struct {
+	ID         interface{}            `json:"id,omitempty"`
+	Geometry   *Geometry              `json:"geometry"`
+	Properties map[string]interface{} `json:"properties"`
+}
+
+// FeatureCollection is a GeoJSON feature collection
+type FeatureCollection struct {
+	Features []Feature `json:"features"`
+}
+
+// UnmarshalJSON decodes a position, keeping only its longitude and latitude
+func (p *Position) UnmarshalJSON(data []byte) error {
+	var values []float64
+	if err := json.Unmarshal(data, &values); err != nil {
+		return fmt.Errorf("invalid position %s", data)
+	}
+	if len(values) < 2 {
+		return fmt.Errorf("position %s needs a longitude and a latitude", data)
+	}
+	p[0], p[1] = values[0], values[1]
+	return nil
+}
+
+// MarshalJSON encodes the geometry as a GeoJSON geometry object
+func (g Geometry) MarshalJSON() ([]byte, error) {
+	var coordinates interface{}
+	switch g.Type {
+	case TypePoint:
+		coordinates = g.Point
+	case TypeMultiPoint, TypeLineString:
+		coordinates = g.Positions
+	case TypeMultiLineString:
+		coordinates = g.Lines
+	case TypePolygon:
+		coordinates = g.Polygon
+	case TypeMultiPolygon:
+		coordinates = g.MultiPolygon
+	case TypeGeometryCollection:
+		return json.Marshal(struct {
+			Type       string     `json:"type"`
+			Geometries []Geometry `json:"geometries"`
+		}{g.Type, g.Geometries})
+	default:
+		return nil, fmt.Errorf("unsupported geometry type %q", g.Type)
+	}
+	return json.Marshal(struct {
+		Type        string      `json:"type"`
+		Coordinates interface{} `json:"coordinates"`
+	}{g.Type, coordinates})
+}
+
+// UnmarshalJSON decodes a GeoJSON geometry object
+func (g *Geometry) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Type        string          `json:"type"`
+		Coordinates json.RawMessage `json:"coordinates"`
+		Geometries  []Geometry      `json:"geometries"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	*g = Geometry{Type: raw.Type}
+	var target interface{}
+	switch raw.Type {
+	case TypePoint:
+		target = &g.Point
+	case TypeMultiPoint, TypeLineString:
+		target = &g.Positions
+	case TypeMultiLineString:
+		target = &g.Lines
+	case TypePolygon:
+		target = &g.Polygon
+	case TypeMultiPolygon:
+		target = &g.MultiPolygon
+	case TypeGeometryCollection:
+		g.Geometries = raw.Geometries
+		return nil
+	case "":
+		return fmt.Errorf("geometry has no type")
+	default:
+		return fmt.Errorf("unsupported geometry type %q", raw.Type)
+	}
+	if len(raw.Coordinates) == 0 {
+		return fmt.Errorf("%s geometry has no coordinates", raw.Type)
+	}
+	if err := json.Unmarshal(raw.Coordinates, target); err != nil {
+		return fmt.Errorf("invalid %s coordinates: %w", raw.Type, err)
+	}
+	return nil
+}
+
+// FromValue converts a decoded GeoJSON geometry, such as a map read from YAML or JSON, to a Geometry
+func FromValue(value interface{}) (*Geometry, error) {
+	if value == nil {
+		return nil, fmt.Errorf("geometry is missing")
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid geometry: %w", err)
+	}
+	var g Geometry
+	if err := json.Unmarshal(data, &g); err != nil {
+		return nil, err
+	}
+	return &g, nil
+}
+
+// ParseFeatures parses a GeoJSON FeatureCollection, a single Feature or a list of features
+func ParseFeatures(data []byte) ([]Feature, error) {
+	var probe interface{}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return nil, fmt.Errorf("failed to parse GeoJSON: %w", err)
+	}
+
+	var features []Feature
+	switch v := probe.(type) {
+	case []interface{}:
+		if err := json.Unmarshal(data, &features); err != nil {
+			return nil, fmt.Errorf("failed to parse GeoJSON features: %w", err)
+		}
+	case map[string]interface{}:
+		switch v["type"] {
+		case "FeatureCollection":
+			var collection FeatureCollection
+			if err := json.Unmarshal(data, &collection); err != nil {
+				return nil, fmt.Errorf("failed to parse GeoJSON features: %w", err)
+			}
+			features = collection.Features
+		case "Feature":
+			var feature Feature
+			if err := json.Unmarshal(data, &feature); err != nil {
+				return nil, fmt.Errorf("failed to parse GeoJSON feature: %w", err)
+			}
+			features = []Feature{feature}
+		default:
+			return nil, fmt.Errorf("GeoJSON is a %v, expected a FeatureCollection or Feature", v["type"])
+		}
+	default:
+		return nil, fmt.Errorf("unexpected GeoJSON document")
+	}
+	return features, nil
+}
+
+// MarshalJSON encodes the collection as a GeoJSON FeatureCollection
+func (c FeatureCollection) MarshalJSON() ([]byte, error) {
+	features := c.Features
+	if features == nil {
+		features = []Feature{}
+	}
+	return json.Marshal(struct {
+		Type     string    `json:"type"`
+		Features []Feature `json:"features"`
+	}{"FeatureCollection", features})
+}
+
+// MarshalJSON encodes the feature as a GeoJSON Feature
+func (f Feature) MarshalJSON() ([]byte, error) {
+	properties := f.Properties
+	if properties == nil {
+		properties = map[string]interface{}{}
+	}
+	return json.Marshal(struct {
+		Type       string                 `json:"type"`
+		ID         interface{}            `json:"id,omitempty"`
+		Geometry   *Geometry              `json:"geometry"`
+		Properties map[string]interface{} `json:"properties"`
+	}{"Feature", f.ID, f.Geometry, properties})
+}
+
+// VertexCount returns the number of positions in the geometry
+func (g *Geometry) VertexCount() int {
+	switch g.Type {
+	case TypePoint:
+		return 1
+	case TypeMultiPoint, TypeLineString:
+		return len(g.Positions)
+	case TypeMultiLineString:
+		return countPositions(g.Lines)
+	case TypePolygon:
+		return countPositions(g.Polygon)
+	case TypeMultiPolygon:
+		count := 0
+		for _, polygon := range g.MultiPolygon {
+			count += countPositions(polygon)
+		}
+		return count
+	case TypeGeometryCollection:
+		count := 0
+		for i := range g.Geometries {
+			count += g.Geometries[i].VertexCount()
+		}
+		return count
+	}
+	return 0
+}
+
+func countPositions(rings []Ring) int {
+	count := 0
+	for _, ring := range rings {
+		count += len(ring)
+	}
+	return count
+}
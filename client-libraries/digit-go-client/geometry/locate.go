@@ -0,0 +1,186 @@
+package geometry
+
+import (
+	"math"
+)
+
+// Contains reports whether a geometry contains a position. A position on the outline of a
+// polygon, including the outline of a hole, counts as contained; points and lines contain
+// only the positions lying on them.
+func Contains(g *Geometry, p Position) bool {
+	switch g.Type {
+	case TypePoint:
+		return g.Point == p
+	case TypeMultiPoint:
+		for _, point := range g.Positions {
+			if point == p {
+				return true
+			}
+		}
+	case TypeLineString:
+		return onLine(g.Positions, p)
+	case TypeMultiLineString:
+		for _, line := range g.Lines {
+			if onLine(line, p) {
+				return true
+			}
+		}
+	case TypePolygon:
+		return polygonContains(g.Polygon, p)
+	case TypeMultiPolygon:
+		for _, polygon := range g.MultiPolygon {
+			if polygonContains(polygon, p) {
+				return true
+			}
+		}
+	case TypeGeometryCollection:
+		for i := range g.Geometries {
+			if Contains(&g.Geometries[i], p) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func polygonContains(polygon Polygon, p Position) bool {
+	if len(polygon) == 0 {
+		return false
+	}
+	for _, ring := range polygon {
+		if onLine(ring, p) {
+			return true
+		}
+	}
+	if !ringContains(polygon[0], p) {
+		return false
+	}
+	for _, hole := range polygon[1:] {
+		if ringContains(hole, p) {
+			return false
+		}
+	}
+	return true
+}
+
+// ringContains reports whether a position lies inside a ring, by ray casting; the ring need not be closed
+func ringContains(ring Ring, p Position) bool {
+	inside := false
+	for i, j := 0, len(ring)-1; i < len(ring); j, i = i, i+1 {
+		xi, yi := ring[i][0], ring[i][1]
+		xj, yj := ring[j][0], ring[j][1]
+		if (yi > p[1]) != (yj > p[1]) && p[0] < (xj-xi)*(p[1]-yi)/(yj-yi)+xi {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+func onLine(line Ring, p Position) bool {
+	for i := 0; i+1 < len(line); i++ {
+		if orientation(line[i], line[i+1], p) == 0 && onSegment(line[i], line[i+1], p) {
+			return true
+		}
+	}
+	return len(line) == 1 && line[0] == p
+}
+
+// Distance returns the distance in metres from a position to a geometry: zero when the geometry
+// contains it, otherwise the distance to its nearest outline, line or point. Distances are measured
+// in an equirectangular projection around the position, which is accurate for nearby geometries.
+func Distance(g *Geometry, p Position) float64 {
+	if Contains(g, p) {
+		return 0
+	}
+
+	scaleX := earthRadius * math.Pi / 180 * math.Cos(p[1]*math.Pi/180)
+	scaleY := earthRadius * math.Pi / 180
+	project := func(q Position) [2]float64 {
+		return [2]float64{(q[0] - p[0]) * scaleX, (q[1] - p[1]) * scaleY}
+	}
+
+	nearest := math.Inf(1)
+	measure := func(line Ring) {
+		for i := range line {
+			a := project(line[i])
+			b := a
+			if i+1 < len(line) {
+				b = project(line[i+1])
+			}
+			nearest = math.Min(nearest, distanceToSegment([2]float64{}, a, b))
+		}
+	}
+	switch g.Type {
+	case TypePoint:
+		measure(Ring{g.Point})
+	case TypeMultiPoint:
+		for _, point := range g.Positions {
+			measure(Ring{point})
+		}
+	case TypeLineString:
+		measure(g.Positions)
+	case TypeMultiLineString:
+		for _, line := range g.Lines {
+			measure(line)
+		}
+	case TypePolygon:
+		for _, ring := range g.Polygon {
+			measure(ring)
+		}
+	case TypeMultiPolygon:
+		for _, polygon := range g.MultiPolygon {
+			for _, ring := range polygon {
+				measure(ring)
+			}
+		}
+	case TypeGeometryCollection:
+		for i := range g.Geometries {
+			nearest = math.Min(nearest, Distance(&g.Geometries[i], p))
+		}
+	}
+	return nearest
+}
+
+// Bounds returns the south-west and north-east corners of the geometry's bounding box;
+// ok is false when the geometry has no positions
+func (g *Geometry) Bounds() (min, max Position, ok bool) {
+	min = Position{math.Inf(1), math.Inf(1)}
+	max = Position{math.Inf(-1), math.Inf(-1)}
+	extend := func(positions Ring) {
+		for _, p := range positions {
+			min = Position{math.Min(min[0], p[0]), math.Min(min[1], p[1])}
+			max = Position{math.Max(max[0], p[0]), math.Max(max[1], p[1])}
+			ok = true
+		}
+	}
+	switch g.Type {
+	case TypePoint:
+		extend(Ring{g.Point})
+	case TypeMultiPoint, TypeLineString:
+		extend(g.Positions)
+	case TypeMultiLineString:
+		for _, line := range g.Lines {
+			extend(line)
+		}
+	case TypePolygon:
+		for _, ring := range g.Polygon {
+			extend(ring)
+		}
+	case TypeMultiPolygon:
+		for _, polygon := range g.MultiPolygon {
+			for _, ring := range polygon {
+				extend(ring)
+			}
+		}
+	case TypeGeometryCollection:
+		for i := range g.Geometries {
+			if lo, hi, found := g.Geometries[i].Bounds(); found {
+				extend(Ring{lo, hi})
+			}
+		}
+	}
+	if !ok {
+		return Position{}, Position{}, false
+	}
+	return min, max, true
+}
@@ -0,0 +1,167 @@
+package geometry
+
+import (
+	"fmt"
+	"math"
+)
+
+// Repair fixes the repairable issues of a geometry in place: repeated consecutive positions are
+// removed, polygon rings are closed and their winding order is corrected to the RFC 7946 right-hand
+// rule. It returns a description of each change; other issues are left for Validate to report.
+func Repair(g *Geometry) []string {
+	return repair(g, "")
+}
+
+func repair(g *Geometry, path string) []string {
+	var changes []string
+	switch g.Type {
+	case TypePolygon:
+		changes = repairPolygon(g.Polygon, path)
+	case TypeMultiPolygon:
+		for i := range g.MultiPolygon {
+			changes = append(changes, repairPolygon(g.MultiPolygon[i], join(path, fmt.Sprintf("polygon %d", i+1)))...)
+		}
+	case TypeGeometryCollection:
+		for i := range g.Geometries {
+			changes = append(changes, repair(&g.Geometries[i], join(path, fmt.Sprintf("geometry %d", i+1)))...)
+		}
+	}
+	return changes
+}
+
+func repairPolygon(polygon Polygon, path string) []string {
+	var changes []string
+	for i, ring := range polygon {
+		ringPath := join(path, fmt.Sprintf("ring %d", i+1))
+		if i > 0 {
+			ringPath += " (hole)"
+		}
+
+		if repeats := countRepeats(ring); repeats > 0 {
+			deduplicated := Ring{ring[0]}
+			for _, p := range ring[1:] {
+				if p != deduplicated[len(deduplicated)-1] {
+					deduplicated = append(deduplicated, p)
+				}
+			}
+			ring = deduplicated
+			changes = append(changes, fmt.Sprintf("%s: removed %d repeated position(s)", ringPath, repeats))
+		}
+		if len(ring) > 0 && ring[0] != ring[len(ring)-1] {
+			ring = append(ring, ring[0])
+			changes = append(changes, ringPath+": closed the ring")
+		}
+		area := signedArea(ring)
+		if (i == 0 && area < 0) || (i > 0 && area > 0) {
+			reverse(ring)
+			changes = append(changes, ringPath+": reversed the winding order")
+		}
+		polygon[i] = ring
+	}
+	return changes
+}
+
+func reverse(ring Ring) {
+	for i, j := 0, len(ring)-1; i < j; i, j = i+1, j-1 {
+		ring[i], ring[j] = ring[j], ring[i]
+	}
+}
+
+// earthRadius is the mean radius of the earth in metres
+const earthRadius = 6371008.8
+
+// Simplify reduces the number of positions of the lines and polygon rings of a geometry in place
+// with the Douglas–Peucker algorithm. Tolerance is the largest distance in metres that a removed
+// position may lie from the simplified outline. Rings keep at least four positions and stay closed;
+// a ring that would collapse is left unchanged. Simplifying can make rings intersect, so the result
+// should be validated again.
+func Simplify(g *Geometry, tolerance float64) {
+	if tolerance <= 0 {
+		return
+	}
+	switch g.Type {
+	case TypeLineString:
+		g.Positions = simplifyLine(g.Positions, tolerance, 2)
+	case TypeMultiLineString:
+		for i := range g.Lines {
+			g.Lines[i] = simplifyLine(g.Lines[i], tolerance, 2)
+		}
+	case TypePolygon:
+		simplifyPolygon(g.Polygon, tolerance)
+	case TypeMultiPolygon:
+		for _, polygon := range g.MultiPolygon {
+			simplifyPolygon(polygon, tolerance)
+		}
+	case TypeGeometryCollection:
+		for i := range g.Geometries {
+			Simplify(&g.Geometries[i], tolerance)
+		}
+	}
+}
+
+func simplifyPolygon(polygon Polygon, tolerance float64) {
+	for i, ring := range polygon {
+		if len(ring) > 0 && ring[0] == ring[len(ring)-1] {
+			polygon[i] = simplifyLine(ring, tolerance, 4)
+		}
+	}
+}
+
+// simplifyLine runs Douglas–Peucker on a line, measuring distances in an equirectangular projection
+// around the line's first position. The result is kept only if it has at least minPositions.
+func simplifyLine(line Ring, tolerance float64, minPositions int) Ring {
+	if len(line) <= minPositions {
+		return line
+	}
+
+	scaleX := earthRadius * math.Pi / 180 * math.Cos(line[0][1]*math.Pi/180)
+	scaleY := earthRadius * math.Pi / 180
+	projected := make([][2]float64, len(line))
+	for i, p := range line {
+		projected[i] = [2]float64{p[0] * scaleX, p[1] * scaleY}
+	}
+
+	keep := make([]bool, len(line))
+	keep[0], keep[len(line)-1] = true, true
+	// An explicit stack avoids deep recursion on rings with many thousands of positions
+	stack := [][2]int{{0, len(line) - 1}}
+	for len(stack) > 0 {
+		span := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		first, last := span[0], span[1]
+
+		farthest, maxDistance := -1, 0.0
+		for i := first + 1; i < last; i++ {
+			if d := distanceToSegment(projected[i], projected[first], projected[last]); d > maxDistance {
+				farthest, maxDistance = i, d
+			}
+		}
+		if farthest >= 0 && maxDistance > tolerance {
+			keep[farthest] = true
+			stack = append(stack, [2]int{first, farthest}, [2]int{farthest, last})
+		}
+	}
+
+	simplified := make(Ring, 0, len(line))
+	for i, p := range line {
+		if keep[i] {
+			simplified = append(simplified, p)
+		}
+	}
+	if len(simplified) < minPositions {
+		return line
+	}
+	return simplified
+}
+
+// distanceToSegment returns the distance from p to the segment ab; a closed ring's first
+// and last positions are equal, in which case it is the distance to that point
+func distanceToSegment(p, a, b [2]float64) float64 {
+	dx, dy := b[0]-a[0], b[1]-a[1]
+	if dx == 0 && dy == 0 {
+		return math.Hypot(p[0]-a[0], p[1]-a[1])
+	}
+	t := ((p[0]-a[0])*dx + (p[1]-a[1])*dy) / (dx*dx + dy*dy)
+	t = math.Max(0, math.Min(1, t))
+	return math.Hypot(p[0]-(a[0]+t*dx), p[1]-(a[1]+t*dy))
+}
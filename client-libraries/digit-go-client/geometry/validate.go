@@ -0,0 +1,284 @@
+package geometry
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Severity tells whether an issue makes a geometry invalid
+type Severity int
+
+const (
+	// Warning issues are tolerated by GeoJSON readers, such as rings with the wrong winding order
+	Warning Severity = iota
+	// Error issues make the geometry invalid
+	Error
+)
+
+func (s Severity) String() string {
+	if s == Error {
+		return "error"
+	}
+	return "warning"
+}
+
+// Issue is a problem found in a geometry
+type Issue struct {
+	Severity   Severity
+	Path       string // the part of the geometry, e.g. "polygon 2, ring 1"
+	Message    string
+	Repairable bool // whether Repair fixes it
+}
+
+func (i Issue) String() string {
+	if i.Path == "" {
+		return i.Message
+	}
+	return i.Path + ": " + i.Message
+}
+
+// Validate checks a geometry: positions must be valid longitudes and latitudes, polygon rings must be
+// closed with at least four positions, must not intersect themselves or cross other rings of the
+// polygon, and should follow the right-hand rule of RFC 7946 (exterior rings counter-clockwise,
+// holes clockwise). Repeated consecutive positions are reported as warnings.
+func Validate(g *Geometry) []Issue {
+	return validate(g, "")
+}
+
+func validate(g *Geometry, path string) []Issue {
+	var issues []Issue
+	switch g.Type {
+	case TypePoint:
+		issues = checkRange(Ring{g.Point}, path)
+	case TypeMultiPoint:
+		issues = checkRange(g.Positions, path)
+	case TypeLineString:
+		issues = checkLine(g.Positions, path)
+	case TypeMultiLineString:
+		for i, line := range g.Lines {
+			issues = append(issues, checkLine(line, join(path, fmt.Sprintf("line %d", i+1)))...)
+		}
+	case TypePolygon:
+		issues = checkPolygon(g.Polygon, path)
+	case TypeMultiPolygon:
+		if len(g.MultiPolygon) == 0 {
+			issues = append(issues, Issue{Severity: Error, Path: path, Message: "multipolygon has no polygons"})
+		}
+		for i, polygon := range g.MultiPolygon {
+			issues = append(issues, checkPolygon(polygon, join(path, fmt.Sprintf("polygon %d", i+1)))...)
+		}
+	case TypeGeometryCollection:
+		for i := range g.Geometries {
+			issues = append(issues, validate(&g.Geometries[i], join(path, fmt.Sprintf("geometry %d", i+1)))...)
+		}
+	default:
+		issues = append(issues, Issue{Severity: Error, Path: path, Message: fmt.Sprintf("unsupported geometry type %q", g.Type)})
+	}
+	return issues
+}
+
+// HasErrors reports whether any of the issues is an error
+func HasErrors(issues []Issue) bool {
+	for _, issue := range issues {
+		if issue.Severity == Error {
+			return true
+		}
+	}
+	return false
+}
+
+func join(path, part string) string {
+	if path == "" {
+		return part
+	}
+	return path + ", " + part
+}
+
+func checkRange(positions Ring, path string) []Issue {
+	for _, p := range positions {
+		lon, lat := p[0], p[1]
+		if math.IsNaN(lon) || math.IsNaN(lat) || lon < -180 || lon > 180 || lat < -90 || lat > 90 {
+			return []Issue{{Severity: Error, Path: path, Message: fmt.Sprintf("position (%g, %g) is not a valid longitude/latitude; are the coordinates projected or swapped?", lon, lat)}}
+		}
+	}
+	return nil
+}
+
+func checkLine(line Ring, path string) []Issue {
+	issues := checkRange(line, path)
+	if len(line) < 2 {
+		issues = append(issues, Issue{Severity: Error, Path: path, Message: fmt.Sprintf("line has %d positions, at least 2 are required", len(line))})
+	}
+	return issues
+}
+
+func checkPolygon(polygon Polygon, path string) []Issue {
+	if len(polygon) == 0 {
+		return []Issue{{Severity: Error, Path: path, Message: "polygon has no rings"}}
+	}
+
+	var issues []Issue
+	for i, ring := range polygon {
+		ringPath := join(path, fmt.Sprintf("ring %d", i+1))
+		if i > 0 {
+			ringPath += " (hole)"
+		}
+		issues = append(issues, checkRange(ring, ringPath)...)
+
+		if repeats := countRepeats(ring); repeats > 0 {
+			issues = append(issues, Issue{Severity: Warning, Path: ringPath, Message: fmt.Sprintf("%d repeated consecutive position(s)", repeats), Repairable: true})
+		}
+		closed := len(ring) > 0 && ring[0] == ring[len(ring)-1]
+		if !closed {
+			issues = append(issues, Issue{Severity: Error, Path: ringPath, Message: "ring is not closed: its first and last positions differ", Repairable: true})
+		}
+		distinct := len(ring) - countRepeats(ring)
+		if closed {
+			distinct--
+		}
+		if distinct < 3 {
+			issues = append(issues, Issue{Severity: Error, Path: ringPath, Message: fmt.Sprintf("ring has %d distinct positions, at least 3 are required", distinct)})
+			continue
+		}
+
+		area := signedArea(ring)
+		switch {
+		case area == 0:
+			issues = append(issues, Issue{Severity: Error, Path: ringPath, Message: "ring has no area"})
+		case i == 0 && area < 0:
+			issues = append(issues, Issue{Severity: Warning, Path: ringPath, Message: "exterior ring is clockwise, RFC 7946 requires counter-clockwise", Repairable: true})
+		case i > 0 && area > 0:
+			issues = append(issues, Issue{Severity: Warning, Path: ringPath, Message: "hole is counter-clockwise, RFC 7946 requires clockwise", Repairable: true})
+		}
+	}
+	return append(issues, checkIntersections(polygon, path)...)
+}
+
+func countRepeats(ring Ring) int {
+	repeats := 0
+	for i := 1; i < len(ring); i++ {
+		if ring[i] == ring[i-1] {
+			repeats++
+		}
+	}
+	return repeats
+}
+
+// signedArea returns the planar signed area of a ring: positive when counter-clockwise
+func signedArea(ring Ring) float64 {
+	area := 0.0
+	for i := range ring {
+		j := (i + 1) % len(ring)
+		area += ring[i][0]*ring[j][1] - ring[j][0]*ring[i][1]
+	}
+	return area / 2
+}
+
+type segment struct {
+	a, b       Position
+	ring, edge int
+	last       bool // the closing edge of its ring
+	minX, maxX float64
+}
+
+// checkIntersections finds rings that intersect themselves or cross another ring of the polygon.
+// Segments are swept in order of their smallest longitude so that only segments whose extents
+// overlap are compared.
+func checkIntersections(polygon Polygon, path string) []Issue {
+	var segments []segment
+	for r, ring := range polygon {
+		positions := ring
+		if len(positions) > 0 && positions[0] != positions[len(positions)-1] {
+			positions = append(append(Ring{}, ring...), ring[0])
+		}
+		var ringSegments []segment
+		for i := 0; i+1 < len(positions); i++ {
+			a, b := positions[i], positions[i+1]
+			if a == b {
+				continue
+			}
+			ringSegments = append(ringSegments, segment{a: a, b: b, ring: r, edge: len(ringSegments), minX: math.Min(a[0], b[0]), maxX: math.Max(a[0], b[0])})
+		}
+		if len(ringSegments) > 0 {
+			ringSegments[len(ringSegments)-1].last = true
+		}
+		segments = append(segments, ringSegments...)
+	}
+	sort.Slice(segments, func(i, j int) bool { return segments[i].minX < segments[j].minX })
+
+	reported := map[[2]int]bool{}
+	var issues []Issue
+	for i := range segments {
+		s := &segments[i]
+		for j := i + 1; j < len(segments) && segments[j].minX <= s.maxX; j++ {
+			t := &segments[j]
+			first, second := s, t
+			if first.ring > second.ring || (first.ring == second.ring && first.edge > second.edge) {
+				first, second = second, first
+			}
+			key := [2]int{first.ring, second.ring}
+			if reported[key] {
+				continue
+			}
+			if first.ring == second.ring {
+				adjacent := second.edge == first.edge+1 || (first.edge == 0 && second.last)
+				if adjacent || !segmentsTouch(first.a, first.b, second.a, second.b) {
+					continue
+				}
+			} else if !segmentsCross(first.a, first.b, second.a, second.b) {
+				continue
+			}
+
+			reported[key] = true
+			at := intersectionPoint(first.a, first.b, second.a, second.b)
+			ringPath := join(path, fmt.Sprintf("ring %d", first.ring+1))
+			message := fmt.Sprintf("ring intersects itself near (%.6f, %.6f)", at[0], at[1])
+			if first.ring != second.ring {
+				message = fmt.Sprintf("ring crosses ring %d near (%.6f, %.6f)", second.ring+1, at[0], at[1])
+			}
+			issues = append(issues, Issue{Severity: Error, Path: ringPath, Message: message})
+		}
+	}
+	return issues
+}
+
+func orientation(a, b, c Position) float64 {
+	return (b[0]-a[0])*(c[1]-a[1]) - (b[1]-a[1])*(c[0]-a[0])
+}
+
+func onSegment(a, b, p Position) bool {
+	return math.Min(a[0], b[0]) <= p[0] && p[0] <= math.Max(a[0], b[0]) &&
+		math.Min(a[1], b[1]) <= p[1] && p[1] <= math.Max(a[1], b[1])
+}
+
+// segmentsTouch reports whether segments ab and cd share any point
+func segmentsTouch(a, b, c, d Position) bool {
+	o1, o2 := orientation(a, b, c), orientation(a, b, d)
+	o3, o4 := orientation(c, d, a), orientation(c, d, b)
+	if ((o1 > 0 && o2 < 0) || (o1 < 0 && o2 > 0)) && ((o3 > 0 && o4 < 0) || (o3 < 0 && o4 > 0)) {
+		return true
+	}
+	return (o1 == 0 && onSegment(a, b, c)) || (o2 == 0 && onSegment(a, b, d)) ||
+		(o3 == 0 && onSegment(c, d, a)) || (o4 == 0 && onSegment(c, d, b))
+}
+
+// segmentsCross reports whether segments ab and cd cross at a point inside both of them
+func segmentsCross(a, b, c, d Position) bool {
+	o1, o2 := orientation(a, b, c), orientation(a, b, d)
+	o3, o4 := orientation(c, d, a), orientation(c, d, b)
+	return ((o1 > 0 && o2 < 0) || (o1 < 0 && o2 > 0)) && ((o3 > 0 && o4 < 0) || (o3 < 0 && o4 > 0))
+}
+
+func intersectionPoint(a, b, c, d Position) Position {
+	denominator := (a[0]-b[0])*(c[1]-d[1]) - (a[1]-b[1])*(c[0]-d[0])
+	if denominator == 0 {
+		// Collinear overlap: report the shared end point
+		if onSegment(a, b, c) {
+			return c
+		}
+		return d
+	}
+	t := ((a[0]-c[0])*(c[1]-d[1]) - (a[1]-c[1])*(c[0]-d[0])) / denominator
+	return Position{a[0] + t*(b[0]-a[0]), a[1] + t*(b[1]-a[1])}
+}
@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/digitnxt/digit3/code/libraries/digit-library/digit"
+	"github.com/spf13/cobra"
+)
+
+// accountCmd represents the account command
+var accountCmd = &cobra.Command{
+	Use:   "account",
+	Short: "Manage accounts (tenants) and their configuration",
+	Long: `View, update and deactivate accounts (tenants) and manage their configuration,
+such as the default login type, OTP length and languages.
+
+The account defaults to the tenant of the JWT token. New accounts are created
+with 'digit create-account'.`,
+}
+
+// accountCode returns the account code given as the first argument, or else the tenant of the JWT token
+func accountCode(args []string, tenantID string) string {
+	if len(args) > 0 {
+		return args[0]
+	}
+	return tenantID
+}
+
+// fetchTenant fetches the tenant with the given code
+func fetchTenant(serverURL, jwtToken, clientID, code string) (*digit.Tenant, error) {
+	responseBody, err := digit.SearchTenantByCode(serverURL, jwtToken, clientID, code)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search account %s: %w", code, err)
+	}
+	tenants, err := digit.ParseTenants(responseBody)
+	if err != nil {
+		return nil, err
+	}
+	for i := range tenants {
+		if tenants[i].Code == code || (len(tenants) == 1 && tenants[i].Code == "") {
+			return &tenants[i], nil
+		}
+	}
+	return nil, fmt.Errorf("account %s not found", code)
+}
+
+// fetchTenantConfig fetches the configuration of the tenant with the given code, or nil when it has none
+func fetchTenantConfig(serverURL, jwtToken, clientID, code string) (*digit.TenantConfig, error) {
+	responseBody, err := digit.SearchTenantConfigByCode(serverURL, jwtToken, clientID, code)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search configuration of account %s: %w", code, err)
+	}
+	configs, err := digit.ParseTenantConfigs(responseBody)
+	if err != nil {
+		return nil, err
+	}
+	for i := range configs {
+		if configs[i].Code == code || (len(configs) == 1 && configs[i].Code == "") {
+			return &configs[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// printTenant prints the details of a tenant
+func printTenant(tenant *digit.Tenant) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "Code:\t%s\n", tenant.Code)
+	fmt.Fprintf(w, "Name:\t%s\n", tenant.Name)
+	fmt.Fprintf(w, "Email:\t%s\n", tenant.Email)
+	fmt.Fprintf(w, "Active:\t%t\n", tenant.IsActive)
+	fmt.Fprintf(w, "ID:\t%s\n", tenant.ID)
+	fmt.Fprintf(w, "Attributes:\t%s\n", formatDetails(tenant.AdditionalAttributes))
+	return w.Flush()
+}
+
+// printTenantConfig prints a tenant configuration
+func printTenantConfig(config *digit.TenantConfig) error {
+	languages := strings.Join(config.Languages, ", ")
+	if languages == "" {
+		languages = "-"
+	}
+	otpLength := config.OtpLength
+	if otpLength == "" {
+		otpLength = "-"
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "Code:\t%s\n", config.Code)
+	fmt.Fprintf(w, "Name:\t%s\n", config.Name)
+	fmt.Fprintf(w, "Default login type:\t%s\n", config.DefaultLoginType)
+	fmt.Fprintf(w, "OTP length:\t%s\n", otpLength)
+	fmt.Fprintf(w, "User-based login:\t%t\n", config.EnableUserBasedLogin)
+	fmt.Fprintf(w, "Languages:\t%s\n", languages)
+	fmt.Fprintf(w, "Active:\t%t\n", config.IsActive)
+	fmt.Fprintf(w, "ID:\t%s\n", config.ID)
+	fmt.Fprintf(w, "Attributes:\t%s\n", formatDetails(config.AdditionalAttributes))
+	for _, document := range config.Documents {
+		location := document.FileStoreID
+		if document.URL != "" {
+			location = document.URL
+		}
+		fmt.Fprintf(w, "Document:\t%s %s (active: %t)\n", document.Type, location, document.IsActive)
+	}
+	return w.Flush()
+}
+
+func init() {
+	rootCmd.AddCommand(accountCmd)
+}
@@ -0,0 +1,16 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// accountConfigCmd represents the account config command
+var accountConfigCmd = &cobra.Command{
+	Use:   "config",
+	Short: "View and change account configuration",
+	Long:  `View and change the configuration of an account (tenant): its default login type, OTP length, user-based login, languages and additional attributes.`,
+}
+
+func init() {
+	accountCmd.AddCommand(accountConfigCmd)
+}
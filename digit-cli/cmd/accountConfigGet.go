@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// accountConfigGetCmd represents the account config get command
+var accountConfigGetCmd = &cobra.Command{
+	Use:   "get [code]",
+	Short: "Show the configuration of an account",
+	Long: `Show the configuration of an account (tenant). Without a code, the
+configuration of the JWT token's account is shown.
+
+Examples:
+  digit account config get
+  digit account config get pb`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Get flag values
+		serverURL, _ := cmd.Flags().GetString("server")
+		jwtToken, _ := cmd.Flags().GetString("jwt-token")
+
+		serverURL, jwtToken, tenantID, clientID, err := clientSettings(serverURL, jwtToken)
+		if err != nil {
+			return err
+		}
+
+		code := accountCode(args, tenantID)
+		config, err := fetchTenantConfig(serverURL, jwtToken, clientID, code)
+		if err != nil {
+			return err
+		}
+		if config == nil {
+			return fmt.Errorf("account %s has no configuration; create one with 'digit account config set %s'", code, code)
+		}
+		return printTenantConfig(config)
+	},
+}
+
+func init() {
+	accountConfigCmd.AddCommand(accountConfigGetCmd)
+
+	// Add flags
+	accountConfigGetCmd.Flags().String("server", "", "Server URL (overrides config)")
+	accountConfigGetCmd.Flags().String("jwt-token", "", "JWT token for authentication (overrides config)")
+}
@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/digitnxt/digit3/code/libraries/digit-library/digit"
+	"github.com/spf13/cobra"
+)
+
+// accountConfigSetCmd represents the account config set command
+var accountConfigSetCmd = &cobra.Command{
+	Use:   "set [code]",
+	Short: "Change the configuration of an account",
+	Long: `Change the configuration of an account (tenant). Only the given flags are
+changed; the configuration is created if the account has none yet. Without a
+code, the configuration of the JWT token's account is changed.
+
+--set and --unset change single additional attributes. Values are read as YAML,
+so true and 12 become a boolean and a number; quote them to keep them as text.
+
+Examples:
+  digit account config set --default-login-type OTP --otp-length 6
+  digit account config set pb --languages en_IN,hi_IN,pa_IN
+  digit account config set pb --user-based-login=false --set supportPhone="1800 123 456"`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Get flag values
+		sets, _ := cmd.Flags().GetStringArray("set")
+		unsets, _ := cmd.Flags().GetStringSlice("unset")
+		serverURL, _ := cmd.Flags().GetString("server")
+		jwtToken, _ := cmd.Flags().GetString("jwt-token")
+
+		flags := cmd.Flags()
+		changed := len(sets) > 0 || len(unsets) > 0
+		for _, name := range []string{"name", "default-login-type", "otp-length", "user-based-login", "languages", "active"} {
+			changed = changed || flags.Changed(name)
+		}
+		if !changed {
+			return fmt.Errorf("nothing to change: use --name, --default-login-type, --otp-length, --user-based-login, --languages, --active, --set or --unset")
+		}
+		attributes, err := parseSetFlags(sets)
+		if err != nil {
+			return err
+		}
+
+		serverURL, jwtToken, tenantID, clientID, err := clientSettings(serverURL, jwtToken)
+		if err != nil {
+			return err
+		}
+		code := accountCode(args, tenantID)
+		config, err := fetchTenantConfig(serverURL, jwtToken, clientID, code)
+		if err != nil {
+			return err
+		}
+		create := config == nil
+		if create {
+			config = &digit.TenantConfig{Code: code, IsActive: true}
+		}
+
+		// Apply the changed flags
+		if flags.Changed("name") {
+			config.Name, _ = flags.GetString("name")
+		}
+		if flags.Changed("default-login-type") {
+			config.DefaultLoginType, _ = flags.GetString("default-login-type")
+		}
+		if flags.Changed("otp-length") {
+			otpLength, _ := flags.GetInt("otp-length")
+			if otpLength < 1 {
+				return fmt.Errorf("--otp-length must be at least 1")
+			}
+			config.OtpLength = strconv.Itoa(otpLength)
+		}
+		if flags.Changed("user-based-login") {
+			config.EnableUserBasedLogin, _ = flags.GetBool("user-based-login")
+		}
+		if flags.Changed("languages") {
+			languages, _ := flags.GetStringSlice("languages")
+			config.Languages = nil
+			for _, language := range languages {
+				if language = strings.TrimSpace(language); language != "" {
+					config.Languages = append(config.Languages, language)
+				}
+			}
+		}
+		if flags.Changed("active") {
+			config.IsActive, _ = flags.GetBool("active")
+		}
+		if config.AdditionalAttributes == nil {
+			config.AdditionalAttributes = map[string]interface{}{}
+		}
+		for key, value := range attributes {
+			config.AdditionalAttributes[key] = value
+		}
+		for _, key := range unsets {
+			delete(config.AdditionalAttributes, strings.TrimSpace(key))
+		}
+
+		if create {
+			if _, err := digit.CreateTenantConfig(serverURL, jwtToken, clientID, *config); err != nil {
+				return fmt.Errorf("failed to create configuration of account %s: %w", code, err)
+			}
+			fmt.Printf("✓ Created configuration of account %s\n", code)
+		} else {
+			if config.ID == "" {
+				return fmt.Errorf("the server did not return the ID of the configuration of account %s", code)
+			}
+			if _, err := digit.UpdateTenantConfig(serverURL, jwtToken, clientID, config.ID, *config); err != nil {
+				return fmt.Errorf("failed to update configuration of account %s: %w", code, err)
+			}
+			fmt.Printf("✓ Updated configuration of account %s\n", code)
+		}
+		return printTenantConfig(config)
+	},
+}
+
+func init() {
+	accountConfigCmd.AddCommand(accountConfigSetCmd)
+
+	// Add flags
+	accountConfigSetCmd.Flags().String("name", "", "Name of the configuration")
+	accountConfigSetCmd.Flags().String("default-login-type", "", "Default login type, e.g. OTP or PASSWORD")
+	accountConfigSetCmd.Flags().Int("otp-length", 6, "Number of digits in login OTPs")
+	accountConfigSetCmd.Flags().Bool("user-based-login", false, "Whether users log in with a username")
+	accountConfigSetCmd.Flags().StringSlice("languages", nil, "Supported languages, e.g. en_IN,hi_IN")
+	accountConfigSetCmd.Flags().Bool("active", true, "Whether the configuration is active")
+	accountConfigSetCmd.Flags().StringArray("set", []string{}, "Additional attribute to set as KEY=VALUE (repeatable)")
+	accountConfigSetCmd.Flags().StringSlice("unset", nil, "Additional attributes to remove")
+	accountConfigSetCmd.Flags().String("server", "", "Server URL (overrides config)")
+	accountConfigSetCmd.Flags().String("jwt-token", "", "JWT token for authentication (overrides config)")
+}
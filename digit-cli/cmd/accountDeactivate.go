@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/digitnxt/digit3/code/libraries/digit-library/digit"
+	"github.com/spf13/cobra"
+)
+
+// accountDeactivateCmd represents the account deactivate command
+var accountDeactivateCmd = &cobra.Command{
+	Use:   "deactivate <code>...",
+	Short: "Deactivate accounts",
+	Long: `Deactivate one or more accounts (tenants). Their data is kept; reactivate an
+account with 'digit account update <code> --active=true'.
+
+Codes are always required, so that the account of the JWT token is not
+deactivated by accident.
+
+Examples:
+  digit account deactivate old-tenant
+  digit account deactivate trial1 trial2`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Get flag values
+		serverURL, _ := cmd.Flags().GetString("server")
+		jwtToken, _ := cmd.Flags().GetString("jwt-token")
+
+		serverURL, jwtToken, _, clientID, err := clientSettings(serverURL, jwtToken)
+		if err != nil {
+			return err
+		}
+
+		failed := 0
+		for _, code := range args {
+			tenant, err := fetchTenant(serverURL, jwtToken, clientID, code)
+			if err != nil {
+				failed++
+				fmt.Printf("✗ %s: %v\n", code, err)
+				continue
+			}
+			if !tenant.IsActive {
+				fmt.Printf("= %s (already inactive)\n", code)
+				continue
+			}
+			if tenant.ID == "" {
+				failed++
+				fmt.Printf("✗ %s: the server did not return the account ID\n", code)
+				continue
+			}
+
+			tenant.IsActive = false
+			if _, err := digit.UpdateTenant(serverURL, jwtToken, clientID, tenant.ID, *tenant); err != nil {
+				failed++
+				fmt.Printf("✗ %s: %v\n", code, err)
+				continue
+			}
+			fmt.Printf("✓ Deactivated account %s\n", code)
+		}
+
+		if failed > 0 {
+			return fmt.Errorf("%d of %d accounts could not be deactivated", failed, len(args))
+		}
+		return nil
+	},
+}
+
+func init() {
+	accountCmd.AddCommand(accountDeactivateCmd)
+
+	// Add flags
+	accountDeactivateCmd.Flags().String("server", "", "Server URL (overrides config)")
+	accountDeactivateCmd.Flags().String("jwt-token", "", "JWT token for authentication (overrides config)")
+}
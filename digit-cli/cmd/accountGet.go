@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// accountGetCmd represents the account get command
+var accountGetCmd = &cobra.Command{
+	Use:   "get [code]",
+	Short: "Show an account",
+	Long: `Show the details of an account (tenant). Without a code, the account of the
+JWT token is shown.
+
+Examples:
+  digit account get
+  digit account get pb`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Get flag values
+		serverURL, _ := cmd.Flags().GetString("server")
+		jwtToken, _ := cmd.Flags().GetString("jwt-token")
+
+		serverURL, jwtToken, tenantID, clientID, err := clientSettings(serverURL, jwtToken)
+		if err != nil {
+			return err
+		}
+
+		tenant, err := fetchTenant(serverURL, jwtToken, clientID, accountCode(args, tenantID))
+		if err != nil {
+			return err
+		}
+		return printTenant(tenant)
+	},
+}
+
+func init() {
+	accountCmd.AddCommand(accountGetCmd)
+
+	// Add flags
+	accountGetCmd.Flags().String("server", "", "Server URL (overrides config)")
+	accountGetCmd.Flags().String("jwt-token", "", "JWT token for authentication (overrides config)")
+}
@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/digitnxt/digit3/code/libraries/digit-library/digit"
+	"github.com/spf13/cobra"
+)
+
+// accountUpdateCmd represents the account update command
+var accountUpdateCmd = &cobra.Command{
+	Use:   "update [code]",
+	Short: "Update an account",
+	Long: `Update the name, email, status or additional attributes of an account (tenant).
+Only the given flags are changed. Without a code, the account of the JWT token
+is updated.
+
+--set and --unset change single additional attributes. Values are read as YAML,
+so true and 12 become a boolean and a number; quote them to keep them as text.
+
+Examples:
+  digit account update --name "Punjab Municipal Services"
+  digit account update pb --email admin@pb.example.org --set region=north
+  digit account update pb --active=true`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Get flag values
+		sets, _ := cmd.Flags().GetStringArray("set")
+		unsets, _ := cmd.Flags().GetStringSlice("unset")
+		serverURL, _ := cmd.Flags().GetString("server")
+		jwtToken, _ := cmd.Flags().GetString("jwt-token")
+
+		flags := cmd.Flags()
+		if !flags.Changed("name") && !flags.Changed("email") && !flags.Changed("active") && len(sets) == 0 && len(unsets) == 0 {
+			return fmt.Errorf("nothing to update: use --name, --email, --active, --set or --unset")
+		}
+		attributes, err := parseSetFlags(sets)
+		if err != nil {
+			return err
+		}
+
+		serverURL, jwtToken, tenantID, clientID, err := clientSettings(serverURL, jwtToken)
+		if err != nil {
+			return err
+		}
+		tenant, err := fetchTenant(serverURL, jwtToken, clientID, accountCode(args, tenantID))
+		if err != nil {
+			return err
+		}
+		if tenant.ID == "" {
+			return fmt.Errorf("the server did not return the ID of account %s", tenant.Code)
+		}
+
+		// Apply the changed flags
+		if flags.Changed("name") {
+			tenant.Name, _ = flags.GetString("name")
+		}
+		if flags.Changed("email") {
+			tenant.Email, _ = flags.GetString("email")
+		}
+		if flags.Changed("active") {
+			tenant.IsActive, _ = flags.GetBool("active")
+		}
+		if tenant.AdditionalAttributes == nil {
+			tenant.AdditionalAttributes = map[string]interface{}{}
+		}
+		for key, value := range attributes {
+			tenant.AdditionalAttributes[key] = value
+		}
+		for _, key := range unsets {
+			delete(tenant.AdditionalAttributes, strings.TrimSpace(key))
+		}
+
+		if _, err := digit.UpdateTenant(serverURL, jwtToken, clientID, tenant.ID, *tenant); err != nil {
+			return fmt.Errorf("failed to update account %s: %w", tenant.Code, err)
+		}
+
+		fmt.Printf("✓ Updated account %s\n", tenant.Code)
+		return printTenant(tenant)
+	},
+}
+
+func init() {
+	accountCmd.AddCommand(accountUpdateCmd)
+
+	// Add flags
+	accountUpdateCmd.Flags().String("name", "", "New name of the account")
+	accountUpdateCmd.Flags().String("email", "", "New email of the account")
+	accountUpdateCmd.Flags().Bool("active", true, "Whether the account is active")
+	accountUpdateCmd.Flags().StringArray("set", []string{}, "Additional attribute to set as KEY=VALUE (repeatable)")
+	accountUpdateCmd.Flags().StringSlice("unset", nil, "Additional attributes to remove")
+	accountUpdateCmd.Flags().String("server", "", "Server URL (overrides config)")
+	accountUpdateCmd.Flags().String("jwt-token", "", "JWT token for authentication (overrides config)")
+}
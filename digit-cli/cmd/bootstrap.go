@@ -0,0 +1,641 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"digit-cli/pkg/bootstrap"
+	"digit-cli/pkg/boundary"
+	"digit-cli/pkg/config"
+	"digit-cli/pkg/jwt"
+	"github.com/digitnxt/digit3/code/libraries/digit-library/digit"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// bootstrapLoginInterval is how often the login to a new account is retried while waiting for it
+const bootstrapLoginInterval = 5 * time.Second
+
+// bootstrapCmd represents the bootstrap command
+var bootstrapCmd = &cobra.Command{
+	Use:   "bootstrap",
+	Short: "Set up a new account from a profile",
+	Long: `Set up a new account (tenant) from a profile directory declaring the whole tenant:
+the account and its administrator login, the account configuration, roles,
+users and their roles, and the workflow, ID generation template, notification
+template, MDMS schema and boundary files to create in it.
+
+The steps run in dependency order, as the individual commands would:
+create-account, config set, account config set, create-role, create-user,
+assign-role, create-workflow, create-idgen-template,
+create-notification-template, create-schema and create-boundaries. After
+creating the account, the command waits for it to accept the administrator
+login (see --wait) and stores the login like 'digit config set', so later
+commands use the new account.
+
+Finished steps are recorded in a state file next to the profile, as are the
+created account and the parts of workflows and notification templates created
+so far. When a step fails, fix the problem and run the same command again to
+resume from that step without creating its finished parts again. Delete the
+state file to start over.
+
+--init writes the built-in starter profile, made from the defaults of the
+create-* commands, to the --profile directory for editing. In profile.yaml,
+values like ${ADMIN_PASSWORD} are read from the environment; an unset
+variable is an error.
+
+Examples:
+  digit bootstrap --profile pgr-profile/ --init
+  digit bootstrap --profile pgr-profile/ --dry-run
+  digit bootstrap --profile pgr-profile/
+  digit bootstrap --profile pgr-profile/ --wait 5m --server http://localhost:8080`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Get flag values
+		profilePath, _ := cmd.Flags().GetString("profile")
+		initProfile, _ := cmd.Flags().GetBool("init")
+		wait, _ := cmd.Flags().GetDuration("wait")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		serverURL, _ := cmd.Flags().GetString("server")
+
+		if initProfile {
+			return writeStarterProfile(profilePath)
+		}
+
+		profile, err := bootstrap.Load(profilePath)
+		if err != nil {
+			return err
+		}
+		statePath := profile.StatePath()
+		state, err := bootstrap.LoadState(statePath)
+		if err != nil {
+			return err
+		}
+		if state.Account != "" && state.Account != profile.Account.Name {
+			return fmt.Errorf("state file %s is for account %s, not %s; delete it to start over", statePath, state.Account, profile.Account.Name)
+		}
+		if state.Code != "" && profile.Account.Code != "" && state.Code != profile.Account.Code {
+			return fmt.Errorf("state file %s is for account code %s, not %s; delete it to start over", statePath, state.Code, profile.Account.Code)
+		}
+		state.Account = profile.Account.Name
+		steps := profile.Steps()
+
+		if dryRun {
+			fmt.Printf("Bootstrap plan for account %s:\n\n", profile.Account.Name)
+			for _, step := range steps {
+				if _, ok := state.Completed(step); ok {
+					fmt.Printf("= %s (done)\n", step)
+				} else if !step.Recorded() {
+					fmt.Printf("~ %s (repeated on every run)\n", step)
+				} else {
+					fmt.Printf("+ %s\n", step)
+				}
+			}
+			fmt.Println("\nDry run: no changes were made.")
+			return nil
+		}
+
+		// Load server URL from config if not provided as flag
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		if serverURL == "" {
+			serverURL = cfg.GetServer()
+			if serverURL == "" {
+				return fmt.Errorf("server URL not configured. Use 'digit config set --server <url>' or provide --server flag")
+			}
+		}
+
+		// The account is created with the client ID of the profile, or else of the configured token
+		if _, done := state.Completed(bootstrap.Step{Kind: bootstrap.StepAccount}); !done && profile.Account.ClientID == "" {
+			jwtToken := cfg.GetJWTToken()
+			if jwtToken == "" {
+				return fmt.Errorf("client ID not configured. Set account.client-id in the profile or use 'digit config set' to log in")
+			}
+			profile.Account.ClientID, err = jwt.ExtractClientID(jwtToken)
+			if err != nil {
+				return fmt.Errorf("failed to extract client ID from JWT token: %w", err)
+			}
+		}
+
+		run := &bootstrapRun{profile: profile, state: state, statePath: statePath, serverURL: serverURL, wait: wait}
+		for _, step := range steps {
+			if _, ok := state.Completed(step); ok {
+				fmt.Printf("= %s (done in an earlier run)\n", step)
+				continue
+			}
+			id, err := run.perform(step)
+			if err != nil {
+				fmt.Printf("✗ %s: %v\n", step, err)
+				return fmt.Errorf("bootstrap stopped at %s; fix the problem and run the command again to resume from there", step)
+			}
+			if !step.Recorded() {
+				continue
+			}
+			if id != "" {
+				fmt.Printf("✓ %s (%s)\n", step, id)
+			} else {
+				fmt.Printf("✓ %s\n", step)
+			}
+			state.Complete(step, id)
+			if err := state.Save(statePath); err != nil {
+				return err
+			}
+		}
+
+		fmt.Printf("\nAccount %s is ready.\n\n", state.Code)
+		return printBootstrapSummary(steps, state)
+	},
+}
+
+// bootstrapRun performs the steps of a bootstrap, holding the login to the new account once it is made
+type bootstrapRun struct {
+	profile   *bootstrap.Profile
+	state     *bootstrap.State
+	statePath string
+	serverURL string
+	wait      time.Duration
+
+	jwtToken string
+	tenantID string
+	clientID string
+}
+
+// perform performs one step and returns the ID of what it created, if the server returned one
+func (r *bootstrapRun) perform(step bootstrap.Step) (string, error) {
+	switch step.Kind {
+	case bootstrap.StepAccount:
+		return r.createAccount(step)
+	case bootstrap.StepLogin:
+		return "", r.login()
+	case bootstrap.StepAccountConfig:
+		return r.configureAccount()
+	case bootstrap.StepRole:
+		role := r.profile.Role(step.Name)
+		responseBody, err := digit.CreateRole(r.serverURL, r.jwtToken, r.state.Code, role.Name, role.Description)
+		return responseID(responseBody), err
+	case bootstrap.StepUser:
+		user := r.profile.User(step.Name)
+		responseBody, err := digit.CreateUser(r.serverURL, r.jwtToken, r.state.Code, user.Username, user.Password, user.Email)
+		return responseID(responseBody), err
+	case bootstrap.StepAssignRole:
+		_, err := digit.AssignRoleToUser(r.serverURL, r.jwtToken, r.state.Code, step.Name, step.Role)
+		return "", err
+	case bootstrap.StepWorkflow:
+		return r.createWorkflow(step)
+	case bootstrap.StepIdGenTemplate:
+		template, err := r.profile.LoadIdGenTemplate(step.Name)
+		if err != nil {
+			return "", err
+		}
+		responseBody, err := digit.CreateIdGenTemplate(r.serverURL, r.jwtToken, r.clientID, r.tenantID, template.TemplateCode, template.Template,
+			template.Scope, template.Start, template.PaddingLength, template.PaddingChar, template.RandomLength, template.RandomCharset)
+		if err != nil {
+			return "", err
+		}
+		return idOr(responseID(responseBody), template.TemplateCode), nil
+	case bootstrap.StepNotificationTemplate:
+		return r.createNotificationTemplates(step)
+	case bootstrap.StepSchema:
+		return r.createSchema(step.Name)
+	case bootstrap.StepBoundaries:
+		return r.createBoundaries(step.Name)
+	}
+	return "", fmt.Errorf("unknown step %s", step.Kind)
+}
+
+// createAccount creates the account and records its code, which is also the name of its login realm.
+// The response is recorded as soon as the account is created, so that a rerun after a failure,
+// such as a response without the code, reads it again instead of creating the account twice.
+func (r *bootstrapRun) createAccount(step bootstrap.Step) (string, error) {
+	account := r.profile.Account
+	created := r.state.StepParts(step)
+	responseBody, ok := created["response"]
+	if ok {
+		fmt.Printf("= Account %s was created in an earlier run\n", account.Name)
+	} else {
+		active := account.Active == nil || *account.Active
+		var err error
+		responseBody, err = digit.CreateAccount(r.serverURL, account.ClientID, account.Name, account.Email, active)
+		if err != nil {
+			return "", err
+		}
+		created["response"] = responseBody
+		if err := r.saveState(); err != nil {
+			return "", err
+		}
+	}
+	code, id := account.Code, ""
+	if tenants, err := digit.ParseTenants(responseBody); err == nil && len(tenants) > 0 {
+		if tenants[0].Code != "" {
+			code = tenants[0].Code
+		}
+		id = tenants[0].ID
+	}
+	if code == "" {
+		return "", fmt.Errorf("the server did not return the code of the new account; set account.code in the profile: %s", responseBody)
+	}
+	r.state.Code = code
+	return idOr(id, code), nil
+}
+
+// login waits for the new account to accept the administrator login and stores it as 'digit config set' does
+func (r *bootstrapRun) login() error {
+	login := r.profile.Login
+	deadline := time.Now().Add(r.wait)
+	for attempt := 1; ; attempt++ {
+		jwtToken, err := digit.GetJWTToken(r.serverURL, r.state.Code, login.ClientID, login.ClientSecret, login.Username, login.Password)
+		if err == nil {
+			r.jwtToken = jwtToken
+			break
+		}
+		if !time.Now().Before(deadline) {
+			return fmt.Errorf("account %s did not accept the login of %s within %s: %w", r.state.Code, login.Username, r.wait, err)
+		}
+		if attempt == 1 {
+			fmt.Printf("Waiting for account %s to accept logins...\n", r.state.Code)
+		}
+		time.Sleep(bootstrapLoginInterval)
+	}
+
+	tenantID, err := jwt.ExtractTenantID(r.jwtToken)
+	if err != nil {
+		return fmt.Errorf("failed to extract tenant ID from JWT token: %w", err)
+	}
+	clientID, err := jwt.ExtractClientID(r.jwtToken)
+	if err != nil {
+		return fmt.Errorf("failed to extract client ID from JWT token: %w", err)
+	}
+	r.tenantID, r.clientID = tenantID, clientID
+
+	if err := config.SetServerURL(r.serverURL); err != nil {
+		return fmt.Errorf("failed to set server URL: %w", err)
+	}
+	if err := config.SetJWTToken(r.jwtToken); err != nil {
+		return fmt.Errorf("failed to set JWT token: %w", err)
+	}
+	if err := config.SetAuthConfig(r.serverURL, r.state.Code, login.ClientID, login.ClientSecret, login.Username, login.Password); err != nil {
+		return fmt.Errorf("failed to store auth config: %w", err)
+	}
+	fmt.Printf("✓ Logged in to account %s as %s; the configuration now uses this account\n", r.state.Code, login.Username)
+	return nil
+}
+
+// configureAccount creates the account configuration, or updates it when the account already has one
+func (r *bootstrapRun) configureAccount() (string, error) {
+	settings := r.profile.Config
+	existing, err := fetchTenantConfig(r.serverURL, r.jwtToken, r.clientID, r.state.Code)
+	if err != nil {
+		return "", err
+	}
+	tenantConfig := digit.TenantConfig{Code: r.state.Code, IsActive: true}
+	if existing != nil {
+		tenantConfig = *existing
+	}
+	tenantConfig.Name = settings.Name
+	tenantConfig.DefaultLoginType = settings.DefaultLoginType
+	if settings.OtpLength > 0 {
+		tenantConfig.OtpLength = strconv.Itoa(settings.OtpLength)
+	}
+	tenantConfig.EnableUserBasedLogin = settings.UserBasedLogin
+	tenantConfig.Languages = settings.Languages
+	if tenantConfig.AdditionalAttributes == nil {
+		tenantConfig.AdditionalAttributes = map[string]interface{}{}
+	}
+	for key, value := range settings.Attributes {
+		tenantConfig.AdditionalAttributes[key] = value
+	}
+
+	var responseBody string
+	if existing == nil {
+		responseBody, err = digit.CreateTenantConfig(r.serverURL, r.jwtToken, r.clientID, tenantConfig)
+	} else {
+		if tenantConfig.ID == "" {
+			return "", fmt.Errorf("the server did not return the ID of the configuration of account %s", r.state.Code)
+		}
+		responseBody, err = digit.UpdateTenantConfig(r.serverURL, r.jwtToken, r.clientID, tenantConfig.ID, tenantConfig)
+	}
+	if err != nil {
+		return "", err
+	}
+	if configs, err := digit.ParseTenantConfigs(responseBody); err == nil && len(configs) > 0 && configs[0].ID != "" {
+		return configs[0].ID, nil
+	}
+	return tenantConfig.ID, nil
+}
+
+// saveState writes the state file, after recording a part of a step
+func (r *bootstrapRun) saveState() error {
+	return r.state.Save(r.statePath)
+}
+
+// createWorkflow creates the workflow of a workflow definition file and returns the process ID;
+// the parts created are recorded, so that a rerun after a failure does not create them again
+func (r *bootstrapRun) createWorkflow(step bootstrap.Step) (string, error) {
+	yamlData, err := os.ReadFile(r.profile.Path(step.Name))
+	if err != nil {
+		return "", fmt.Errorf("failed to read YAML file: %w", err)
+	}
+	var workflowDef WorkflowDefinition
+	if err := yaml.Unmarshal(yamlData, &workflowDef); err != nil {
+		return "", fmt.Errorf("failed to parse YAML: %w", err)
+	}
+	progress := &workflowProgress{created: r.state.StepParts(step), save: r.saveState}
+	return createWorkflowFromDefinition(r.serverURL, r.jwtToken, r.tenantID, &workflowDef, progress)
+}
+
+// createNotificationTemplates creates the templates of a template configuration file, one per
+// locale for localized templates; files it refers to are relative to the configuration file.
+// The template versions created are recorded, so that a rerun after a failure does not create
+// them again.
+func (r *bootstrapRun) createNotificationTemplates(step bootstrap.Step) (string, error) {
+	path := r.profile.Path(step.Name)
+	yamlData, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read YAML file: %w", err)
+	}
+	var templateConfig TemplateConfig
+	if err := yaml.Unmarshal(yamlData, &templateConfig); err != nil {
+		return "", fmt.Errorf("failed to parse YAML file: %w", err)
+	}
+	templateConfig.resolvePaths(filepath.Dir(path))
+	if templateConfig.Content != "" && templateConfig.ContentFile != "" {
+		return "", fmt.Errorf("cannot use both content and content-file together")
+	}
+	if templateConfig.ContentFile != "" {
+		fileContent, err := os.ReadFile(templateConfig.ContentFile)
+		if err != nil {
+			return "", fmt.Errorf("failed to read content file: %w", err)
+		}
+		templateConfig.Content = string(fileContent)
+	}
+	if templateConfig.TemplateID == "" || templateConfig.Version == "" || templateConfig.Type == "" {
+		return "", fmt.Errorf("template-id, version and type are required")
+	}
+
+	compiled, err := compileTemplateConfig(&templateConfig)
+	if err != nil {
+		return "", fmt.Errorf("failed to compile template: %w", err)
+	}
+	created := r.state.StepParts(step)
+	var ids []string
+	for _, t := range compiled {
+		part := t.TemplateID + "@" + t.Version
+		if id, ok := created[part]; ok {
+			ids = append(ids, id)
+			continue
+		}
+		responseBody, err := digit.CreateTemplate(r.serverURL, r.jwtToken, r.tenantID, t.TemplateID, t.Version, templateConfig.Type, t.Subject, t.Content, templateConfig.HTML)
+		if err != nil {
+			return "", fmt.Errorf("failed to create template %s version %s: %w", t.TemplateID, t.Version, err)
+		}
+		created[part] = idOr(responseID(responseBody), part)
+		if err := r.saveState(); err != nil {
+			return "", err
+		}
+		ids = append(ids, created[part])
+	}
+	return strings.Join(ids, ", "), nil
+}
+
+// resolvePaths makes the relative content, layout and partial files of a configuration relative to dir
+func (c *TemplateConfig) resolvePaths(dir string) {
+	resolve := func(path string) string {
+		if path == "" || filepath.IsAbs(path) {
+			return path
+		}
+		return filepath.Join(dir, path)
+	}
+	c.ContentFile = resolve(c.ContentFile)
+	c.Layout = resolve(c.Layout)
+	for name, path := range c.Partials {
+		c.Partials[name] = resolve(path)
+	}
+	for locale, variant := range c.Locales {
+		variant.ContentFile = resolve(variant.ContentFile)
+		c.Locales[locale] = variant
+	}
+}
+
+// createSchema creates the MDMS schema of a schema definition file
+func (r *bootstrapRun) createSchema(file string) (string, error) {
+	yamlData, err := os.ReadFile(r.profile.Path(file))
+	if err != nil {
+		return "", fmt.Errorf("failed to read YAML file: %w", err)
+	}
+	var schemaDef SchemaDefinition
+	if err := yaml.Unmarshal(yamlData, &schemaDef); err != nil {
+		return "", fmt.Errorf("failed to parse YAML: %w", err)
+	}
+	if schemaDef.Schema.Code == "" || schemaDef.Schema.Description == "" {
+		return "", fmt.Errorf("code and description are required in YAML file")
+	}
+	definitionBytes, err := json.Marshal(schemaDef.Schema.Definition)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal definition to JSON: %w", err)
+	}
+	responseBody, err := digit.CreateSchema(r.serverURL, r.jwtToken, r.tenantID, r.clientID, schemaDef.Schema.Code, schemaDef.Schema.Description, string(definitionBytes), schemaDef.Schema.IsActive)
+	if err != nil {
+		return "", err
+	}
+	return idOr(responseID(responseBody), schemaDef.Schema.Code), nil
+}
+
+// createBoundaries checks and creates the boundaries of a boundaries YAML file
+func (r *bootstrapRun) createBoundaries(file string) (string, error) {
+	yamlData, err := os.ReadFile(r.profile.Path(file))
+	if err != nil {
+		return "", fmt.Errorf("failed to read YAML file: %w", err)
+	}
+	var boundaryDef BoundaryDefinition
+	if err := yaml.Unmarshal(yamlData, &boundaryDef); err != nil {
+		return "", fmt.Errorf("failed to parse YAML: %w", err)
+	}
+	if len(boundaryDef.Boundary) == 0 {
+		return "", fmt.Errorf("at least one boundary entry is required in YAML file")
+	}
+	boundaries, err := boundary.BoundariesFromMaps(boundaryDef.Boundary)
+	if err != nil {
+		return "", err
+	}
+	if err := printBoundaryChecks(boundary.CheckBoundaries(boundaries, boundary.CheckOptions{})); err != nil {
+		return "", fmt.Errorf("%w; see 'digit boundary validate --repair'", err)
+	}
+	if _, err := digit.CreateBoundaries(r.serverURL, r.jwtToken, r.tenantID, r.clientID, boundaryDef.Boundary); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%d boundaries", len(boundaryDef.Boundary)), nil
+}
+
+// responseID returns the ID in a create response: its "id", or else the "id" of the first object
+// it wraps, e.g. {"schema": {"id": ...}} or {"boundary": [{"id": ...}]}
+func responseID(responseBody string) string {
+	var response interface{}
+	if err := json.Unmarshal([]byte(responseBody), &response); err != nil {
+		return ""
+	}
+	object, ok := response.(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	if id, ok := object["id"].(string); ok {
+		return id
+	}
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		value := object[key]
+		if list, ok := value.([]interface{}); ok && len(list) > 0 {
+			value = list[0]
+		}
+		if wrapped, ok := value.(map[string]interface{}); ok {
+			if id, ok := wrapped["id"].(string); ok {
+				return id
+			}
+		}
+	}
+	return ""
+}
+
+// idOr returns id, or fallback when the server returned no ID
+func idOr(id, fallback string) string {
+	if id != "" {
+		return id
+	}
+	return fallback
+}
+
+// printBootstrapSummary prints the IDs created by each recorded step
+func printBootstrapSummary(steps []bootstrap.Step, state *bootstrap.State) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "STEP\tID")
+	for _, step := range steps {
+		done, ok := state.Completed(step)
+		if !ok {
+			continue
+		}
+		id := done.ID
+		if id == "" {
+			id = "-"
+		}
+		fmt.Fprintf(w, "%s\t%s\n", step, id)
+	}
+	return w.Flush()
+}
+
+// starterProfileYAML is the manifest of the built-in starter profile
+const starterProfileYAML = `# Starter bootstrap profile, made from the defaults of the create-* commands.
+# Set the account and its administrator login, then run:
+#   ADMIN_CLIENT_SECRET=... ADMIN_PASSWORD=... digit bootstrap --profile <this directory>
+# Values like ${ADMIN_PASSWORD} are read from the environment.
+
+account:
+  name: starter
+  email: admin@example.com
+
+# Administrator login of the new account, as for 'digit config set';
+# the username defaults to the account email
+login:
+  client-id: auth-server
+  client-secret: ${ADMIN_CLIENT_SECRET}
+  password: ${ADMIN_PASSWORD}
+
+config:
+  default-login-type: OTP
+  otp-length: 6
+  languages: [en_IN]
+
+# Roles used by the workflow
+roles:
+  - name: CITIZEN
+    description: Citizen filing complaints
+  - name: CSR
+    description: Customer service representative
+  - name: GRO
+    description: Grievance routing officer
+  - name: LME
+    description: Last mile employee
+
+# users:
+#   - username: gro1
+#     password: ${GRO1_PASSWORD}
+#     email: gro1@example.com
+#     roles: [GRO]
+
+workflows:
+  - workflow.yaml
+
+idgen-templates:
+  - idgen-template.yaml
+
+notification-templates:
+  - notification-template.yaml
+
+# MDMS schema files, as for 'digit create-schema --file'
+# schemas:
+#   - schema.yaml
+
+boundaries:
+  - boundaries.yaml
+`
+
+// starterProfileFiles returns the files of the built-in starter profile
+func starterProfileFiles() map[string]string {
+	return map[string]string{
+		bootstrap.ProfileFile:        starterProfileYAML,
+		"workflow.yaml":              strings.Replace(defaultWorkflowYAML, "DEFAULT_CODE", "PGR", 1) + "\n",
+		"idgen-template.yaml":        strings.Replace(defaultIdGenConfig, "DEFAULT_TEMPLATE_CODE", "complaintId", 1) + "\n",
+		"notification-template.yaml": strings.Replace(defaultNotificationTemplateYAML, "DEFAULT_TEMPLATE_ID", "welcome", 1) + "\n",
+		"boundaries.yaml":            strings.ReplaceAll(defaultBoundaryYAML, "DEFAULT_BOUNDARY", "STARTER_BOUNDARY") + "\n",
+	}
+}
+
+// writeStarterProfile writes the built-in starter profile to a directory, without overwriting any file
+func writeStarterProfile(dir string) error {
+	files := starterProfileFiles()
+	names := make([]string, 0, len(files))
+	for name := range files {
+		if _, err := os.Stat(filepath.Join(dir, name)); err == nil {
+			return fmt.Errorf("%s already exists", filepath.Join(dir, name))
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create profile directory: %w", err)
+	}
+	for _, name := range names {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(files[name]), 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", name, err)
+		}
+		fmt.Printf("✓ Wrote %s\n", filepath.Join(dir, name))
+	}
+	fmt.Printf("\nEdit %s, set ADMIN_CLIENT_SECRET and ADMIN_PASSWORD, then run: digit bootstrap --profile %s\n", filepath.Join(dir, bootstrap.ProfileFile), dir)
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(bootstrapCmd)
+
+	// Add flags
+	bootstrapCmd.Flags().String("profile", "", "Profile directory, or its profile.yaml")
+	bootstrapCmd.Flags().Bool("init", false, "Write the built-in starter profile to the --profile directory and exit")
+	bootstrapCmd.Flags().Duration("wait", 2*time.Minute, "How long to wait for the new account to accept logins")
+	bootstrapCmd.Flags().Bool("dry-run", false, "Show the steps to run and those done in an earlier run, without changing anything")
+	bootstrapCmd.Flags().String("server", "", "Server URL (overrides config)")
+
+	// Mark required flags
+	bootstrapCmd.MarkFlagRequired("profile")
+}
@@ -0,0 +1,191 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"digit-cli/pkg/boundary"
+	"github.com/digitnxt/digit3/code/libraries/digit-library/digit"
+	"github.com/digitnxt/digit3/code/libraries/digit-library/geometry"
+	"github.com/spf13/cobra"
+)
+
+// boundaryCmd represents the boundary command
+var boundaryCmd = &cobra.Command{
+	Use:   "boundary",
+	Short: "Manage boundaries, hierarchies and relationships",
+	Long: `Import, validate, search and update boundaries, find the boundaries containing
+a point, define boundary hierarchies such as State > District > Ward, link
+boundaries to their parents within a hierarchy and view the resulting tree.
+
+Boundaries can also be created from a YAML file with 'digit create-boundaries'.`,
+}
+
+// fetchBoundaryHierarchy fetches and parses the definition of a hierarchy type
+func fetchBoundaryHierarchy(serverURL, jwtToken, tenantID, clientID, hierarchyType string) (*boundary.Hierarchy, error) {
+	responseBody, err := digit.SearchBoundaryHierarchy(serverURL, jwtToken, tenantID, clientID, hierarchyType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch boundary hierarchy %s: %w", hierarchyType, err)
+	}
+	hierarchy, err := boundary.ParseHierarchy(responseBody)
+	if err != nil {
+		return nil, fmt.Errorf("boundary hierarchy %s: %w", hierarchyType, err)
+	}
+	if hierarchy.Type == "" {
+		hierarchy.Type = hierarchyType
+	}
+	return hierarchy, nil
+}
+
+// fetchBoundaryTree fetches the boundaries linked into a hierarchy as a tree
+func fetchBoundaryTree(serverURL, jwtToken, tenantID, clientID, hierarchyType string) ([]*boundary.Node, error) {
+	responseBody, err := digit.SearchBoundaryRelationships(serverURL, jwtToken, tenantID, clientID, hierarchyType, "", true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search boundary relationships: %w", err)
+	}
+	return boundary.ParseTree(responseBody)
+}
+
+// boundaryCodesPerRequest limits the codes of one boundary search, keeping request URLs short
+const boundaryCodesPerRequest = 50
+
+// fetchBoundaries fetches the boundaries with the given codes; codes that do not exist are left out
+func fetchBoundaries(serverURL, jwtToken, tenantID, clientID string, codes []string) ([]digit.Boundary, error) {
+	var boundaries []digit.Boundary
+	for start := 0; start < len(codes); start += boundaryCodesPerRequest {
+		end := start + boundaryCodesPerRequest
+		if end > len(codes) {
+			end = len(codes)
+		}
+		responseBody, err := digit.SearchBoundariesByCodes(serverURL, jwtToken, tenantID, clientID, codes[start:end])
+		if err != nil {
+			return nil, fmt.Errorf("failed to search boundaries: %w", err)
+		}
+		found, err := digit.ParseBoundaries(responseBody)
+		if err != nil {
+			return nil, err
+		}
+		boundaries = append(boundaries, found...)
+	}
+	sort.Slice(boundaries, func(i, j int) bool { return boundaries[i].Code < boundaries[j].Code })
+	return boundaries, nil
+}
+
+// missingBoundaryCodes returns the codes that have no boundary among the fetched boundaries
+func missingBoundaryCodes(codes []string, boundaries []digit.Boundary) []string {
+	found := map[string]bool{}
+	for _, b := range boundaries {
+		found[b.Code] = true
+	}
+	var missing []string
+	for _, code := range codes {
+		if !found[code] {
+			missing = append(missing, code)
+		}
+	}
+	return missing
+}
+
+// printBoundaries prints fetched boundaries as a table; the boundary types are shown when nodes are given
+func printBoundaries(boundaries []digit.Boundary, nodes map[string]*boundary.Node) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	if nodes != nil {
+		fmt.Fprintln(w, "CODE\tTYPE\tGEOMETRY\tVERTICES\tBOUNDS\tDETAILS")
+	} else {
+		fmt.Fprintln(w, "CODE\tGEOMETRY\tVERTICES\tBOUNDS\tDETAILS")
+	}
+	for _, b := range boundaries {
+		geometryType, vertices, bounds := "-", "-", "-"
+		if b.Geometry != nil {
+			geometryType = b.Geometry.Type
+			vertices = fmt.Sprint(b.Geometry.VertexCount())
+			if min, max, ok := b.Geometry.Bounds(); ok {
+				bounds = fmt.Sprintf("%.5f,%.5f %.5f,%.5f", min[1], min[0], max[1], max[0])
+			}
+		}
+		details := formatDetails(b.AdditionalDetails)
+		if nodes != nil {
+			boundaryType := "-"
+			if n := nodes[b.Code]; n != nil && n.BoundaryType != "" {
+				boundaryType = n.BoundaryType
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", b.Code, boundaryType, geometryType, vertices, bounds, details)
+		} else {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", b.Code, geometryType, vertices, bounds, details)
+		}
+	}
+	return w.Flush()
+}
+
+// formatDetails formats additional details as sorted key=value pairs
+func formatDetails(details map[string]interface{}) string {
+	if len(details) == 0 {
+		return "-"
+	}
+	keys := make([]string, 0, len(details))
+	for key := range details {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		pairs = append(pairs, key+"="+boundary.PropertyString(details[key]))
+	}
+	return strings.Join(pairs, " ")
+}
+
+// writeFetchedBoundaries writes fetched boundaries to a .yaml or .geojson file
+func writeFetchedBoundaries(path string, fetched []digit.Boundary) error {
+	boundaries := make([]boundary.Boundary, 0, len(fetched))
+	for _, b := range fetched {
+		boundaries = append(boundaries, boundary.Boundary{Code: b.Code, Geometry: b.Geometry, AdditionalDetails: b.AdditionalDetails})
+	}
+	if err := boundary.WriteBoundaries(path, boundaries); err != nil {
+		return err
+	}
+	fmt.Printf("✓ Wrote %d boundaries to %s\n", len(boundaries), path)
+	return nil
+}
+
+// printBoundaryChecks prints the boundaries that were changed or have issues, followed by a summary,
+// and returns an error when any boundary has geometry errors
+func printBoundaryChecks(results []boundary.CheckResult) error {
+	for _, result := range results {
+		simplified := result.VerticesAfter != result.VerticesBefore
+		if len(result.Issues) == 0 && len(result.Changes) == 0 && !simplified {
+			continue
+		}
+
+		status := "✓"
+		if geometry.HasErrors(result.Issues) {
+			status = "✗"
+		}
+		line := status + " " + result.Code
+		if simplified {
+			line += fmt.Sprintf(" (simplified from %d to %d positions)", result.VerticesBefore, result.VerticesAfter)
+		}
+		fmt.Println(line)
+		for _, change := range result.Changes {
+			fmt.Printf("    fixed: %s\n", change)
+		}
+		boundary.SortIssues(result.Issues)
+		for _, issue := range result.Issues {
+			fmt.Printf("    %s: %s\n", issue.Severity, issue)
+		}
+	}
+
+	withErrors, withWarnings := boundary.CheckSummary(results)
+	fmt.Printf("%d boundaries checked: %d valid, %d with warnings, %d with errors\n",
+		len(results), len(results)-withErrors-withWarnings, withWarnings, withErrors)
+	if withErrors > 0 {
+		return fmt.Errorf("%d of %d boundaries have invalid geometries", withErrors, len(results))
+	}
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(boundaryCmd)
+}
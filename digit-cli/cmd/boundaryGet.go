@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// boundaryGetCmd represents the boundary get command
+var boundaryGetCmd = &cobra.Command{
+	Use:   "get <code>...",
+	Short: "Show boundaries by code",
+	Long: `Show the geometry, bounds (south-west and north-east corners as lat,lon) and
+additional details of boundaries. With --output, the boundaries are also
+written to a .yaml or .geojson file, e.g. for 'digit boundary locate --file'.
+
+Examples:
+  digit boundary get W001
+  digit boundary get W001 W002 --output wards.geojson`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Get flag values
+		outputPath, _ := cmd.Flags().GetString("output")
+		serverURL, _ := cmd.Flags().GetString("server")
+		jwtToken, _ := cmd.Flags().GetString("jwt-token")
+
+		serverURL, jwtToken, tenantID, clientID, err := clientSettings(serverURL, jwtToken)
+		if err != nil {
+			return err
+		}
+
+		boundaries, err := fetchBoundaries(serverURL, jwtToken, tenantID, clientID, args)
+		if err != nil {
+			return err
+		}
+		if len(boundaries) > 0 {
+			if err := printBoundaries(boundaries, nil); err != nil {
+				return err
+			}
+		}
+		if outputPath != "" && len(boundaries) > 0 {
+			if err := writeFetchedBoundaries(outputPath, boundaries); err != nil {
+				return err
+			}
+		}
+
+		if missing := missingBoundaryCodes(args, boundaries); len(missing) > 0 {
+			return fmt.Errorf("%d of %d boundaries not found: %s", len(missing), len(args), strings.Join(missing, ", "))
+		}
+		return nil
+	},
+}
+
+func init() {
+	boundaryCmd.AddCommand(boundaryGetCmd)
+
+	// Add flags
+	boundaryGetCmd.Flags().StringP("output", "o", "", "Also write the boundaries to a .yaml or .geojson file")
+	boundaryGetCmd.Flags().String("server", "", "Server URL (overrides config)")
+	boundaryGetCmd.Flags().String("jwt-token", "", "JWT token for authentication (overrides config)")
+}
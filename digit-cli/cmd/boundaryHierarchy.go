@@ -0,0 +1,16 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// boundaryHierarchyCmd represents the boundary hierarchy command
+var boundaryHierarchyCmd = &cobra.Command{
+	Use:   "hierarchy",
+	Short: "Define and view boundary hierarchies",
+	Long:  `Define boundary hierarchy types such as ADMIN: State > District > Ward and view their definitions.`,
+}
+
+func init() {
+	boundaryCmd.AddCommand(boundaryHierarchyCmd)
+}
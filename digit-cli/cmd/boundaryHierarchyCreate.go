@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"digit-cli/pkg/boundary"
+	"github.com/digitnxt/digit3/code/libraries/digit-library/digit"
+	"github.com/spf13/cobra"
+)
+
+// boundaryHierarchyCreateCmd represents the boundary hierarchy create command
+var boundaryHierarchyCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a boundary hierarchy definition",
+	Long: `Create a boundary hierarchy definition, either as a list of boundary types from
+the top level down or from the levels of a hierarchy file.
+
+In a hierarchy file a level without a parent is placed under the level declared
+before it; give a parent to declare branches:
+  hierarchy-type: ADMIN
+  levels:
+    - boundary-type: State
+    - boundary-type: District
+    - boundary-type: Ward
+    - boundary-type: Zone
+      parent: District
+
+Examples:
+  digit boundary hierarchy create --hierarchy ADMIN --levels State,District,Ward
+  digit boundary hierarchy create -f admin-hierarchy.yaml`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Get flag values
+		hierarchyType, _ := cmd.Flags().GetString("hierarchy")
+		levelsFlag, _ := cmd.Flags().GetStringSlice("levels")
+		filePath, _ := cmd.Flags().GetString("file")
+		serverURL, _ := cmd.Flags().GetString("server")
+		jwtToken, _ := cmd.Flags().GetString("jwt-token")
+
+		var hierarchy *boundary.Hierarchy
+		switch {
+		case filePath != "" && len(levelsFlag) > 0:
+			return fmt.Errorf("cannot use both --file and --levels flags together")
+		case filePath != "":
+			file, err := boundary.LoadFile(filePath)
+			if err != nil {
+				return err
+			}
+			if hierarchyType != "" && hierarchyType != file.HierarchyType {
+				return fmt.Errorf("--hierarchy %s does not match hierarchy-type %s in %s", hierarchyType, file.HierarchyType, filePath)
+			}
+			if hierarchy, err = file.Hierarchy(); err != nil {
+				return err
+			}
+		case len(levelsFlag) > 0:
+			if hierarchyType == "" {
+				return fmt.Errorf("--hierarchy is required with --levels")
+			}
+			for i := range levelsFlag {
+				levelsFlag[i] = strings.TrimSpace(levelsFlag[i])
+			}
+			levels, err := boundary.OrderLevels(boundary.ChainLevels(levelsFlag))
+			if err != nil {
+				return err
+			}
+			hierarchy = &boundary.Hierarchy{Type: hierarchyType, Levels: levels}
+		default:
+			return fmt.Errorf("either --levels or --file flag is required")
+		}
+
+		serverURL, jwtToken, tenantID, clientID, err := clientSettings(serverURL, jwtToken)
+		if err != nil {
+			return err
+		}
+
+		levels := make([]digit.BoundaryHierarchyLevel, 0, len(hierarchy.Levels))
+		for _, level := range hierarchy.Levels {
+			levels = append(levels, digit.BoundaryHierarchyLevel{
+				BoundaryType:       level.BoundaryType,
+				ParentBoundaryType: level.ParentBoundaryType,
+				Active:             level.Active,
+			})
+		}
+
+		if _, err := digit.CreateBoundaryHierarchy(serverURL, jwtToken, tenantID, clientID, hierarchy.Type, levels); err != nil {
+			return fmt.Errorf("failed to create boundary hierarchy: %w", err)
+		}
+
+		fmt.Printf("✓ Created boundary hierarchy %s: %s\n", hierarchy.Type, hierarchy.Format())
+		return nil
+	},
+}
+
+func init() {
+	boundaryHierarchyCmd.AddCommand(boundaryHierarchyCreateCmd)
+
+	// Add flags
+	boundaryHierarchyCreateCmd.Flags().String("hierarchy", "", "Hierarchy type, e.g. ADMIN")
+	boundaryHierarchyCreateCmd.Flags().StringSlice("levels", nil, "Boundary types from the top level down, e.g. State,District,Ward")
+	boundaryHierarchyCreateCmd.Flags().StringP("file", "f", "", "Hierarchy file declaring the levels")
+	boundaryHierarchyCreateCmd.Flags().String("server", "", "Server URL (overrides config)")
+	boundaryHierarchyCreateCmd.Flags().String("jwt-token", "", "JWT token for authentication (overrides config)")
+}
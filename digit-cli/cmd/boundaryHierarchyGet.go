@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+)
+
+// boundaryHierarchyGetCmd represents the boundary hierarchy get command
+var boundaryHierarchyGetCmd = &cobra.Command{
+	Use:   "get <hierarchy-type>",
+	Short: "Show a boundary hierarchy definition",
+	Long: `Show the boundary types of a hierarchy definition and their parents.
+
+Examples:
+  digit boundary hierarchy get ADMIN`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Get flag values
+		serverURL, _ := cmd.Flags().GetString("server")
+		jwtToken, _ := cmd.Flags().GetString("jwt-token")
+
+		serverURL, jwtToken, tenantID, clientID, err := clientSettings(serverURL, jwtToken)
+		if err != nil {
+			return err
+		}
+
+		hierarchy, err := fetchBoundaryHierarchy(serverURL, jwtToken, tenantID, clientID, args[0])
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Hierarchy %s: %s\n\n", hierarchy.Type, hierarchy.Format())
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "BOUNDARY TYPE\tPARENT\tACTIVE")
+		for _, level := range hierarchy.Levels {
+			parent := level.ParentBoundaryType
+			if parent == "" {
+				parent = "-"
+			}
+			fmt.Fprintf(w, "%s\t%s\t%t\n", level.BoundaryType, parent, level.Active)
+		}
+		return w.Flush()
+	},
+}
+
+func init() {
+	boundaryHierarchyCmd.AddCommand(boundaryHierarchyGetCmd)
+
+	// Add flags
+	boundaryHierarchyGetCmd.Flags().String("server", "", "Server URL (overrides config)")
+	boundaryHierarchyGetCmd.Flags().String("jwt-token", "", "JWT token for authentication (overrides config)")
+}
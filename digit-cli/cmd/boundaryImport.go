@@ -0,0 +1,214 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"digit-cli/pkg/boundary"
+	"github.com/digitnxt/digit3/code/libraries/digit-library/digit"
+	"github.com/spf13/cobra"
+)
+
+// boundaryImportCmd represents the boundary import command
+var boundaryImportCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Import boundaries from GeoJSON, KML or Shapefile",
+	Long: `Create boundaries from the features of a GIS file: a GeoJSON FeatureCollection
+(.geojson, .json), a KML document (.kml, .kmz) or a Shapefile (.shp, or a .zip
+containing the .shp, .dbf and optional .prj files).
+
+Each feature becomes a boundary whose code is taken from --code-property and whose
+additionalDetails are the feature's properties (or only those given with --properties).
+Coordinates must be longitude/latitude in WGS 84; projected files are rejected.
+Geometries are validated as by 'boundary validate' and nothing is uploaded while any
+is invalid; --repair and --simplify fix and shrink them on the way.
+
+With --hierarchy and --type the imported boundaries are also linked into a hierarchy,
+under the boundary named by --parent-property when one is given. The relationships
+are checked against the hierarchy before any boundary is created.
+
+Examples:
+  # Preview what would be imported
+  digit boundary import --file wards.geojson --code-property WARD_ID --dry-run
+
+  # Import wards and link them under their zones
+  digit boundary import --file wards.geojson --code-property WARD_ID \
+    --parent-property ZONE_ID --hierarchy ADMIN --type Ward
+
+  # Import a zipped shapefile, keeping only some attributes
+  digit boundary import --file wards.zip --code-property WARD_NO --properties WARD_NAME,AREA_SQKM`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Get flag values
+		filePath, _ := cmd.Flags().GetString("file")
+		codeProperty, _ := cmd.Flags().GetString("code-property")
+		parentProperty, _ := cmd.Flags().GetString("parent-property")
+		properties, _ := cmd.Flags().GetStringSlice("properties")
+		layer, _ := cmd.Flags().GetString("layer")
+		hierarchyType, _ := cmd.Flags().GetString("hierarchy")
+		boundaryType, _ := cmd.Flags().GetString("type")
+		repair, _ := cmd.Flags().GetBool("repair")
+		tolerance, _ := cmd.Flags().GetFloat64("simplify")
+		batchSize, _ := cmd.Flags().GetInt("batch-size")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		serverURL, _ := cmd.Flags().GetString("server")
+		jwtToken, _ := cmd.Flags().GetString("jwt-token")
+
+		if parentProperty != "" && hierarchyType == "" {
+			return fmt.Errorf("--hierarchy and --type are required with --parent-property")
+		}
+		if (hierarchyType == "") != (boundaryType == "") {
+			return fmt.Errorf("--hierarchy and --type must be given together")
+		}
+		if tolerance < 0 {
+			return fmt.Errorf("--simplify tolerance cannot be negative")
+		}
+		if batchSize < 1 {
+			return fmt.Errorf("--batch-size must be at least 1")
+		}
+
+		features, err := boundary.ReadFeatures(filePath, layer)
+		if err != nil {
+			return err
+		}
+		boundaries, parents, err := boundary.BuildBoundaries(features, boundary.ImportOptions{
+			CodeProperty:   codeProperty,
+			ParentProperty: parentProperty,
+			Properties:     properties,
+		})
+		if err != nil {
+			return fmt.Errorf("%s: %w", filepath.Base(filePath), err)
+		}
+		fmt.Printf("Read %d features from %s (%s)\n", len(boundaries), filepath.Base(filePath), geometrySummary(boundaries))
+
+		// Invalid geometries are never uploaded
+		results := boundary.CheckBoundaries(boundaries, boundary.CheckOptions{Repair: repair, Tolerance: tolerance})
+		if err := printBoundaryChecks(results); err != nil {
+			return fmt.Errorf("%w; fix them or try --repair", err)
+		}
+
+		// A dry run only needs the server to check relationships
+		var tenantID, clientID string
+		if !dryRun || hierarchyType != "" {
+			if serverURL, jwtToken, tenantID, clientID, err = clientSettings(serverURL, jwtToken); err != nil {
+				return err
+			}
+		}
+
+		// Check the relationships before creating anything
+		var plan *boundaryLinkPlan
+		if hierarchyType != "" {
+			specs := make([]boundary.RelationshipSpec, 0, len(boundaries))
+			pending := map[string]string{}
+			for _, b := range boundaries {
+				specs = append(specs, boundary.RelationshipSpec{Code: b.Code, BoundaryType: boundaryType, Parent: parents[b.Code]})
+				pending[b.Code] = boundaryType
+			}
+			if plan, err = planBoundaryLinks(serverURL, jwtToken, tenantID, clientID, hierarchyType, specs, pending); err != nil {
+				return err
+			}
+		}
+
+		if dryRun {
+			fmt.Println()
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "CODE\tGEOMETRY\tVERTICES\tPARENT\tDETAILS")
+			for _, b := range boundaries {
+				parent := parents[b.Code]
+				if parent == "" {
+					parent = "-"
+				}
+				fmt.Fprintf(w, "%s\t%s\t%d\t%s\t%d\n", b.Code, b.Geometry.Type, b.Geometry.VertexCount(), parent, len(b.AdditionalDetails))
+			}
+			if err := w.Flush(); err != nil {
+				return err
+			}
+			if plan != nil {
+				fmt.Printf("\nRelationships in hierarchy %s:\n", plan.hierarchy.Type)
+				if err := applyBoundaryLinks(serverURL, jwtToken, tenantID, clientID, plan, true); err != nil {
+					return err
+				}
+			}
+			fmt.Println("\nDry run: no changes were made.")
+			return nil
+		}
+
+		failed := 0
+		for start := 0; start < len(boundaries); start += batchSize {
+			end := start + batchSize
+			if end > len(boundaries) {
+				end = len(boundaries)
+			}
+			label := fmt.Sprintf("boundaries %d-%d of %d", start+1, end, len(boundaries))
+			batch := make([]map[string]interface{}, 0, end-start)
+			for _, b := range boundaries[start:end] {
+				batch = append(batch, b.Map())
+			}
+			if _, err := digit.CreateBoundaries(serverURL, jwtToken, tenantID, clientID, batch); err != nil {
+				failed += end - start
+				fmt.Printf("✗ %s: %v\n", label, err)
+				continue
+			}
+			fmt.Printf("✓ Created %s\n", label)
+		}
+		if failed > 0 {
+			if plan != nil {
+				fmt.Println("Relationships were not created because some boundaries could not be created.")
+			}
+			return fmt.Errorf("%d of %d boundaries could not be created", failed, len(boundaries))
+		}
+
+		if plan != nil {
+			fmt.Printf("\nLinking into hierarchy %s:\n", plan.hierarchy.Type)
+			return applyBoundaryLinks(serverURL, jwtToken, tenantID, clientID, plan, false)
+		}
+		return nil
+	},
+}
+
+// geometrySummary counts boundaries by geometry type, e.g. "40 Polygon, 2 MultiPolygon"
+func geometrySummary(boundaries []boundary.Boundary) string {
+	counts := map[string]int{}
+	for _, b := range boundaries {
+		if b.Geometry != nil {
+			counts[b.Geometry.Type]++
+		}
+	}
+	types := make([]string, 0, len(counts))
+	for geometryType := range counts {
+		types = append(types, geometryType)
+	}
+	sort.Strings(types)
+	parts := make([]string, 0, len(types))
+	for _, geometryType := range types {
+		parts = append(parts, fmt.Sprintf("%d %s", counts[geometryType], geometryType))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func init() {
+	boundaryCmd.AddCommand(boundaryImportCmd)
+
+	// Add flags
+	boundaryImportCmd.Flags().StringP("file", "f", "", "GeoJSON, KML/KMZ or Shapefile (.shp or .zip) to import")
+	boundaryImportCmd.Flags().String("code-property", "", "Feature property holding the boundary code")
+	boundaryImportCmd.Flags().String("parent-property", "", "Feature property holding the parent boundary code")
+	boundaryImportCmd.Flags().StringSlice("properties", nil, "Properties to copy to additionalDetails (default all)")
+	boundaryImportCmd.Flags().String("layer", "", "Shapefile to import when the zip contains several")
+	boundaryImportCmd.Flags().String("hierarchy", "", "Hierarchy type to link the boundaries into, e.g. ADMIN")
+	boundaryImportCmd.Flags().String("type", "", "Boundary type of the imported boundaries, e.g. Ward")
+	boundaryImportCmd.Flags().Bool("repair", false, "Close rings, remove repeated positions and fix winding order before uploading")
+	boundaryImportCmd.Flags().Float64("simplify", 0, "Simplify rings with this Douglas–Peucker tolerance in metres before uploading")
+	boundaryImportCmd.Flags().Int("batch-size", 100, "Number of boundaries created per request")
+	boundaryImportCmd.Flags().Bool("dry-run", false, "Show the boundaries that would be created without creating them")
+	boundaryImportCmd.Flags().String("server", "", "Server URL (overrides config)")
+	boundaryImportCmd.Flags().String("jwt-token", "", "JWT token for authentication (overrides config)")
+
+	// Mark required flags
+	boundaryImportCmd.MarkFlagRequired("file")
+	boundaryImportCmd.MarkFlagRequired("code-property")
+}
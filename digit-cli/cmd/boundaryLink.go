@@ -0,0 +1,216 @@
+package cmd
+
+import (
+	"fmt"
+
+	"digit-cli/pkg/boundary"
+	"github.com/digitnxt/digit3/code/libraries/digit-library/digit"
+	"github.com/spf13/cobra"
+)
+
+// boundaryLinkCmd represents the boundary link command
+var boundaryLinkCmd = &cobra.Command{
+	Use:   "link [code]",
+	Short: "Link boundaries to their parents within a hierarchy",
+	Long: `Link a boundary to its parent within a hierarchy, or link all the boundaries
+declared in the relationships of a hierarchy file.
+
+Every boundary type must be part of the hierarchy and every parent must be of the
+parent boundary type; this is checked for all boundaries before anything is linked.
+Parents are linked before their children. Boundaries that are already linked to
+the same parent are left unchanged, and boundaries linked to another parent are
+moved to the new one.
+
+File format:
+  hierarchy-type: ADMIN
+  relationships:
+    - code: KA
+      boundary-type: State
+    - code: BLR
+      boundary-type: District
+      parent: KA
+
+Examples:
+  digit boundary link KA --hierarchy ADMIN --type State
+  digit boundary link BLR --hierarchy ADMIN --type District --parent KA
+  digit boundary link -f admin-hierarchy.yaml --dry-run
+  digit boundary link -f admin-hierarchy.yaml`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Get flag values
+		hierarchyType, _ := cmd.Flags().GetString("hierarchy")
+		boundaryType, _ := cmd.Flags().GetString("type")
+		parent, _ := cmd.Flags().GetString("parent")
+		filePath, _ := cmd.Flags().GetString("file")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		serverURL, _ := cmd.Flags().GetString("server")
+		jwtToken, _ := cmd.Flags().GetString("jwt-token")
+
+		var specs []boundary.RelationshipSpec
+		switch {
+		case filePath != "" && len(args) > 0:
+			return fmt.Errorf("cannot link a boundary code and --file together")
+		case filePath != "":
+			file, err := boundary.LoadFile(filePath)
+			if err != nil {
+				return err
+			}
+			if hierarchyType != "" && hierarchyType != file.HierarchyType {
+				return fmt.Errorf("--hierarchy %s does not match hierarchy-type %s in %s", hierarchyType, file.HierarchyType, filePath)
+			}
+			if len(file.Relationships) == 0 {
+				return fmt.Errorf("hierarchy file has no relationships")
+			}
+			hierarchyType = file.HierarchyType
+			specs = file.Relationships
+		case len(args) == 1:
+			if hierarchyType == "" || boundaryType == "" {
+				return fmt.Errorf("--hierarchy and --type are required when linking a boundary")
+			}
+			specs = []boundary.RelationshipSpec{{Code: args[0], BoundaryType: boundaryType, Parent: parent}}
+		default:
+			return fmt.Errorf("either a boundary code or --file is required")
+		}
+
+		serverURL, jwtToken, tenantID, clientID, err := clientSettings(serverURL, jwtToken)
+		if err != nil {
+			return err
+		}
+
+		plan, err := planBoundaryLinks(serverURL, jwtToken, tenantID, clientID, hierarchyType, specs, nil)
+		if err != nil {
+			return err
+		}
+		if err := applyBoundaryLinks(serverURL, jwtToken, tenantID, clientID, plan, dryRun); err != nil {
+			return err
+		}
+		if dryRun {
+			fmt.Println("\nDry run: no changes were made.")
+		}
+		return nil
+	},
+}
+
+// boundaryLinkPlan is a checked set of boundary relationships, ordered parents first
+type boundaryLinkPlan struct {
+	hierarchy *boundary.Hierarchy
+	specs     []boundary.RelationshipSpec
+	linked    map[string]*boundary.Node
+}
+
+// planBoundaryLinks orders relationships and checks them against the hierarchy definition and the
+// boundaries already linked into it. Pending are the boundary types of boundaries that are about
+// to be created and linked by the same command, so that they can be used as parents.
+func planBoundaryLinks(serverURL, jwtToken, tenantID, clientID, hierarchyType string, specs []boundary.RelationshipSpec, pending map[string]string) (*boundaryLinkPlan, error) {
+	specs, err := boundary.OrderRelationships(specs)
+	if err != nil {
+		return nil, err
+	}
+
+	hierarchy, err := fetchBoundaryHierarchy(serverURL, jwtToken, tenantID, clientID, hierarchyType)
+	if err != nil {
+		return nil, err
+	}
+	roots, err := fetchBoundaryTree(serverURL, jwtToken, tenantID, clientID, hierarchyType)
+	if err != nil {
+		return nil, err
+	}
+	linked := boundary.Flatten(roots)
+	linkedTypes := map[string]string{}
+	for code, boundaryType := range pending {
+		linkedTypes[code] = boundaryType
+	}
+	for code, node := range linked {
+		linkedTypes[code] = node.BoundaryType
+	}
+	if err := boundary.CheckRelationships(hierarchy, specs, linkedTypes); err != nil {
+		return nil, fmt.Errorf("boundaries do not fit hierarchy %s (%s):\n%w", hierarchy.Type, hierarchy.Format(), err)
+	}
+	return &boundaryLinkPlan{hierarchy: hierarchy, specs: specs, linked: linked}, nil
+}
+
+// applyBoundaryLinks links the boundaries of a plan, moving boundaries already linked to another
+// parent and skipping the children of boundaries that could not be linked
+func applyBoundaryLinks(serverURL, jwtToken, tenantID, clientID string, plan *boundaryLinkPlan, dryRun bool) error {
+	linkedCount, movedCount, failed := 0, 0, 0
+	failedCodes := map[string]bool{}
+	for _, spec := range plan.specs {
+		label := spec.Code
+		if spec.Parent != "" {
+			label += " → " + spec.Parent
+		}
+
+		if failedCodes[spec.Parent] {
+			failed++
+			failedCodes[spec.Code] = true
+			fmt.Printf("✗ %s: skipped because parent %s could not be linked\n", label, spec.Parent)
+			continue
+		}
+
+		relationship := digit.BoundaryRelationship{
+			TenantID:      tenantID,
+			Code:          spec.Code,
+			HierarchyType: plan.hierarchy.Type,
+			BoundaryType:  spec.BoundaryType,
+			Parent:        spec.Parent,
+		}
+		existing, isLinked := plan.linked[spec.Code]
+		switch {
+		case isLinked && existing.Parent == spec.Parent:
+			fmt.Printf("= %s (already linked)\n", label)
+			continue
+		case isLinked && existing.ID == "":
+			failed++
+			failedCodes[spec.Code] = true
+			fmt.Printf("✗ %s: already linked to %s and the server did not return its relationship ID\n", label, existing.Parent)
+			continue
+		case dryRun && isLinked:
+			fmt.Printf("~ %s (moved from %s)\n", label, existing.Parent)
+			continue
+		case dryRun:
+			fmt.Printf("+ %s\n", label)
+			continue
+		case isLinked:
+			relationship.ID = existing.ID
+			if _, err := digit.UpdateBoundaryRelationship(serverURL, jwtToken, tenantID, clientID, relationship); err != nil {
+				failed++
+				failedCodes[spec.Code] = true
+				fmt.Printf("✗ %s: %v\n", label, err)
+				continue
+			}
+			movedCount++
+			fmt.Printf("✓ Moved %s from %s\n", label, existing.Parent)
+		default:
+			if _, err := digit.CreateBoundaryRelationships(serverURL, jwtToken, tenantID, clientID, []digit.BoundaryRelationship{relationship}); err != nil {
+				failed++
+				failedCodes[spec.Code] = true
+				fmt.Printf("✗ %s: %v\n", label, err)
+				continue
+			}
+			linkedCount++
+			fmt.Printf("✓ Linked %s\n", label)
+		}
+	}
+
+	if dryRun {
+		return nil
+	}
+	fmt.Printf("\n%d linked, %d moved, %d unchanged\n", linkedCount, movedCount, len(plan.specs)-linkedCount-movedCount-failed)
+	if failed > 0 {
+		return fmt.Errorf("%d of %d boundaries could not be linked", failed, len(plan.specs))
+	}
+	return nil
+}
+
+func init() {
+	boundaryCmd.AddCommand(boundaryLinkCmd)
+
+	// Add flags
+	boundaryLinkCmd.Flags().String("hierarchy", "", "Hierarchy type, e.g. ADMIN")
+	boundaryLinkCmd.Flags().String("type", "", "Boundary type of the boundary, e.g. District")
+	boundaryLinkCmd.Flags().String("parent", "", "Code of the parent boundary (omit for a top-level boundary)")
+	boundaryLinkCmd.Flags().StringP("file", "f", "", "Hierarchy file declaring the relationships")
+	boundaryLinkCmd.Flags().Bool("dry-run", false, "Show what would be linked without changing anything")
+	boundaryLinkCmd.Flags().String("server", "", "Server URL (overrides config)")
+	boundaryLinkCmd.Flags().String("jwt-token", "", "JWT token for authentication (overrides config)")
+}
@@ -0,0 +1,254 @@
+package cmd
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"digit-cli/pkg/boundary"
+	"github.com/digitnxt/digit3/code/libraries/digit-library/geometry"
+	"github.com/spf13/cobra"
+)
+
+// boundaryLocateCmd represents the boundary locate command
+var boundaryLocateCmd = &cobra.Command{
+	Use:   "locate",
+	Short: "Find the boundaries containing a point",
+	Long: `Find the boundaries whose geometry contains a latitude/longitude, e.g. to check
+the geotag of a complaint or survey. The boundaries are read from a file, such
+as one written by 'digit boundary search --output', or fetched from the server
+by code or by hierarchy. A point on a boundary's outline counts as inside it.
+
+When no boundary contains the point, the nearest boundaries are listed with
+their distance, and a hint is shown if swapping latitude and longitude would
+place the point inside a boundary.
+
+Examples:
+  digit boundary locate --lat 28.55 --lon 77.05 --hierarchy ADMIN
+  digit boundary locate --lat 28.55 --lon 77.05 --hierarchy ADMIN --type Ward
+  digit boundary locate --lat 28.55 --lon 77.05 --codes W001,W002
+  digit boundary locate --lat 28.55 --lon 77.05 --file wards.geojson`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Get flag values
+		lat, _ := cmd.Flags().GetFloat64("lat")
+		lon, _ := cmd.Flags().GetFloat64("lon")
+		filePath, _ := cmd.Flags().GetString("file")
+		codeProperty, _ := cmd.Flags().GetString("code-property")
+		layer, _ := cmd.Flags().GetString("layer")
+		codes, _ := cmd.Flags().GetStringSlice("codes")
+		hierarchyType, _ := cmd.Flags().GetString("hierarchy")
+		boundaryType, _ := cmd.Flags().GetString("type")
+		nearest, _ := cmd.Flags().GetInt("nearest")
+		serverURL, _ := cmd.Flags().GetString("server")
+		jwtToken, _ := cmd.Flags().GetString("jwt-token")
+
+		switch {
+		case math.Abs(lat) > 90 && math.Abs(lon) <= 90:
+			return fmt.Errorf("latitude %g is out of range; are --lat and --lon swapped?", lat)
+		case math.Abs(lat) > 90 || math.Abs(lon) > 180:
+			return fmt.Errorf("%g,%g is not a valid latitude/longitude", lat, lon)
+		case filePath == "" && hierarchyType == "" && len(codes) == 0:
+			return fmt.Errorf("either --file, --hierarchy or --codes is required")
+		case filePath != "" && hierarchyType != "":
+			return fmt.Errorf("--file and --hierarchy cannot be combined")
+		case boundaryType != "" && hierarchyType == "":
+			return fmt.Errorf("--type requires --hierarchy")
+		}
+
+		// Collect the candidate boundaries
+		var candidates []boundary.Boundary
+		var nodes map[string]*boundary.Node
+		if filePath != "" {
+			boundaries, err := loadBoundaries(filePath, codeProperty, layer)
+			if err != nil {
+				return err
+			}
+			if len(codes) > 0 {
+				if boundaries, err = selectBoundaries(boundaries, codes); err != nil {
+					return err
+				}
+			}
+			candidates = boundaries
+		} else {
+			serverURL, jwtToken, tenantID, clientID, err := clientSettings(serverURL, jwtToken)
+			if err != nil {
+				return err
+			}
+			if hierarchyType != "" {
+				roots, err := fetchBoundaryTree(serverURL, jwtToken, tenantID, clientID, hierarchyType)
+				if err != nil {
+					return err
+				}
+				nodes = boundary.Flatten(roots)
+				wanted := map[string]bool{}
+				for _, code := range codes {
+					wanted[code] = true
+				}
+				codes = nil
+				for code, n := range nodes {
+					if (boundaryType == "" || n.BoundaryType == boundaryType) && (len(wanted) == 0 || wanted[code]) {
+						codes = append(codes, code)
+					}
+				}
+				sort.Strings(codes)
+				if len(codes) == 0 {
+					return fmt.Errorf("no boundaries to search in hierarchy %s", hierarchyType)
+				}
+			}
+			fetched, err := fetchBoundaries(serverURL, jwtToken, tenantID, clientID, codes)
+			if err != nil {
+				return err
+			}
+			for _, b := range fetched {
+				candidates = append(candidates, boundary.Boundary{Code: b.Code, Geometry: b.Geometry, AdditionalDetails: b.AdditionalDetails})
+			}
+		}
+
+		point := geometry.Position{lon, lat}
+		var inside []boundary.Boundary
+		withoutGeometry := 0
+		for _, b := range candidates {
+			if b.Geometry == nil {
+				withoutGeometry++
+				continue
+			}
+			if geometry.Contains(b.Geometry, point) {
+				inside = append(inside, b)
+			}
+		}
+		if withoutGeometry > 0 {
+			fmt.Printf("! %d of %d boundaries have no geometry and were skipped\n\n", withoutGeometry, len(candidates))
+		}
+
+		if len(inside) > 0 {
+			printLocatedBoundaries(lat, lon, inside, nodes)
+			return nil
+		}
+
+		fmt.Printf("Point %g,%g is not inside any of the %d boundaries searched\n", lat, lon, len(candidates)-withoutGeometry)
+		if nearest > 0 {
+			printNearestBoundaries(point, candidates, nearest)
+		}
+		swapped := geometry.Position{lat, lon}
+		if math.Abs(lon) <= 90 {
+			for _, b := range candidates {
+				if b.Geometry != nil && geometry.Contains(b.Geometry, swapped) {
+					fmt.Printf("\nHint: with latitude and longitude swapped (%g,%g) the point is inside %s;\n", lon, lat, b.Code)
+					fmt.Println("the coordinates may be sent in the wrong order.")
+					break
+				}
+			}
+		}
+		return nil
+	},
+}
+
+// printLocatedBoundaries prints the boundaries containing a point; within a hierarchy they are
+// ordered from the top level down and shown with their boundary type and path
+func printLocatedBoundaries(lat, lon float64, inside []boundary.Boundary, nodes map[string]*boundary.Node) {
+	paths := map[string][]string{}
+	for _, b := range inside {
+		var path []string
+		seen := map[string]bool{}
+		for code := b.Code; code != "" && !seen[code]; {
+			seen[code] = true
+			path = append([]string{code}, path...)
+			n := nodes[code]
+			if n == nil {
+				break
+			}
+			code = n.Parent
+		}
+		paths[b.Code] = path
+	}
+	sort.Slice(inside, func(i, j int) bool {
+		if len(paths[inside[i].Code]) != len(paths[inside[j].Code]) {
+			return len(paths[inside[i].Code]) < len(paths[inside[j].Code])
+		}
+		return inside[i].Code < inside[j].Code
+	})
+
+	fmt.Printf("Point %g,%g is inside %d boundaries:\n\n", lat, lon, len(inside))
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	if nodes != nil {
+		fmt.Fprintln(w, "CODE\tTYPE\tPATH")
+		for _, b := range inside {
+			boundaryType := "-"
+			if n := nodes[b.Code]; n != nil && n.BoundaryType != "" {
+				boundaryType = n.BoundaryType
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\n", b.Code, boundaryType, strings.Join(paths[b.Code], " > "))
+		}
+	} else {
+		fmt.Fprintln(w, "CODE\tDETAILS")
+		for _, b := range inside {
+			fmt.Fprintf(w, "%s\t%s\n", b.Code, formatDetails(b.AdditionalDetails))
+		}
+	}
+	w.Flush()
+}
+
+// printNearestBoundaries prints up to limit boundaries closest to a point, with their distance
+func printNearestBoundaries(point geometry.Position, candidates []boundary.Boundary, limit int) {
+	type candidate struct {
+		code     string
+		distance float64
+	}
+	var ranked []candidate
+	for _, b := range candidates {
+		if b.Geometry != nil {
+			ranked = append(ranked, candidate{b.Code, geometry.Distance(b.Geometry, point)})
+		}
+	}
+	if len(ranked) == 0 {
+		return
+	}
+	sort.Slice(ranked, func(i, j int) bool { return ranked[i].distance < ranked[j].distance })
+	if len(ranked) > limit {
+		ranked = ranked[:limit]
+	}
+
+	fmt.Println("\nNearest boundaries:")
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "CODE\tDISTANCE")
+	for _, c := range ranked {
+		fmt.Fprintf(w, "%s\t%s\n", c.code, formatDistance(c.distance))
+	}
+	w.Flush()
+}
+
+// formatDistance formats a distance in metres, or kilometres from 10 km
+func formatDistance(metres float64) string {
+	if metres >= 10000 {
+		return fmt.Sprintf("%.0f km", metres/1000)
+	}
+	if metres >= 1000 {
+		return fmt.Sprintf("%.1f km", metres/1000)
+	}
+	return fmt.Sprintf("%.0f m", metres)
+}
+
+func init() {
+	boundaryCmd.AddCommand(boundaryLocateCmd)
+
+	// Add flags
+	boundaryLocateCmd.Flags().Float64("lat", 0, "Latitude of the point, e.g. 28.55")
+	boundaryLocateCmd.Flags().Float64("lon", 0, "Longitude of the point, e.g. 77.05")
+	boundaryLocateCmd.Flags().StringP("file", "f", "", "Read the boundaries from a boundaries YAML file, GeoJSON, KML/KMZ or Shapefile")
+	boundaryLocateCmd.Flags().String("code-property", "code", "Feature property holding the boundary code (GIS files)")
+	boundaryLocateCmd.Flags().String("layer", "", "Shapefile to read when the zip contains several")
+	boundaryLocateCmd.Flags().StringSlice("codes", nil, "Only search these boundary codes")
+	boundaryLocateCmd.Flags().String("hierarchy", "", "Search the boundaries linked into this hierarchy type")
+	boundaryLocateCmd.Flags().String("type", "", "Only search boundaries of this boundary type, e.g. Ward")
+	boundaryLocateCmd.Flags().Int("nearest", 3, "Number of nearest boundaries to list when none contains the point")
+	boundaryLocateCmd.Flags().String("server", "", "Server URL (overrides config)")
+	boundaryLocateCmd.Flags().String("jwt-token", "", "JWT token for authentication (overrides config)")
+
+	// Mark required flags
+	boundaryLocateCmd.MarkFlagRequired("lat")
+	boundaryLocateCmd.MarkFlagRequired("lon")
+}
@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"digit-cli/pkg/boundary"
+	"github.com/spf13/cobra"
+)
+
+// boundarySearchCmd represents the boundary search command
+var boundarySearchCmd = &cobra.Command{
+	Use:   "search",
+	Short: "Search boundaries by code or within a hierarchy",
+	Long: `Search boundaries by code, or list the boundaries linked into a hierarchy,
+optionally only those of one boundary type or under one parent boundary.
+With --output, the boundaries found are written to a .yaml or .geojson file.
+
+Examples:
+  digit boundary search --codes W001,W002
+  digit boundary search --hierarchy ADMIN --type Ward
+  digit boundary search --hierarchy ADMIN --parent BLR --output blr.geojson`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Get flag values
+		codes, _ := cmd.Flags().GetStringSlice("codes")
+		hierarchyType, _ := cmd.Flags().GetString("hierarchy")
+		boundaryType, _ := cmd.Flags().GetString("type")
+		parentCode, _ := cmd.Flags().GetString("parent")
+		outputPath, _ := cmd.Flags().GetString("output")
+		serverURL, _ := cmd.Flags().GetString("server")
+		jwtToken, _ := cmd.Flags().GetString("jwt-token")
+
+		if hierarchyType == "" && len(codes) == 0 {
+			return fmt.Errorf("either --codes or --hierarchy is required")
+		}
+		if hierarchyType == "" && (boundaryType != "" || parentCode != "") {
+			return fmt.Errorf("--type and --parent require --hierarchy")
+		}
+
+		serverURL, jwtToken, tenantID, clientID, err := clientSettings(serverURL, jwtToken)
+		if err != nil {
+			return err
+		}
+
+		var nodes map[string]*boundary.Node
+		if hierarchyType != "" {
+			roots, err := fetchBoundaryTree(serverURL, jwtToken, tenantID, clientID, hierarchyType)
+			if err != nil {
+				return err
+			}
+			if parentCode != "" {
+				parent := boundary.Find(roots, parentCode)
+				if parent == nil {
+					return fmt.Errorf("boundary %s is not linked into hierarchy %s", parentCode, hierarchyType)
+				}
+				roots = []*boundary.Node{parent}
+			}
+			nodes = boundary.Flatten(roots)
+
+			wanted := map[string]bool{}
+			for _, code := range codes {
+				wanted[code] = true
+			}
+			codes = nil
+			for code, n := range nodes {
+				if (boundaryType == "" || n.BoundaryType == boundaryType) && (len(wanted) == 0 || wanted[code]) {
+					codes = append(codes, code)
+				}
+			}
+			sort.Strings(codes)
+		}
+		if len(codes) == 0 {
+			fmt.Println("No boundaries found")
+			return nil
+		}
+
+		boundaries, err := fetchBoundaries(serverURL, jwtToken, tenantID, clientID, codes)
+		if err != nil {
+			return err
+		}
+		if len(boundaries) == 0 {
+			fmt.Println("No boundaries found")
+		} else if err := printBoundaries(boundaries, nodes); err != nil {
+			return err
+		}
+
+		if missing := missingBoundaryCodes(codes, boundaries); len(missing) > 0 {
+			if hierarchyType != "" {
+				fmt.Printf("\n! %d linked boundaries have no boundary entity: %s\n", len(missing), strings.Join(missing, ", "))
+			} else {
+				fmt.Printf("\n! %d boundaries not found: %s\n", len(missing), strings.Join(missing, ", "))
+			}
+		}
+		if outputPath != "" && len(boundaries) > 0 {
+			return writeFetchedBoundaries(outputPath, boundaries)
+		}
+		return nil
+	},
+}
+
+func init() {
+	boundaryCmd.AddCommand(boundarySearchCmd)
+
+	// Add flags
+	boundarySearchCmd.Flags().StringSlice("codes", nil, "Boundary codes to search for, e.g. W001,W002")
+	boundarySearchCmd.Flags().String("hierarchy", "", "List the boundaries linked into this hierarchy type")
+	boundarySearchCmd.Flags().String("type", "", "Only boundaries of this boundary type, e.g. Ward")
+	boundarySearchCmd.Flags().String("parent", "", "Only this boundary and the boundaries under it")
+	boundarySearchCmd.Flags().StringP("output", "o", "", "Also write the boundaries to a .yaml or .geojson file")
+	boundarySearchCmd.Flags().String("server", "", "Server URL (overrides config)")
+	boundarySearchCmd.Flags().String("jwt-token", "", "JWT token for authentication (overrides config)")
+}
@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"digit-cli/pkg/boundary"
+	"github.com/spf13/cobra"
+)
+
+// boundaryTreeCmd represents the boundary tree command
+var boundaryTreeCmd = &cobra.Command{
+	Use:   "tree",
+	Short: "Print the boundary tree of a hierarchy",
+	Long: `Print the boundaries linked into a hierarchy as a tree, with their names
+and boundary types.
+
+Examples:
+  digit boundary tree --hierarchy ADMIN
+  digit boundary tree --hierarchy ADMIN --root BLR
+  digit boundary tree --hierarchy ADMIN --depth 2`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Get flag values
+		hierarchyType, _ := cmd.Flags().GetString("hierarchy")
+		rootCode, _ := cmd.Flags().GetString("root")
+		depth, _ := cmd.Flags().GetInt("depth")
+		serverURL, _ := cmd.Flags().GetString("server")
+		jwtToken, _ := cmd.Flags().GetString("jwt-token")
+
+		if depth < 0 {
+			return fmt.Errorf("--depth cannot be negative")
+		}
+
+		serverURL, jwtToken, tenantID, clientID, err := clientSettings(serverURL, jwtToken)
+		if err != nil {
+			return err
+		}
+
+		roots, err := fetchBoundaryTree(serverURL, jwtToken, tenantID, clientID, hierarchyType)
+		if err != nil {
+			return err
+		}
+		if rootCode != "" {
+			root := boundary.Find(roots, rootCode)
+			if root == nil {
+				return fmt.Errorf("boundary %s is not linked into hierarchy %s", rootCode, hierarchyType)
+			}
+			roots = []*boundary.Node{root}
+		}
+		if len(roots) == 0 {
+			fmt.Printf("No boundaries are linked into hierarchy %s\n", hierarchyType)
+			return nil
+		}
+
+		boundary.RenderTree(os.Stdout, roots, depth)
+		if depth == 0 {
+			fmt.Printf("\n%d boundaries\n", boundary.CountNodes(roots))
+		}
+		return nil
+	},
+}
+
+func init() {
+	boundaryCmd.AddCommand(boundaryTreeCmd)
+
+	// Add flags
+	boundaryTreeCmd.Flags().String("hierarchy", "", "Hierarchy type, e.g. ADMIN")
+	boundaryTreeCmd.Flags().String("root", "", "Only print the subtree of this boundary")
+	boundaryTreeCmd.Flags().Int("depth", 0, "Number of levels to print (0 for all)")
+	boundaryTreeCmd.Flags().String("server", "", "Server URL (overrides config)")
+	boundaryTreeCmd.Flags().String("jwt-token", "", "JWT token for authentication (overrides config)")
+
+	// Mark required flags
+	boundaryTreeCmd.MarkFlagRequired("hierarchy")
+}
@@ -0,0 +1,273 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"digit-cli/pkg/boundary"
+	"github.com/digitnxt/digit3/code/libraries/digit-library/digit"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// boundaryUpdateCmd represents the boundary update command
+var boundaryUpdateCmd = &cobra.Command{
+	Use:   "update [code]...",
+	Short: "Update the geometry or additional details of existing boundaries",
+	Long: `Update existing boundaries. With --file, the geometry and additional details of
+every boundary in the file are replaced by those in the file; name codes to
+update only some of them. Geometries are validated first, and can be repaired
+and simplified as with 'digit boundary validate'.
+
+--set and --unset change single additional details of the named boundaries, or
+of every boundary in the file. Values are read as YAML, so true and 12 become a
+boolean and a number; quote them to keep them as text.
+
+Boundaries that do not exist yet are not created; use 'digit boundary import'.
+
+Examples:
+  digit boundary update W001 --set WARD_NAME="Gandhi Nagar" --unset OLD_CODE
+  digit boundary update --file wards.geojson --code-property WARD_NO --repair --dry-run
+  digit boundary update W001 W002 --file wards.geojson --code-property WARD_NO`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Get flag values
+		filePath, _ := cmd.Flags().GetString("file")
+		codeProperty, _ := cmd.Flags().GetString("code-property")
+		layer, _ := cmd.Flags().GetString("layer")
+		repair, _ := cmd.Flags().GetBool("repair")
+		tolerance, _ := cmd.Flags().GetFloat64("simplify")
+		sets, _ := cmd.Flags().GetStringArray("set")
+		unsets, _ := cmd.Flags().GetStringSlice("unset")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		serverURL, _ := cmd.Flags().GetString("server")
+		jwtToken, _ := cmd.Flags().GetString("jwt-token")
+
+		if filePath == "" && len(sets) == 0 && len(unsets) == 0 {
+			return fmt.Errorf("nothing to update: use --file, --set or --unset")
+		}
+		if filePath == "" && len(args) == 0 {
+			return fmt.Errorf("at least one boundary code is required without --file")
+		}
+		if filePath == "" && (repair || tolerance != 0) {
+			return fmt.Errorf("--repair and --simplify require --file")
+		}
+		if tolerance < 0 {
+			return fmt.Errorf("--simplify tolerance cannot be negative")
+		}
+
+		setDetails, err := parseSetFlags(sets)
+		if err != nil {
+			return err
+		}
+
+		// Read the new geometries and details from the file
+		codes := args
+		var fromFile map[string]boundary.Boundary
+		if filePath != "" {
+			boundaries, err := loadBoundaries(filePath, codeProperty, layer)
+			if err != nil {
+				return err
+			}
+			if len(args) > 0 {
+				boundaries, err = selectBoundaries(boundaries, args)
+				if err != nil {
+					return err
+				}
+			}
+			results := boundary.CheckBoundaries(boundaries, boundary.CheckOptions{Repair: repair, Tolerance: tolerance})
+			if err := printBoundaryChecks(results); err != nil {
+				return fmt.Errorf("%w; fix them or try --repair", err)
+			}
+			fmt.Println()
+
+			fromFile = map[string]boundary.Boundary{}
+			codes = nil
+			for _, b := range boundaries {
+				fromFile[b.Code] = b
+				codes = append(codes, b.Code)
+			}
+		}
+
+		serverURL, jwtToken, tenantID, clientID, err := clientSettings(serverURL, jwtToken)
+		if err != nil {
+			return err
+		}
+		existing, err := fetchBoundaries(serverURL, jwtToken, tenantID, clientID, codes)
+		if err != nil {
+			return err
+		}
+		if missing := missingBoundaryCodes(codes, existing); len(missing) > 0 {
+			return fmt.Errorf("%d boundaries do not exist: %s; create them with 'digit boundary import' or 'digit create-boundaries'",
+				len(missing), strings.Join(missing, ", "))
+		}
+
+		// Work out the changes
+		var updates []digit.Boundary
+		for _, current := range existing {
+			updated := current
+			updated.AdditionalDetails = map[string]interface{}{}
+			for key, value := range current.AdditionalDetails {
+				updated.AdditionalDetails[key] = value
+			}
+			if b, ok := fromFile[current.Code]; ok {
+				updated.Geometry = b.Geometry
+				updated.AdditionalDetails = map[string]interface{}{}
+				for key, value := range b.AdditionalDetails {
+					updated.AdditionalDetails[key] = value
+				}
+			}
+			for key, value := range setDetails {
+				updated.AdditionalDetails[key] = value
+			}
+			for _, key := range unsets {
+				delete(updated.AdditionalDetails, strings.TrimSpace(key))
+			}
+
+			changes := describeBoundaryChanges(current, updated)
+			if len(changes) == 0 {
+				fmt.Printf("= %s (unchanged)\n", current.Code)
+				continue
+			}
+			fmt.Printf("~ %s: %s\n", current.Code, strings.Join(changes, ", "))
+			updates = append(updates, updated)
+		}
+
+		if dryRun {
+			fmt.Println("\nDry run: no changes were made.")
+			return nil
+		}
+		if len(updates) == 0 {
+			fmt.Println("\nNothing to update")
+			return nil
+		}
+
+		fmt.Println()
+		failed := 0
+		for _, b := range updates {
+			if _, err := digit.UpdateBoundary(serverURL, jwtToken, tenantID, clientID, b.ID, b); err != nil {
+				failed++
+				fmt.Printf("✗ %s: %v\n", b.Code, err)
+				continue
+			}
+			fmt.Printf("✓ Updated %s\n", b.Code)
+		}
+
+		fmt.Printf("\n%d updated, %d unchanged\n", len(updates)-failed, len(existing)-len(updates))
+		if failed > 0 {
+			return fmt.Errorf("%d of %d boundaries could not be updated", failed, len(updates))
+		}
+		return nil
+	},
+}
+
+// parseSetFlags parses --set KEY=VALUE flags, reading each value with detailValue
+func parseSetFlags(sets []string) (map[string]interface{}, error) {
+	values := map[string]interface{}{}
+	for _, s := range sets {
+		key, value, found := strings.Cut(s, "=")
+		if !found || strings.TrimSpace(key) == "" {
+			return nil, fmt.Errorf("invalid --set %q, expected KEY=VALUE", s)
+		}
+		values[strings.TrimSpace(key)] = detailValue(value)
+	}
+	return values, nil
+}
+
+// detailValue reads a --set value as a YAML scalar, keeping it as text when it is not one
+func detailValue(value string) interface{} {
+	var decoded interface{}
+	if err := yaml.Unmarshal([]byte(value), &decoded); err != nil {
+		return value
+	}
+	switch decoded.(type) {
+	case string, bool, int, float64:
+		return decoded
+	case nil:
+		if strings.TrimSpace(value) == "" {
+			return ""
+		}
+		return nil
+	}
+	return value
+}
+
+// selectBoundaries returns the boundaries with the given codes, in the order of the codes
+func selectBoundaries(boundaries []boundary.Boundary, codes []string) ([]boundary.Boundary, error) {
+	byCode := map[string]boundary.Boundary{}
+	for _, b := range boundaries {
+		byCode[b.Code] = b
+	}
+	selected := make([]boundary.Boundary, 0, len(codes))
+	for _, code := range codes {
+		b, ok := byCode[code]
+		if !ok {
+			return nil, fmt.Errorf("boundary %s is not in the file", code)
+		}
+		selected = append(selected, b)
+	}
+	return selected, nil
+}
+
+// describeBoundaryChanges lists the differences between the current and updated boundary,
+// e.g. "geometry (51 to 60 positions)" and "details +WARD_NAME ~AREA -OLD_CODE"
+func describeBoundaryChanges(current, updated digit.Boundary) []string {
+	var changes []string
+	if !jsonEqual(current.Geometry, updated.Geometry) {
+		before, after := 0, 0
+		if current.Geometry != nil {
+			before = current.Geometry.VertexCount()
+		}
+		if updated.Geometry != nil {
+			after = updated.Geometry.VertexCount()
+		}
+		changes = append(changes, fmt.Sprintf("geometry (%d to %d positions)", before, after))
+	}
+
+	var details []string
+	for key, value := range updated.AdditionalDetails {
+		old, ok := current.AdditionalDetails[key]
+		switch {
+		case !ok:
+			details = append(details, "+"+key)
+		case !jsonEqual(old, value):
+			details = append(details, "~"+key)
+		}
+	}
+	for key := range current.AdditionalDetails {
+		if _, ok := updated.AdditionalDetails[key]; !ok {
+			details = append(details, "-"+key)
+		}
+	}
+	if len(details) > 0 {
+		sort.Slice(details, func(i, j int) bool { return details[i][1:] < details[j][1:] })
+		changes = append(changes, "details "+strings.Join(details, " "))
+	}
+	return changes
+}
+
+// jsonEqual reports whether two values have the same JSON encoding, so that numbers
+// read from YAML compare equal to the same numbers decoded from a response
+func jsonEqual(a, b interface{}) bool {
+	encodedA, errA := json.Marshal(a)
+	encodedB, errB := json.Marshal(b)
+	return errA == nil && errB == nil && bytes.Equal(encodedA, encodedB)
+}
+
+func init() {
+	boundaryCmd.AddCommand(boundaryUpdateCmd)
+
+	// Add flags
+	boundaryUpdateCmd.Flags().StringP("file", "f", "", "Boundaries YAML file, GeoJSON, KML/KMZ or Shapefile with the new geometries")
+	boundaryUpdateCmd.Flags().String("code-property", "", "Feature property holding the boundary code (GIS files)")
+	boundaryUpdateCmd.Flags().String("layer", "", "Shapefile to read when the zip contains several")
+	boundaryUpdateCmd.Flags().Bool("repair", false, "Close rings, remove repeated positions and fix winding order before updating")
+	boundaryUpdateCmd.Flags().Float64("simplify", 0, "Simplify rings with this Douglas–Peucker tolerance in metres before updating")
+	boundaryUpdateCmd.Flags().StringArray("set", []string{}, "Additional detail to set as KEY=VALUE (repeatable)")
+	boundaryUpdateCmd.Flags().StringSlice("unset", nil, "Additional details to remove")
+	boundaryUpdateCmd.Flags().Bool("dry-run", false, "Show what would change without updating anything")
+	boundaryUpdateCmd.Flags().String("server", "", "Server URL (overrides config)")
+	boundaryUpdateCmd.Flags().String("jwt-token", "", "JWT token for authentication (overrides config)")
+}
@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"digit-cli/pkg/boundary"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// boundaryValidateCmd represents the boundary validate command
+var boundaryValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Validate, repair and simplify boundary geometries",
+	Long: `Check the boundaries of a boundaries YAML file (as used by create-boundaries) or a
+GIS file (GeoJSON, KML or Shapefile, as used by 'boundary import') before uploading them.
+
+Every position must be a valid longitude/latitude, polygon rings must be closed with at
+least four positions and must not intersect themselves or each other, exterior rings
+should be counter-clockwise and holes clockwise (RFC 7946), and boundary codes must be
+unique. Only boundaries with problems are listed.
+
+With --repair, unclosed rings are closed, repeated positions removed and the winding
+order corrected. With --simplify, rings are simplified with the Douglas–Peucker
+algorithm so that no removed position lies further than the tolerance (in metres) from
+the new outline. Write the result with --output to upload it.
+
+Examples:
+  digit boundary validate -f boundaries.yaml
+  digit boundary validate -f wards.geojson --code-property WARD_ID
+  digit boundary validate -f wards.zip --code-property WARD_NO --repair --simplify 5 -o wards-clean.geojson`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Get flag values
+		filePath, _ := cmd.Flags().GetString("file")
+		codeProperty, _ := cmd.Flags().GetString("code-property")
+		layer, _ := cmd.Flags().GetString("layer")
+		repair, _ := cmd.Flags().GetBool("repair")
+		tolerance, _ := cmd.Flags().GetFloat64("simplify")
+		outputPath, _ := cmd.Flags().GetString("output")
+
+		if tolerance < 0 {
+			return fmt.Errorf("--simplify tolerance cannot be negative")
+		}
+
+		boundaries, err := loadBoundaries(filePath, codeProperty, layer)
+		if err != nil {
+			return err
+		}
+
+		results := boundary.CheckBoundaries(boundaries, boundary.CheckOptions{Repair: repair, Tolerance: tolerance})
+		checkErr := printBoundaryChecks(results)
+		if tolerance > 0 && len(results) > 1 {
+			before, after := 0, 0
+			for _, result := range results {
+				before += result.VerticesBefore
+				after += result.VerticesAfter
+			}
+			fmt.Printf("Simplified from %d to %d positions in total\n", before, after)
+		}
+
+		if outputPath != "" {
+			if checkErr != nil {
+				return fmt.Errorf("%w; %s was not written", checkErr, outputPath)
+			}
+			if err := boundary.WriteBoundaries(outputPath, boundaries); err != nil {
+				return err
+			}
+			fmt.Printf("✓ Wrote %d boundaries to %s\n", len(boundaries), outputPath)
+		}
+		return checkErr
+	},
+}
+
+// loadBoundaries reads the boundaries of a boundaries YAML file or, with a code property, a GIS file
+func loadBoundaries(path, codeProperty, layer string) ([]boundary.Boundary, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read YAML file: %w", err)
+		}
+		var boundaryDef BoundaryDefinition
+		if err := yaml.Unmarshal(data, &boundaryDef); err != nil {
+			return nil, fmt.Errorf("failed to parse YAML: %w", err)
+		}
+		if len(boundaryDef.Boundary) == 0 {
+			return nil, fmt.Errorf("at least one boundary entry is required in YAML file")
+		}
+		return boundary.BoundariesFromMaps(boundaryDef.Boundary)
+	}
+
+	if codeProperty == "" {
+		return nil, fmt.Errorf("--code-property is required for %s files", filepath.Ext(path))
+	}
+	features, err := boundary.ReadFeatures(path, layer)
+	if err != nil {
+		return nil, err
+	}
+	boundaries, _, err := boundary.BuildBoundaries(features, boundary.ImportOptions{CodeProperty: codeProperty})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", filepath.Base(path), err)
+	}
+	return boundaries, nil
+}
+
+func init() {
+	boundaryCmd.AddCommand(boundaryValidateCmd)
+
+	// Add flags
+	boundaryValidateCmd.Flags().StringP("file", "f", "", "Boundaries YAML file, GeoJSON, KML/KMZ or Shapefile (.shp or .zip)")
+	boundaryValidateCmd.Flags().String("code-property", "", "Feature property holding the boundary code (GIS files)")
+	boundaryValidateCmd.Flags().String("layer", "", "Shapefile to read when the zip contains several")
+	boundaryValidateCmd.Flags().Bool("repair", false, "Close rings, remove repeated positions and fix winding order")
+	boundaryValidateCmd.Flags().Float64("simplify", 0, "Simplify rings with this Douglas–Peucker tolerance in metres")
+	boundaryValidateCmd.Flags().StringP("output", "o", "", "Write the checked boundaries to a .yaml or .geojson file")
+
+	// Mark required flags
+	boundaryValidateCmd.MarkFlagRequired("file")
+}
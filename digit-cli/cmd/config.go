@@ -1,6 +1,10 @@
 package cmd
 
 import (
+	"fmt"
+
+	"digit-cli/pkg/config"
+	"digit-cli/pkg/jwt"
 	"github.com/spf13/cobra"
 )
 
@@ -11,6 +15,73 @@ var configCmd = &cobra.Command{
 	Long:  `Manage CLI configuration settings such as server URL.`,
 }
 
+// serverSettings resolves the server URL, JWT token and tenant ID for a command,
+// falling back to the current configuration for values not given as flags
+func serverSettings(serverURL, jwtToken string) (string, string, string, error) {
+	if serverURL == "" || jwtToken == "" {
+		cfg, err := config.Load()
+		if err != nil {
+			return "", "", "", fmt.Errorf("failed to load config: %w", err)
+		}
+
+		if serverURL == "" {
+			serverURL = cfg.GetServer()
+			if serverURL == "" {
+				return "", "", "", fmt.Errorf("server URL not configured. Use 'digit config set --server <url>' or provide --server flag")
+			}
+		}
+
+		if jwtToken == "" {
+			jwtToken = cfg.GetJWTToken()
+			if jwtToken == "" {
+				return "", "", "", fmt.Errorf("JWT token not configured. Use 'digit config set --jwt-token <token>' or provide --jwt-token flag")
+			}
+		}
+	}
+
+	// Extract tenant ID from JWT token
+	tenantID, err := jwt.ExtractTenantID(jwtToken)
+	if err != nil {
+		return "", "", "", fmt.Errorf("failed to extract tenant ID from JWT token: %w", err)
+	}
+	return serverURL, jwtToken, tenantID, nil
+}
+
+// clientSettings resolves the server URL, JWT token, tenant ID and client ID for a command
+// whose service also needs the client ID from the token
+func clientSettings(serverURL, jwtToken string) (string, string, string, string, error) {
+	serverURL, jwtToken, tenantID, err := serverSettings(serverURL, jwtToken)
+	if err != nil {
+		return "", "", "", "", err
+	}
+
+	clientID, err := jwt.ExtractClientID(jwtToken)
+	if err != nil {
+		return "", "", "", "", fmt.Errorf("failed to extract client ID from JWT token: %w", err)
+	}
+	return serverURL, jwtToken, tenantID, clientID, nil
+}
+
+// realmSettings resolves the server URL, JWT token and account (Keycloak realm) for a command
+// managing users and roles; the account defaults to that of the stored login, or else the token's tenant
+func realmSettings(serverURL, jwtToken, realm string) (string, string, string, error) {
+	serverURL, jwtToken, tenantID, err := serverSettings(serverURL, jwtToken)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	if realm == "" {
+		realm, err = config.GetRealm()
+		if err != nil {
+			return "", "", "", fmt.Errorf("failed to get realm from config: %w", err)
+		}
+	}
+	if realm == "" {
+		realm = tenantID
+	}
+	return serverURL, jwtToken, realm, nil
+}
+
 func init() {
 	rootCmd.AddCommand(configCmd)
 }
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"digit-cli/pkg/config"
+	"digit-cli/pkg/jwt"
 	"github.com/digitnxt/digit3/code/libraries/digit-library/digit"
 	"github.com/spf13/cobra"
 )
@@ -36,16 +37,29 @@ Examples:
 			return fmt.Errorf("--email flag is required")
 		}
 		
-		// Load server URL from config if not provided as flag
-		if serverURL == "" {
+		// Load server URL and client ID from config if not provided as flags
+		if serverURL == "" || clientID == "" {
 			cfg, err := config.Load()
 			if err != nil {
 				return fmt.Errorf("failed to load config: %w", err)
 			}
-			serverURL = cfg.GetServer()
-			
+
 			if serverURL == "" {
-				return fmt.Errorf("server URL not configured. Use 'digit config set --server <url>' or provide --server flag")
+				serverURL = cfg.GetServer()
+				if serverURL == "" {
+					return fmt.Errorf("server URL not configured. Use 'digit config set --server <url>' or provide --server flag")
+				}
+			}
+
+			if clientID == "" {
+				jwtToken := cfg.GetJWTToken()
+				if jwtToken == "" {
+					return fmt.Errorf("client ID not configured. Use 'digit config set' to log in or provide --client-id flag")
+				}
+				clientID, err = jwt.ExtractClientID(jwtToken)
+				if err != nil {
+					return fmt.Errorf("failed to extract client ID from JWT token: %w", err)
+				}
 			}
 		}
 		
@@ -82,7 +96,7 @@ func init() {
 	createAccountCmd.Flags().String("name", "", "Name of the tenant (required)")
 	createAccountCmd.Flags().String("email", "", "Email of the tenant (required)")
 	createAccountCmd.Flags().Bool("active", true, "Whether the tenant is active (default: true)")
-	createAccountCmd.Flags().String("client-id", "test-client", "Client ID for the request (default: test-client)")
+	createAccountCmd.Flags().String("client-id", "", "Client ID for the request (overrides the client ID of the configured token)")
 	createAccountCmd.Flags().String("server", "", "Server URL (overrides config)")
 	
 	// Mark required flags
@@ -6,6 +6,7 @@ import (
 	"os"
 	"strings"
 
+	"digit-cli/pkg/boundary"
 	"digit-cli/pkg/config"
 	"digit-cli/pkg/jwt"
 	"github.com/digitnxt/digit3/code/libraries/digit-library/digit"
@@ -109,6 +110,15 @@ Examples:
 		if len(boundaryDef.Boundary) == 0 {
 			return fmt.Errorf("at least one boundary entry is required in YAML file")
 		}
+
+		// Check the geometries before uploading them
+		boundaries, err := boundary.BoundariesFromMaps(boundaryDef.Boundary)
+		if err != nil {
+			return err
+		}
+		if err := printBoundaryChecks(boundary.CheckBoundaries(boundaries, boundary.CheckOptions{})); err != nil {
+			return fmt.Errorf("%w; see 'digit boundary validate --repair'", err)
+		}
 		
 		// Get server URL and JWT token from config if not provided
 		if serverURL == "" || jwtToken == "" {
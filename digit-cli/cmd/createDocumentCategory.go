@@ -2,10 +2,10 @@ package cmd
 
 import (
 	"fmt"
-	"strconv"
 	"strings"
 
 	"digit-cli/pkg/config"
+	"digit-cli/pkg/filestore"
 	"digit-cli/pkg/jwt"
 	"github.com/digitnxt/digit3/code/libraries/digit-library/digit"
 	"github.com/spf13/cobra"
@@ -20,9 +20,14 @@ var createDocumentCategoryCmd = &cobra.Command{
 This command will create a document category that defines allowed file formats,
 size limits, and other properties for document uploads.
 
+Allowed formats are file extensions (pdf, jpg, ...) or MIME types (application/pdf, ...)
+and must be formats the CLI can detect from file content. Sizes are in bytes or use
+units such as 500KB or 10MB.
+
 Example:
-  digit create-document-category --type Identity --code AADHAR --allowed-formats "pdf,jpg,jpeg,xsm" --min-size 1024 --max-size 1024000 --sensitive --active
-  digit create-document-category --type Certificate --code BIRTH_CERT --allowed-formats "pdf,jpg" --min-size 512 --max-size 2048000 --description "Birth certificate documents" --server http://localhost:8081`,
+  digit create-document-category --type Identity --code AADHAR --allowed-formats "pdf,jpg,jpeg" --min-size 1KB --max-size 1MB --sensitive --active
+  digit create-document-category --type Certificate --code BIRTH_CERT --allowed-formats "pdf,jpg" --min-size 512 --max-size 2MB --description "Birth certificate documents" --server http://localhost:8081`,
+	Args: cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		// Get flag values
 		categoryType, _ := cmd.Flags().GetString("type")
@@ -75,24 +80,30 @@ Example:
 		for i, format := range allowedFormats {
 			allowedFormats[i] = strings.TrimSpace(format)
 		}
+		if err := digit.ValidateDocumentFormats(allowedFormats); err != nil {
+			return err
+		}
 
-		// Parse numeric parameters with defaults
+		// Parse size parameters with defaults
 		minSize := 1024
 		if minSizeStr != "" {
-			var err error
-			minSize, err = strconv.Atoi(minSizeStr)
+			size, err := filestore.ParseSize(minSizeStr)
 			if err != nil {
-				return fmt.Errorf("invalid min-size value: %s", minSizeStr)
+				return fmt.Errorf("invalid min-size value: %w", err)
 			}
+			minSize = int(size)
 		}
 
 		maxSize := 1024000
 		if maxSizeStr != "" {
-			var err error
-			maxSize, err = strconv.Atoi(maxSizeStr)
+			size, err := filestore.ParseSize(maxSizeStr)
 			if err != nil {
-				return fmt.Errorf("invalid max-size value: %s", maxSizeStr)
+				return fmt.Errorf("invalid max-size value: %w", err)
 			}
+			maxSize = int(size)
+		}
+		if maxSize > 0 && minSize > maxSize {
+			return fmt.Errorf("min-size (%d bytes) cannot be larger than max-size (%d bytes)", minSize, maxSize)
 		}
 
 		// Create the document category
@@ -120,10 +131,10 @@ func init() {
 	createDocumentCategoryCmd.Flags().StringP("type", "", "", "Category type (e.g., Identity, Certificate) (required)")
 	createDocumentCategoryCmd.Flags().StringP("code", "", "", "Category code (e.g., AADHAR, BIRTH_CERT) (required)")
 	createDocumentCategoryCmd.Flags().StringP("allowed-formats", "", "", "Comma-separated list of allowed file formats (e.g., 'pdf,jpg,jpeg') (required)")
-	createDocumentCategoryCmd.Flags().StringP("min-size", "", "1024", "Minimum file size in bytes")
-	createDocumentCategoryCmd.Flags().StringP("max-size", "", "1024000", "Maximum file size in bytes")
-	createDocumentCategoryCmd.Flags().StringP("sensitive", "", "false", "Whether the document category is sensitive")
-	createDocumentCategoryCmd.Flags().StringP("active", "", "true", "Whether the document category is active")
+	createDocumentCategoryCmd.Flags().StringP("min-size", "", "1024", "Minimum file size in bytes or with a unit (e.g., 500KB)")
+	createDocumentCategoryCmd.Flags().StringP("max-size", "", "1024000", "Maximum file size in bytes or with a unit (e.g., 10MB)")
+	createDocumentCategoryCmd.Flags().Bool("sensitive", false, "Whether the document category is sensitive")
+	createDocumentCategoryCmd.Flags().Bool("active", true, "Whether the document category is active")
 	createDocumentCategoryCmd.Flags().StringP("description", "", "", "Description of the document category")
 	createDocumentCategoryCmd.Flags().StringP("server", "s", "", "Server URL (overrides config)")
 	createDocumentCategoryCmd.Flags().StringP("jwt-token", "t", "", "JWT token for authentication (overrides config)")
@@ -38,15 +38,28 @@ html: true`
 
 // TemplateConfig represents the YAML configuration for template creation
 type TemplateConfig struct {
-	TemplateID  string `yaml:"template-id"`
-	Version     string `yaml:"version"`
-	Type        string `yaml:"type"`
+	TemplateID  string                    `yaml:"template-id"`
+	Version     string                    `yaml:"version"`
+	Type        string                    `yaml:"type"`
+	Subject     string                    `yaml:"subject"`
+	Content     string                    `yaml:"content"`
+	ContentFile string                    `yaml:"content-file"`
+	HTML        bool                      `yaml:"html"`
+	Variables   []string                  `yaml:"variables"`
+	Layout      string                    `yaml:"layout"`
+	Partials    map[string]string         `yaml:"partials"`
+	Locales     map[string]LocaleTemplate `yaml:"locales"`
+	LocaleMode  string                    `yaml:"locale-mode"`
+	Manifest    string                    `yaml:"manifest"`
+	ServerURL   string                    `yaml:"server"`
+	JWTToken    string                    `yaml:"jwt-token"`
+}
+
+// LocaleTemplate represents the per-locale subject and content of a template
+type LocaleTemplate struct {
 	Subject     string `yaml:"subject"`
 	Content     string `yaml:"content"`
 	ContentFile string `yaml:"content-file"`
-	HTML        bool   `yaml:"html"`
-	ServerURL   string `yaml:"server"`
-	JWTToken    string `yaml:"jwt-token"`
 }
 
 // createNotificationTemplateCmd represents the create-notification-template command
@@ -68,6 +81,10 @@ Examples:
   # Using default configuration with custom template ID
   digit create-notification-template --default --template-id "my-custom-template"
   
+  # Using a YAML configuration with a layout, partials and locale variants;
+  # one template is created per locale and a manifest is written
+  digit create-notification-template --file examples/welcome-localized-config.yaml --manifest welcome.manifest.json
+  
   # With server override
   digit create-notification-template --template-id "my-template" --version "1.0.0" --type "SMS" --subject "Test Subject" --content "Test Content" --server http://localhost:8081`,
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -83,6 +100,7 @@ Examples:
 		isHTML, _ := cmd.Flags().GetBool("html")
 		serverURL, _ := cmd.Flags().GetString("server")
 		jwtToken, _ := cmd.Flags().GetString("jwt-token")
+		manifestPath, _ := cmd.Flags().GetString("manifest")
 		
 		// Validate flags - either file, default, or individual flags must be specified
 		if useDefault && filePath != "" {
@@ -144,6 +162,11 @@ Examples:
 			if templateConfig.JWTToken != "" {
 				jwtToken = templateConfig.JWTToken
 			}
+			
+			// Layouts, partials and locales are compiled into one template per locale
+			if templateConfig.isComposed() {
+				return createComposedTemplates(&templateConfig, serverURL, jwtToken, manifestPath)
+			}
 		} else {
 			// Validate required flags when not using YAML file or default
 			if templateID == "" {
@@ -249,6 +272,146 @@ Examples:
 	},
 }
 
+// templateManifest maps each locale of a composed template to the template ID and version it was created as
+type templateManifest struct {
+	TemplateID string                           `json:"templateId"`
+	Version    string                           `json:"version"`
+	LocaleMode string                           `json:"localeMode,omitempty"`
+	Locales    map[string]templateManifestEntry `json:"locales,omitempty"`
+}
+
+// templateManifestEntry is the uploaded template for one locale
+type templateManifestEntry struct {
+	TemplateID string `json:"templateId"`
+	Version    string `json:"version"`
+}
+
+// createComposedTemplates compiles a configuration with a layout, partials or locales and creates
+// one template per locale; for localized templates a manifest mapping each locale to its template is written
+func createComposedTemplates(templateConfig *TemplateConfig, serverURL, jwtToken, manifestPath string) error {
+	if templateConfig.TemplateID == "" {
+		return fmt.Errorf("template-id is required")
+	}
+	if templateConfig.Version == "" {
+		return fmt.Errorf("version is required")
+	}
+	if templateConfig.Type == "" {
+		return fmt.Errorf("type is required")
+	}
+	if templateConfig.Content != "" && templateConfig.ContentFile != "" {
+		return fmt.Errorf("cannot use both content and content-file together")
+	}
+	if templateConfig.ContentFile != "" {
+		fileContent, err := os.ReadFile(templateConfig.ContentFile)
+		if err != nil {
+			return fmt.Errorf("failed to read content file: %w", err)
+		}
+		templateConfig.Content = string(fileContent)
+	}
+
+	compiled, err := compileTemplateConfig(templateConfig)
+	if err != nil {
+		return fmt.Errorf("failed to compile template: %w", err)
+	}
+	for _, t := range compiled {
+		label := t.TemplateID
+		if t.Locale != "" {
+			label = "locale " + t.Locale
+		}
+		if t.Subject == "" {
+			return fmt.Errorf("%s: subject is required", label)
+		}
+		if strings.TrimSpace(t.Content) == "" {
+			return fmt.Errorf("%s: content is required", label)
+		}
+	}
+
+	// Get server URL and JWT token from config if not provided
+	if serverURL == "" || jwtToken == "" {
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
+		if serverURL == "" {
+			serverURL = cfg.GetServer()
+			if serverURL == "" {
+				return fmt.Errorf("server URL not configured. Use 'digit config set --server <url>' or provide --server flag")
+			}
+		}
+
+		if jwtToken == "" {
+			jwtToken = cfg.GetJWTToken()
+			if jwtToken == "" {
+				return fmt.Errorf("JWT token not configured. Use 'digit config set --jwt-token <token>' or provide --jwt-token flag")
+			}
+		}
+	}
+
+	// Extract tenant ID from JWT token
+	tenantID, err := jwt.ExtractTenantID(jwtToken)
+	if err != nil {
+		return fmt.Errorf("failed to extract tenant ID from JWT token: %w", err)
+	}
+
+	manifest := templateManifest{
+		TemplateID: templateConfig.TemplateID,
+		Version:    templateConfig.Version,
+	}
+	if len(templateConfig.Locales) > 0 {
+		manifest.LocaleMode = templateConfig.LocaleMode
+		if manifest.LocaleMode == "" {
+			manifest.LocaleMode = "template-id"
+		}
+		manifest.Locales = map[string]templateManifestEntry{}
+	}
+
+	for _, t := range compiled {
+		responseBody, err := digit.CreateTemplate(serverURL, jwtToken, tenantID, t.TemplateID, t.Version, templateConfig.Type, t.Subject, t.Content, templateConfig.HTML)
+		if err != nil {
+			if len(manifest.Locales) > 0 {
+				fmt.Printf("Created %d of %d template(s) before the failure\n", len(manifest.Locales), len(compiled))
+			}
+			return fmt.Errorf("failed to create template %s version %s: %w", t.TemplateID, t.Version, err)
+		}
+
+		fmt.Printf("✓ Created template %s version %s", t.TemplateID, t.Version)
+		if t.Locale != "" {
+			fmt.Printf(" (%s)", t.Locale)
+			manifest.Locales[t.Locale] = templateManifestEntry{TemplateID: t.TemplateID, Version: t.Version}
+		}
+		fmt.Println()
+
+		var jsonResponse interface{}
+		if err := json.Unmarshal([]byte(responseBody), &jsonResponse); err == nil {
+			if prettyJSON, err := json.MarshalIndent(jsonResponse, "", "  "); err == nil {
+				responseBody = string(prettyJSON)
+			}
+		}
+		fmt.Println(responseBody)
+	}
+
+	if manifest.Locales == nil {
+		return nil
+	}
+	if manifestPath == "" {
+		manifestPath = templateConfig.Manifest
+	}
+	if manifestPath == "" {
+		manifestPath = templateConfig.TemplateID + ".manifest.json"
+	}
+	manifestJSON, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode manifest: %w", err)
+	}
+	if err := os.WriteFile(manifestPath, append(manifestJSON, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+	fmt.Printf("Manifest written to %s\n", manifestPath)
+
+	return nil
+}
+
 // searchNotificationTemplateCmd represents the search-notification-template command
 var searchNotificationTemplateCmd = &cobra.Command{
 	Use:   "search-notification-template",
@@ -424,6 +587,7 @@ func init() {
 	createNotificationTemplateCmd.Flags().Bool("html", false, "Whether the content is HTML (default: false)")
 	createNotificationTemplateCmd.Flags().String("server", "", "Server URL (overrides config)")
 	createNotificationTemplateCmd.Flags().String("jwt-token", "", "JWT token for authentication (overrides config)")
+	createNotificationTemplateCmd.Flags().String("manifest", "", "Path to write the locale manifest for composed templates (default: <template-id>.manifest.json)")
 	
 	// Add flags for search-notification-template command
 	searchNotificationTemplateCmd.Flags().String("template-id", "", "Template ID to search for (required)")
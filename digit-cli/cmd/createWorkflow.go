@@ -355,6 +355,10 @@ Examples:
   # Create workflow using default configuration with custom code
   digit create-workflow --default --code MY_CUSTOM_CODE
   
+  # Check that the roles of the actions exist in the account first
+  digit create-workflow --file workflow.yaml --check-roles
+  digit create-workflow --file workflow.yaml --create-missing-roles
+  
   # With server override
   digit create-workflow --file workflow.yaml --server http://localhost:9090
   digit create-workflow --default --code MY_CODE --server http://localhost:9090`,
@@ -363,6 +367,9 @@ Examples:
 		filePath, _ := cmd.Flags().GetString("file")
 		useDefault, _ := cmd.Flags().GetBool("default")
 		code, _ := cmd.Flags().GetString("code")
+		checkRoles, _ := cmd.Flags().GetBool("check-roles")
+		createMissingRoles, _ := cmd.Flags().GetBool("create-missing-roles")
+		realm, _ := cmd.Flags().GetString("account")
 		serverURL, _ := cmd.Flags().GetString("server")
 		jwtToken, _ := cmd.Flags().GetString("jwt-token")
 		
@@ -427,112 +434,194 @@ Examples:
 			return fmt.Errorf("failed to parse YAML: %w", err)
 		}
 		
-		// Step 1: Create Process
-		fmt.Println("Creating workflow process...")
-		processResponse, err := digit.CreateProcess(
+		// Pre-flight: the roles of the actions must exist before anyone can take them
+		if checkRoles || createMissingRoles {
+			_, _, realm, err := realmSettings(serverURL, jwtToken, realm)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Checking the roles of the workflow actions in account %s...\n", realm)
+			checks, err := checkWorkflowRoles(newRoleResolver(serverURL, jwtToken, realm), &workflowDef, createMissingRoles)
+			if err != nil {
+				return err
+			}
+			if err := reportWorkflowRoles(checks, realm); err != nil {
+				return fmt.Errorf("%w; the workflow was not created", err)
+			}
+			fmt.Println()
+		}
+		
+		processID, err := createWorkflowFromDefinition(serverURL, jwtToken, tenantID, &workflowDef, nil)
+		if err != nil {
+			return err
+		}
+		
+		fmt.Println("\n🎉 Workflow created successfully!")
+		fmt.Printf("Process ID: %s\n", processID)
+		fmt.Printf("States: %d\n", len(workflowDef.Workflow.States))
+		fmt.Printf("Actions: %d\n", len(workflowDef.Workflow.Actions))
+		
+		return nil
+	},
+}
+
+// workflowProgress records the parts of a workflow created so far, so that a creation that
+// stopped part way can be resumed without creating them again. The process is recorded as
+// "process", states as "state <code>" and actions as "action <state> <name>".
+type workflowProgress struct {
+	created map[string]string
+	save    func() error
+}
+
+// done returns the ID of a part created earlier
+func (p *workflowProgress) done(part string) (string, bool) {
+	if p == nil {
+		return "", false
+	}
+	id, ok := p.created[part]
+	return id, ok
+}
+
+// record records a created part
+func (p *workflowProgress) record(part, id string) error {
+	if p == nil {
+		return nil
+	}
+	p.created[part] = id
+	return p.save()
+}
+
+// createWorkflowFromDefinition creates the process, states and actions of a workflow definition
+// and returns the ID of the process; parts recorded in progress, if given, are not created again
+func createWorkflowFromDefinition(serverURL, jwtToken, tenantID string, workflowDef *WorkflowDefinition, progress *workflowProgress) (string, error) {
+	// Step 1: Create Process
+	fmt.Println("Creating workflow process...")
+	if processID, ok := progress.done("process"); ok {
+		fmt.Printf("= Process created in an earlier run with ID: %s\n", processID)
+		return createWorkflowStates(serverURL, jwtToken, tenantID, workflowDef, processID, progress)
+	}
+	processResponse, err := digit.CreateProcess(
+		serverURL,
+		jwtToken,
+		tenantID,
+		workflowDef.Workflow.Process.Name,
+		workflowDef.Workflow.Process.Code,
+		workflowDef.Workflow.Process.Description,
+		workflowDef.Workflow.Process.Version,
+		workflowDef.Workflow.Process.SLA,
+	)
+	if err != nil {
+		return "", fmt.Errorf("failed to create process: %w", err)
+	}
+	
+	// Parse process response to get process ID
+	var processResult map[string]interface{}
+	if err := json.Unmarshal([]byte(processResponse), &processResult); err != nil {
+		return "", fmt.Errorf("failed to parse process response: %w", err)
+	}
+	
+	processID, ok := processResult["id"].(string)
+	if !ok {
+		return "", fmt.Errorf("failed to extract process ID from response")
+	}
+	
+	fmt.Printf("✓ Process created with ID: %s\n", processID)
+	if err := progress.record("process", processID); err != nil {
+		return "", err
+	}
+	return createWorkflowStates(serverURL, jwtToken, tenantID, workflowDef, processID, progress)
+}
+
+// createWorkflowStates creates the states and actions of a workflow definition in a process
+func createWorkflowStates(serverURL, jwtToken, tenantID string, workflowDef *WorkflowDefinition, processID string, progress *workflowProgress) (string, error) {
+	// Step 2: Create States
+	fmt.Println("Creating workflow states...")
+	stateCodeToID := make(map[string]string) // Map state codes to their IDs
+	
+	for _, state := range workflowDef.Workflow.States {
+		if stateID, ok := progress.done("state " + state.Code); ok {
+			stateCodeToID[state.Code] = stateID
+			fmt.Printf("= State created in an earlier run: %s (%s) - ID: %s\n", state.Name, state.Code, stateID)
+			continue
+		}
+		stateResponse, err := digit.CreateState(
 			serverURL,
 			jwtToken,
 			tenantID,
-			workflowDef.Workflow.Process.Name,
-			workflowDef.Workflow.Process.Code,
-			workflowDef.Workflow.Process.Description,
-			workflowDef.Workflow.Process.Version,
-			workflowDef.Workflow.Process.SLA,
+			processID,
+			state.Code,
+			state.Name,
+			state.IsInitial,
+			state.IsParallel,
+			state.IsJoin,
+			state.SLA,
 		)
 		if err != nil {
-			return fmt.Errorf("failed to create process: %w", err)
+			return "", fmt.Errorf("failed to create state %s: %w", state.Code, err)
 		}
 		
-		// Parse process response to get process ID
-		var processResult map[string]interface{}
-		if err := json.Unmarshal([]byte(processResponse), &processResult); err != nil {
-			return fmt.Errorf("failed to parse process response: %w", err)
+		// Parse state response to get state ID
+		var stateResult map[string]interface{}
+		if err := json.Unmarshal([]byte(stateResponse), &stateResult); err != nil {
+			return "", fmt.Errorf("failed to parse state response for %s: %w", state.Code, err)
 		}
 		
-		processID, ok := processResult["id"].(string)
+		stateID, ok := stateResult["id"].(string)
 		if !ok {
-			return fmt.Errorf("failed to extract process ID from response")
+			return "", fmt.Errorf("failed to extract state ID from response for %s", state.Code)
 		}
 		
-		fmt.Printf("✓ Process created with ID: %s\n", processID)
+		// Store the mapping of state code to state ID
+		stateCodeToID[state.Code] = stateID
 		
-		// Step 2: Create States
-		fmt.Println("Creating workflow states...")
-		stateCodeToID := make(map[string]string) // Map state codes to their IDs
-		
-		for _, state := range workflowDef.Workflow.States {
-			stateResponse, err := digit.CreateState(
-				serverURL,
-				jwtToken,
-				tenantID,
-				processID,
-				state.Code,
-				state.Name,
-				state.IsInitial,
-				state.IsParallel,
-				state.IsJoin,
-				state.SLA,
-			)
-			if err != nil {
-				return fmt.Errorf("failed to create state %s: %w", state.Code, err)
-			}
-			
-			// Parse state response to get state ID
-			var stateResult map[string]interface{}
-			if err := json.Unmarshal([]byte(stateResponse), &stateResult); err != nil {
-				return fmt.Errorf("failed to parse state response for %s: %w", state.Code, err)
-			}
-			
-			stateID, ok := stateResult["id"].(string)
-			if !ok {
-				return fmt.Errorf("failed to extract state ID from response for %s", state.Code)
-			}
-			
-			// Store the mapping of state code to state ID
-			stateCodeToID[state.Code] = stateID
-			
-			fmt.Printf("✓ State created: %s (%s) - ID: %s\n", state.Name, state.Code, stateID)
+		fmt.Printf("✓ State created: %s (%s) - ID: %s\n", state.Name, state.Code, stateID)
+		if err := progress.record("state "+state.Code, stateID); err != nil {
+			return "", err
 		}
-		
-		// Step 3: Create Actions
-		fmt.Println("Creating workflow actions...")
-		for _, action := range workflowDef.Workflow.Actions {
-			// Get the state ID for the current state
-			currentStateID, exists := stateCodeToID[action.CurrentState]
-			if !exists {
-				return fmt.Errorf("state ID not found for current state: %s", action.CurrentState)
-			}
-			
-			// Get the state ID for the next state
-			nextStateID, exists := stateCodeToID[action.NextState]
-			if !exists {
-				return fmt.Errorf("state ID not found for next state: %s", action.NextState)
-			}
-			
-			actionResponse, err := digit.CreateAction(
-				serverURL,
-				jwtToken,
-				tenantID,
-				currentStateID, // Use the actual state ID as path parameter
-				action.Name,
-				nextStateID, // Use the actual next state UUID
-				action.AttributeValidation.Attributes.Roles,
-				action.AttributeValidation.AssigneeCheck,
-			)
-			if err != nil {
-				return fmt.Errorf("failed to create action %s: %w", action.Name, err)
-			}
-			fmt.Printf("✓ Action created: %s (%s → %s) using state ID: %s\n", action.Name, action.CurrentState, action.NextState, currentStateID)
-			_ = actionResponse // We don't need the response for now
+	}
+	
+	// Step 3: Create Actions
+	fmt.Println("Creating workflow actions...")
+	for _, action := range workflowDef.Workflow.Actions {
+		part := "action " + action.CurrentState + " " + action.Name
+		if _, ok := progress.done(part); ok {
+			fmt.Printf("= Action created in an earlier run: %s (%s → %s)\n", action.Name, action.CurrentState, action.NextState)
+			continue
+		}
+
+		// Get the state ID for the current state
+		currentStateID, exists := stateCodeToID[action.CurrentState]
+		if !exists {
+			return "", fmt.Errorf("state ID not found for current state: %s", action.CurrentState)
 		}
 		
-		fmt.Println("\n🎉 Workflow created successfully!")
-		fmt.Printf("Process ID: %s\n", processID)
-		fmt.Printf("States: %d\n", len(workflowDef.Workflow.States))
-		fmt.Printf("Actions: %d\n", len(workflowDef.Workflow.Actions))
+		// Get the state ID for the next state
+		nextStateID, exists := stateCodeToID[action.NextState]
+		if !exists {
+			return "", fmt.Errorf("state ID not found for next state: %s", action.NextState)
+		}
 		
-		return nil
-	},
+		actionResponse, err := digit.CreateAction(
+			serverURL,
+			jwtToken,
+			tenantID,
+			currentStateID, // Use the actual state ID as path parameter
+			action.Name,
+			nextStateID, // Use the actual next state UUID
+			action.AttributeValidation.Attributes.Roles,
+			action.AttributeValidation.AssigneeCheck,
+		)
+		if err != nil {
+			return "", fmt.Errorf("failed to create action %s: %w", action.Name, err)
+		}
+		fmt.Printf("✓ Action created: %s (%s → %s) using state ID: %s\n", action.Name, action.CurrentState, action.NextState, currentStateID)
+		_ = actionResponse // We don't need the response for now
+		if err := progress.record(part, ""); err != nil {
+			return "", err
+		}
+	}
+	
+	return processID, nil
 }
 
 // deleteProcessCmd represents the delete-process command
@@ -649,6 +738,9 @@ func init() {
 	createWorkflowCmd.Flags().String("file", "", "Path to YAML file containing workflow definition")
 	createWorkflowCmd.Flags().Bool("default", false, "Use default workflow configuration (requires --code)")
 	createWorkflowCmd.Flags().String("code", "", "Process code to use with default configuration (required when using --default)")
+	createWorkflowCmd.Flags().Bool("check-roles", false, "Check that the roles of the actions exist in the account before creating the workflow")
+	createWorkflowCmd.Flags().Bool("create-missing-roles", false, "Create the roles of the actions that do not exist before creating the workflow")
+	createWorkflowCmd.Flags().String("account", "", "Account (realm) of the roles to check (overrides config)")
 	createWorkflowCmd.Flags().String("server", "", "Server URL (overrides config)")
 	createWorkflowCmd.Flags().String("jwt-token", "", "JWT token for authentication (overrides config)")
 	
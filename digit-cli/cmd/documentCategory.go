@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"digit-cli/pkg/filestore"
+	"github.com/digitnxt/digit3/code/libraries/digit-library/digit"
+	"github.com/spf13/cobra"
+)
+
+// documentCategoryCmd represents the document-category command
+var documentCategoryCmd = &cobra.Command{
+	Use:   "document-category",
+	Short: "Manage filestore document categories",
+	Long:  `Search, update, deactivate and declaratively apply the document categories that govern file uploads.`,
+}
+
+// printDocumentCategories prints document categories as a table
+func printDocumentCategories(categories []digit.DocumentCategory) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "CODE\tTYPE\tFORMATS\tMIN SIZE\tMAX SIZE\tSENSITIVE\tACTIVE\tDESCRIPTION")
+	for _, c := range categories {
+		maxSize := "-"
+		if c.MaxSize > 0 {
+			maxSize = filestore.FormatSize(c.MaxSize)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%t\t%t\t%s\n", c.Code, c.Type, strings.Join(c.AllowedFormats, ","),
+			filestore.FormatSize(c.MinSize), maxSize, c.IsSensitive, c.IsActive, c.Description)
+	}
+	return w.Flush()
+}
+
+func init() {
+	rootCmd.AddCommand(documentCategoryCmd)
+}
@@ -0,0 +1,200 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"digit-cli/pkg/filestore"
+	"github.com/digitnxt/digit3/code/libraries/digit-library/digit"
+	"github.com/spf13/cobra"
+)
+
+// documentCategoryApplyCmd represents the document-category apply command
+var documentCategoryApplyCmd = &cobra.Command{
+	Use:   "apply",
+	Short: "Create or update document categories from a YAML file",
+	Long: `Create or update the document categories declared in a YAML file, so a whole
+set of categories can be kept in version control.
+
+Categories that do not exist are created, categories whose configuration differs
+are updated, and the rest are left unchanged. Categories that exist on the server
+but are not in the file are not touched.
+
+File format:
+  document-categories:
+    - type: Identity
+      code: AADHAR
+      allowed-formats: [pdf, jpg, jpeg]
+      min-size: 1KB
+      max-size: 5MB
+      sensitive: true
+      description: Aadhaar card
+    - type: General
+      code: OLD_RECEIPT
+      allowed-formats: [pdf]
+      active: false
+
+Examples:
+  digit document-category apply -f categories.yaml --dry-run
+  digit document-category apply -f categories.yaml`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Get flag values
+		filePath, _ := cmd.Flags().GetString("file")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		serverURL, _ := cmd.Flags().GetString("server")
+		jwtToken, _ := cmd.Flags().GetString("jwt-token")
+
+		specs, err := filestore.LoadCategorySpecs(filePath)
+		if err != nil {
+			return err
+		}
+
+		// Validate every category before changing anything
+		desired := make([]digit.DocumentCategory, 0, len(specs))
+		for _, spec := range specs {
+			if err := digit.ValidateDocumentFormats(spec.AllowedFormats); err != nil {
+				return fmt.Errorf("document category %s: %w", spec.Code, err)
+			}
+			minSize, maxSize, err := spec.Sizes()
+			if err != nil {
+				return err
+			}
+			desired = append(desired, digit.DocumentCategory{
+				Type:           spec.Type,
+				Code:           spec.Code,
+				AllowedFormats: spec.AllowedFormats,
+				MinSize:        minSize,
+				MaxSize:        maxSize,
+				IsSensitive:    spec.Sensitive,
+				Description:    spec.Description,
+				IsActive:       spec.IsActive(),
+			})
+		}
+
+		serverURL, jwtToken, tenantID, err := serverSettings(serverURL, jwtToken)
+		if err != nil {
+			return err
+		}
+
+		responseBody, err := digit.SearchDocumentCategories(serverURL, jwtToken, tenantID, "", "")
+		if err != nil {
+			return err
+		}
+		existing, err := digit.ParseDocumentCategories(responseBody)
+		if err != nil {
+			return err
+		}
+		existingByCode := map[string]digit.DocumentCategory{}
+		for _, c := range existing {
+			existingByCode[c.Code] = c
+		}
+
+		created, updated, unchanged, failed := 0, 0, 0, 0
+		for _, c := range desired {
+			current, exists := existingByCode[c.Code]
+			changes := documentCategoryChanges(current, c)
+			switch {
+			case !exists:
+				fmt.Printf("+ %s: create\n", c.Code)
+			case len(changes) == 0:
+				unchanged++
+				fmt.Printf("= %s: unchanged\n", c.Code)
+				continue
+			default:
+				fmt.Printf("~ %s: update\n", c.Code)
+				for _, change := range changes {
+					fmt.Printf("    %s\n", change)
+				}
+			}
+			if dryRun {
+				if exists {
+					updated++
+				} else {
+					created++
+				}
+				continue
+			}
+
+			if exists {
+				_, err = digit.UpdateDocumentCategory(serverURL, jwtToken, tenantID, c.Type, c.Code, c.AllowedFormats,
+					int(c.MinSize), int(c.MaxSize), c.IsSensitive, c.IsActive, c.Description)
+			} else {
+				_, err = digit.CreateDocumentCategory(serverURL, jwtToken, tenantID, c.Type, c.Code, c.AllowedFormats,
+					int(c.MinSize), int(c.MaxSize), c.IsSensitive, c.IsActive, c.Description)
+			}
+			if err != nil {
+				failed++
+				fmt.Printf("  ✗ %v\n", err)
+				continue
+			}
+			if exists {
+				updated++
+			} else {
+				created++
+			}
+		}
+
+		summary := fmt.Sprintf("%d created, %d updated, %d unchanged", created, updated, unchanged)
+		if dryRun {
+			summary = fmt.Sprintf("Dry run: %d to create, %d to update, %d unchanged", created, updated, unchanged)
+		}
+		fmt.Printf("\n%s\n", summary)
+		if failed > 0 {
+			return fmt.Errorf("%d document categories failed to apply", failed)
+		}
+		return nil
+	},
+}
+
+// documentCategoryChanges describes the differences between the current and desired configuration of a category
+func documentCategoryChanges(current, desired digit.DocumentCategory) []string {
+	var changes []string
+	add := func(field, from, to string) {
+		if from != to {
+			changes = append(changes, fmt.Sprintf("%s: %s → %s", field, from, to))
+		}
+	}
+	add("type", current.Type, desired.Type)
+	add("allowed-formats", sortedFormats(current.AllowedFormats), sortedFormats(desired.AllowedFormats))
+	// Sizes are compared in bytes; FormatSize rounds, so it is only used to show them
+	if current.MinSize != desired.MinSize {
+		changes = append(changes, fmt.Sprintf("min-size: %s → %s", formatSizeChange(current.MinSize, desired.MinSize), formatSizeChange(desired.MinSize, current.MinSize)))
+	}
+	if current.MaxSize != desired.MaxSize {
+		changes = append(changes, fmt.Sprintf("max-size: %s → %s", formatSizeChange(current.MaxSize, desired.MaxSize), formatSizeChange(desired.MaxSize, current.MaxSize)))
+	}
+	add("sensitive", fmt.Sprint(current.IsSensitive), fmt.Sprint(desired.IsSensitive))
+	add("active", fmt.Sprint(current.IsActive), fmt.Sprint(desired.IsActive))
+	add("description", current.Description, desired.Description)
+	return changes
+}
+
+// formatSizeChange formats a size for a change line, in bytes when the rounded sizes would look the same
+func formatSizeChange(size, other int64) string {
+	if filestore.FormatSize(size) == filestore.FormatSize(other) {
+		return fmt.Sprintf("%d B", size)
+	}
+	return filestore.FormatSize(size)
+}
+
+// sortedFormats joins formats in sorted order, so that reordering them is not a change
+func sortedFormats(formats []string) string {
+	sorted := append([]string{}, formats...)
+	sort.Strings(sorted)
+	return strings.Join(sorted, ",")
+}
+
+func init() {
+	documentCategoryCmd.AddCommand(documentCategoryApplyCmd)
+
+	// Add flags
+	documentCategoryApplyCmd.Flags().StringP("file", "f", "", "Path to YAML file declaring the document categories (required)")
+	documentCategoryApplyCmd.Flags().Bool("dry-run", false, "Show the changes without applying them")
+	documentCategoryApplyCmd.Flags().String("server", "", "Server URL (overrides config)")
+	documentCategoryApplyCmd.Flags().String("jwt-token", "", "JWT token for authentication (overrides config)")
+
+	// Mark required flags
+	documentCategoryApplyCmd.MarkFlagRequired("file")
+}
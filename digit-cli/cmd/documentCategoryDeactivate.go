@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/digitnxt/digit3/code/libraries/digit-library/digit"
+	"github.com/spf13/cobra"
+)
+
+// documentCategoryDeactivateCmd represents the document-category deactivate command
+var documentCategoryDeactivateCmd = &cobra.Command{
+	Use:   "deactivate <code>...",
+	Short: "Deactivate document categories",
+	Long: `Deactivate one or more document categories so that no new files can be uploaded
+under them. Their configuration and existing files are kept; reactivate a category
+with 'digit document-category update <code> --active=true'.
+
+Examples:
+  digit document-category deactivate OLD_RECEIPT
+  digit document-category deactivate PAN VOTER_ID`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Get flag values
+		serverURL, _ := cmd.Flags().GetString("server")
+		jwtToken, _ := cmd.Flags().GetString("jwt-token")
+
+		serverURL, jwtToken, tenantID, err := serverSettings(serverURL, jwtToken)
+		if err != nil {
+			return err
+		}
+
+		failed := 0
+		for _, code := range args {
+			if _, err := digit.DeactivateDocumentCategory(serverURL, jwtToken, tenantID, code); err != nil {
+				failed++
+				fmt.Printf("✗ %s: %v\n", code, err)
+				continue
+			}
+			fmt.Printf("✓ Deactivated document category %s\n", code)
+		}
+
+		if failed > 0 {
+			return fmt.Errorf("%d of %d document categories could not be deactivated", failed, len(args))
+		}
+		return nil
+	},
+}
+
+func init() {
+	documentCategoryCmd.AddCommand(documentCategoryDeactivateCmd)
+
+	// Add flags
+	documentCategoryDeactivateCmd.Flags().String("server", "", "Server URL (overrides config)")
+	documentCategoryDeactivateCmd.Flags().String("jwt-token", "", "JWT token for authentication (overrides config)")
+}
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/digitnxt/digit3/code/libraries/digit-library/digit"
+	"github.com/spf13/cobra"
+)
+
+// documentCategorySearchCmd represents the document-category search command
+var documentCategorySearchCmd = &cobra.Command{
+	Use:     "search",
+	Aliases: []string{"list"},
+	Short:   "Search or list document categories",
+	Long: `Search document categories by type and code. Without filters, all document
+categories are listed.
+
+Examples:
+  digit document-category list
+  digit document-category search --type Identity
+  digit document-category search --code AADHAR`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Get flag values
+		categoryType, _ := cmd.Flags().GetString("type")
+		code, _ := cmd.Flags().GetString("code")
+		activeOnly, _ := cmd.Flags().GetBool("active-only")
+		serverURL, _ := cmd.Flags().GetString("server")
+		jwtToken, _ := cmd.Flags().GetString("jwt-token")
+
+		serverURL, jwtToken, tenantID, err := serverSettings(serverURL, jwtToken)
+		if err != nil {
+			return err
+		}
+
+		responseBody, err := digit.SearchDocumentCategories(serverURL, jwtToken, tenantID, categoryType, code)
+		if err != nil {
+			return err
+		}
+		categories, err := digit.ParseDocumentCategories(responseBody)
+		if err != nil {
+			return err
+		}
+
+		var matched []digit.DocumentCategory
+		for _, c := range categories {
+			if !activeOnly || c.IsActive {
+				matched = append(matched, c)
+			}
+		}
+		if len(matched) == 0 {
+			fmt.Println("No document categories found")
+			return nil
+		}
+		sort.Slice(matched, func(i, j int) bool { return matched[i].Code < matched[j].Code })
+
+		return printDocumentCategories(matched)
+	},
+}
+
+func init() {
+	documentCategoryCmd.AddCommand(documentCategorySearchCmd)
+
+	// Add flags
+	documentCategorySearchCmd.Flags().String("type", "", "Filter by category type (e.g., Identity)")
+	documentCategorySearchCmd.Flags().String("code", "", "Filter by category code (e.g., AADHAR)")
+	documentCategorySearchCmd.Flags().Bool("active-only", false, "Only show active categories")
+	documentCategorySearchCmd.Flags().String("server", "", "Server URL (overrides config)")
+	documentCategorySearchCmd.Flags().String("jwt-token", "", "JWT token for authentication (overrides config)")
+}
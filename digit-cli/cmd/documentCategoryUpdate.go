@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"digit-cli/pkg/filestore"
+	"github.com/digitnxt/digit3/code/libraries/digit-library/digit"
+	"github.com/spf13/cobra"
+)
+
+// documentCategoryUpdateCmd represents the document-category update command
+var documentCategoryUpdateCmd = &cobra.Command{
+	Use:   "update <code>",
+	Short: "Update a document category",
+	Long: `Update a document category. Only the given flags are changed; the rest of the
+category's configuration is kept.
+
+Sizes are in bytes or use units such as 500KB or 10MB. Allowed formats are file
+extensions (pdf, jpg, ...) or MIME types (application/pdf, ...).
+
+Examples:
+  digit document-category update AADHAR --max-size 5MB
+  digit document-category update RECEIPT --allowed-formats pdf,png,jpg --description "Payment receipts"
+  digit document-category update RECEIPT --active=true`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		code := args[0]
+
+		// Get flag values
+		serverURL, _ := cmd.Flags().GetString("server")
+		jwtToken, _ := cmd.Flags().GetString("jwt-token")
+
+		serverURL, jwtToken, tenantID, err := serverSettings(serverURL, jwtToken)
+		if err != nil {
+			return err
+		}
+
+		responseBody, err := digit.GetDocumentCategory(serverURL, jwtToken, tenantID, code)
+		if err != nil {
+			return err
+		}
+		category, err := digit.ParseDocumentCategory(responseBody)
+		if err != nil {
+			return err
+		}
+
+		// Apply the changed flags
+		flags := cmd.Flags()
+		if flags.Changed("type") {
+			category.Type, _ = flags.GetString("type")
+		}
+		if flags.Changed("allowed-formats") {
+			formats, _ := flags.GetStringSlice("allowed-formats")
+			category.AllowedFormats = nil
+			for _, format := range formats {
+				category.AllowedFormats = append(category.AllowedFormats, strings.TrimSpace(format))
+			}
+			if err := digit.ValidateDocumentFormats(category.AllowedFormats); err != nil {
+				return err
+			}
+		}
+		for _, name := range []string{"min-size", "max-size"} {
+			if !flags.Changed(name) {
+				continue
+			}
+			value, _ := flags.GetString(name)
+			size, err := filestore.ParseSize(value)
+			if err != nil {
+				return fmt.Errorf("invalid %s value: %w", name, err)
+			}
+			if name == "min-size" {
+				category.MinSize = size
+			} else {
+				category.MaxSize = size
+			}
+		}
+		if flags.Changed("sensitive") {
+			category.IsSensitive, _ = flags.GetBool("sensitive")
+		}
+		if flags.Changed("active") {
+			category.IsActive, _ = flags.GetBool("active")
+		}
+		if flags.Changed("description") {
+			category.Description, _ = flags.GetString("description")
+		}
+		if category.MaxSize > 0 && category.MinSize > category.MaxSize {
+			return fmt.Errorf("min-size (%d bytes) cannot be larger than max-size (%d bytes)", category.MinSize, category.MaxSize)
+		}
+
+		_, err = digit.UpdateDocumentCategory(serverURL, jwtToken, tenantID, category.Type, category.Code, category.AllowedFormats,
+			int(category.MinSize), int(category.MaxSize), category.IsSensitive, category.IsActive, category.Description)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("✓ Updated document category %s\n", category.Code)
+		return printDocumentCategories([]digit.DocumentCategory{*category})
+	},
+}
+
+func init() {
+	documentCategoryCmd.AddCommand(documentCategoryUpdateCmd)
+
+	// Add flags
+	documentCategoryUpdateCmd.Flags().String("type", "", "Category type (e.g., Identity, Certificate)")
+	documentCategoryUpdateCmd.Flags().StringSlice("allowed-formats", nil, "Comma-separated list of allowed file formats (e.g., 'pdf,jpg,jpeg')")
+	documentCategoryUpdateCmd.Flags().String("min-size", "", "Minimum file size in bytes or with a unit (e.g., 500KB)")
+	documentCategoryUpdateCmd.Flags().String("max-size", "", "Maximum file size in bytes or with a unit (e.g., 10MB)")
+	documentCategoryUpdateCmd.Flags().Bool("sensitive", false, "Whether the document category is sensitive")
+	documentCategoryUpdateCmd.Flags().Bool("active", true, "Whether the document category is active")
+	documentCategoryUpdateCmd.Flags().String("description", "", "Description of the document category")
+	documentCategoryUpdateCmd.Flags().String("server", "", "Server URL (overrides config)")
+	documentCategoryUpdateCmd.Flags().String("jwt-token", "", "JWT token for authentication (overrides config)")
+}
@@ -0,0 +1,16 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// fileCmd represents the file command
+var fileCmd = &cobra.Command{
+	Use:   "file",
+	Short: "Store and retrieve files in filestore",
+	Long:  `Upload, download, inspect and delete files stored in the filestore service.`,
+}
+
+func init() {
+	rootCmd.AddCommand(fileCmd)
+}
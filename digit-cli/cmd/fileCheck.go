@@ -0,0 +1,160 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"digit-cli/pkg/filestore"
+	"github.com/digitnxt/digit3/code/libraries/digit-library/digit"
+	"github.com/spf13/cobra"
+)
+
+// fileCheckCmd represents the file check command
+var fileCheckCmd = &cobra.Command{
+	Use:   "check <path>...",
+	Short: "Check documents against a document category without uploading",
+	Long: `Check files, or every file in a directory, against the rules of a document
+category: the file size must be within the category's minimum and maximum, and
+the MIME type detected from the file content must be one of its allowed formats.
+File extensions are ignored, so a renamed file is reported by what it contains.
+
+Directories are checked recursively unless --recursive=false is given; hidden
+files and directories are skipped. The command fails when any file is rejected.
+
+Examples:
+  digit file check --category AADHAR ./documents
+  digit file check --category RECEIPT ./receipt-1.pdf ./receipt-2.jpg`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Get flag values
+		categoryCode, _ := cmd.Flags().GetString("category")
+		recursive, _ := cmd.Flags().GetBool("recursive")
+		serverURL, _ := cmd.Flags().GetString("server")
+		jwtToken, _ := cmd.Flags().GetString("jwt-token")
+
+		files, err := collectFiles(args, recursive)
+		if err != nil {
+			return err
+		}
+		if len(files) == 0 {
+			return fmt.Errorf("no files found")
+		}
+
+		serverURL, jwtToken, tenantID, err := serverSettings(serverURL, jwtToken)
+		if err != nil {
+			return err
+		}
+
+		responseBody, err := digit.GetDocumentCategory(serverURL, jwtToken, tenantID, categoryCode)
+		if err != nil {
+			return err
+		}
+		category, err := digit.ParseDocumentCategory(responseBody)
+		if err != nil {
+			return err
+		}
+
+		limits := "no size limit"
+		if category.MinSize > 0 || category.MaxSize > 0 {
+			limits = fmt.Sprintf("%s to %s", filestore.FormatSize(category.MinSize), filestore.FormatSize(category.MaxSize))
+			if category.MaxSize == 0 {
+				limits = "at least " + filestore.FormatSize(category.MinSize)
+			}
+		}
+		fmt.Printf("Category %s: %s, %s\n\n", category.Code, strings.Join(category.AllowedFormats, ", "), limits)
+
+		rejected := 0
+		for _, path := range files {
+			size, mimeType, err := checkDocumentFile(category, path)
+			if err != nil {
+				rejected++
+				fmt.Printf("✗ %s\n", path)
+				for _, reason := range strings.Split(err.Error(), "\n") {
+					fmt.Printf("    %s\n", reason)
+				}
+				continue
+			}
+			fmt.Printf("✓ %s (%s, %s)\n", path, mimeType, filestore.FormatSize(size))
+		}
+
+		fmt.Printf("\n%d of %d file(s) accepted\n", len(files)-rejected, len(files))
+		if rejected > 0 {
+			return fmt.Errorf("%d file(s) rejected by category %s", rejected, category.Code)
+		}
+		return nil
+	},
+}
+
+// checkDocumentFile checks a single file against a category, returning its size and detected MIME type
+func checkDocumentFile(category *digit.DocumentCategory, path string) (int64, string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to open file: %w", err)
+	}
+	defer file.Close()
+	stat, err := file.Stat()
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to read file: %w", err)
+	}
+
+	head := make([]byte, digit.SniffLength)
+	n, err := io.ReadFull(file, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return 0, "", fmt.Errorf("failed to read file: %w", err)
+	}
+	head = head[:n]
+
+	return stat.Size(), digit.DetectMIMEType(head), digit.CheckDocument(category, head, stat.Size())
+}
+
+// collectFiles expands the given paths into regular files, walking directories
+func collectFiles(paths []string, recursive bool) ([]string, error) {
+	var files []string
+	for _, root := range paths {
+		info, err := os.Stat(root)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			files = append(files, root)
+			continue
+		}
+		err = filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			hidden := path != root && strings.HasPrefix(entry.Name(), ".")
+			if entry.IsDir() {
+				if hidden || (path != root && !recursive) {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if !hidden && entry.Type().IsRegular() {
+				files = append(files, path)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
+}
+
+func init() {
+	fileCmd.AddCommand(fileCheckCmd)
+
+	// Add flags
+	fileCheckCmd.Flags().String("category", "", "Document category code to check the files against (required)")
+	fileCheckCmd.Flags().Bool("recursive", true, "Check files in subdirectories")
+	fileCheckCmd.Flags().String("server", "", "Server URL (overrides config)")
+	fileCheckCmd.Flags().String("jwt-token", "", "JWT token for authentication (overrides config)")
+
+	// Mark required flags
+	fileCheckCmd.MarkFlagRequired("category")
+}
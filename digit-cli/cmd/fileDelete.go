@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/digitnxt/digit3/code/libraries/digit-library/digit"
+	"github.com/spf13/cobra"
+)
+
+// fileDeleteCmd represents the file delete command
+var fileDeleteCmd = &cobra.Command{
+	Use:   "delete <file-id>...",
+	Short: "Delete files from filestore",
+	Long: `Delete one or more files from filestore. Deletion must be confirmed unless
+--yes is given.
+
+Examples:
+  digit file delete 5f1c2d3e-aaaa-bbbb-cccc-123456789abc
+  digit file delete 5f1c2d3e-aaaa-bbbb-cccc-123456789abc 6a2b3c4d-aaaa-bbbb-cccc-123456789abc --yes`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Get flag values
+		yes, _ := cmd.Flags().GetBool("yes")
+		serverURL, _ := cmd.Flags().GetString("server")
+		jwtToken, _ := cmd.Flags().GetString("jwt-token")
+
+		serverURL, jwtToken, tenantID, err := serverSettings(serverURL, jwtToken)
+		if err != nil {
+			return err
+		}
+
+		if !yes {
+			fmt.Printf("Delete %d file(s) (%s)? [y/N]: ", len(args), strings.Join(args, ", "))
+			answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+			answer = strings.ToLower(strings.TrimSpace(answer))
+			if answer != "y" && answer != "yes" {
+				fmt.Println("Aborted, no files deleted")
+				return nil
+			}
+		}
+
+		failed := 0
+		for _, fileID := range args {
+			if _, err := digit.DeleteFile(serverURL, jwtToken, tenantID, fileID); err != nil {
+				failed++
+				fmt.Printf("✗ %s: %v\n", fileID, err)
+				continue
+			}
+			fmt.Printf("✓ Deleted %s\n", fileID)
+		}
+
+		if failed > 0 {
+			return fmt.Errorf("%d of %d file(s) could not be deleted", failed, len(args))
+		}
+		return nil
+	},
+}
+
+func init() {
+	fileCmd.AddCommand(fileDeleteCmd)
+
+	// Add flags
+	fileDeleteCmd.Flags().BoolP("yes", "y", false, "Delete without asking for confirmation")
+	fileDeleteCmd.Flags().String("server", "", "Server URL (overrides config)")
+	fileDeleteCmd.Flags().String("jwt-token", "", "JWT token for authentication (overrides config)")
+}
@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"digit-cli/pkg/filestore"
+	"github.com/digitnxt/digit3/code/libraries/digit-library/digit"
+	"github.com/spf13/cobra"
+)
+
+// fileDownloadCmd represents the file download command
+var fileDownloadCmd = &cobra.Command{
+	Use:   "download <file-id>",
+	Short: "Download a file from filestore",
+	Long: `Download a file from filestore, streaming it to disk.
+
+The file is saved as --output, or in the current directory under the name given
+by filestore (falling back to the file ID). Use --output - to write to stdout.
+The SHA-256 checksum of the downloaded content is printed, and compared with
+--sha256 when given.
+
+Examples:
+  digit file download 5f1c2d3e-aaaa-bbbb-cccc-123456789abc
+  digit file download 5f1c2d3e-aaaa-bbbb-cccc-123456789abc -o receipt.pdf
+  digit file download 5f1c2d3e-aaaa-bbbb-cccc-123456789abc -o - > receipt.pdf`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		fileID := args[0]
+
+		// Get flag values
+		output, _ := cmd.Flags().GetString("output")
+		expectedSHA256, _ := cmd.Flags().GetString("sha256")
+		serverURL, _ := cmd.Flags().GetString("server")
+		jwtToken, _ := cmd.Flags().GetString("jwt-token")
+
+		serverURL, jwtToken, tenantID, err := serverSettings(serverURL, jwtToken)
+		if err != nil {
+			return err
+		}
+
+		// Look up the size and name for progress and the default output path
+		info := &filestore.FileInfo{ID: fileID}
+		if metadata, err := digit.GetFileMetadata(serverURL, jwtToken, tenantID, fileID); err == nil {
+			if parsed, err := filestore.ParseFileInfo(metadata); err == nil {
+				info = parsed
+			}
+		}
+
+		hash := sha256.New()
+		progress := &filestore.Progress{Out: os.Stderr, Label: "Downloading " + fileID, Total: info.Size}
+
+		if output == "-" {
+			_, err := digit.DownloadFile(serverURL, jwtToken, tenantID, fileID, io.MultiWriter(os.Stdout, hash))
+			if err != nil {
+				return err
+			}
+			return checkDownloadChecksum(hash.Sum(nil), expectedSHA256)
+		}
+
+		// Stream to a temporary file next to the destination and rename it once complete
+		dir := "."
+		if output != "" {
+			dir = filepath.Dir(output)
+		}
+		tmp, err := os.CreateTemp(dir, ".digit-download-*")
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer os.Remove(tmp.Name())
+
+		serverName, err := digit.DownloadFile(serverURL, jwtToken, tenantID, fileID, io.MultiWriter(tmp, hash, progress))
+		progress.Finish()
+		if closeErr := tmp.Close(); err == nil && closeErr != nil {
+			err = fmt.Errorf("failed to write output file: %w", closeErr)
+		}
+		if err != nil {
+			return err
+		}
+		if err := checkDownloadChecksum(hash.Sum(nil), expectedSHA256); err != nil {
+			return err
+		}
+
+		if output == "" {
+			output = fileID
+			if serverName != "" {
+				output = serverName
+			} else if info.Name != "" {
+				output = filepath.Base(info.Name)
+			}
+		}
+		if err := os.Rename(tmp.Name(), output); err != nil {
+			return fmt.Errorf("failed to save file: %w", err)
+		}
+
+		fmt.Printf("✓ Saved %s (sha256 %s)\n", output, hex.EncodeToString(hash.Sum(nil)))
+		return nil
+	},
+}
+
+// checkDownloadChecksum compares the downloaded content's SHA-256 with the expected value, if given
+func checkDownloadChecksum(sum []byte, expected string) error {
+	if expected == "" {
+		return nil
+	}
+	if actual := hex.EncodeToString(sum); actual != expected {
+		return fmt.Errorf("checksum mismatch: expected %s, downloaded %s", expected, actual)
+	}
+	return nil
+}
+
+func init() {
+	fileCmd.AddCommand(fileDownloadCmd)
+
+	// Add flags
+	fileDownloadCmd.Flags().StringP("output", "o", "", "Path to save the file to, or - for stdout (default: name from filestore)")
+	fileDownloadCmd.Flags().String("sha256", "", "Expected SHA-256 checksum of the file")
+	fileDownloadCmd.Flags().String("server", "", "Server URL (overrides config)")
+	fileDownloadCmd.Flags().String("jwt-token", "", "JWT token for authentication (overrides config)")
+}
@@ -0,0 +1,167 @@
+package cmd
+
+import (
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"digit-cli/pkg/filestore"
+	"github.com/digitnxt/digit3/code/libraries/digit-library/digit"
+	"github.com/spf13/cobra"
+)
+
+// fileUploadCmd represents the file upload command
+var fileUploadCmd = &cobra.Command{
+	Use:   "upload <file>...",
+	Short: "Upload files to filestore",
+	Long: `Upload one or more files to filestore under a document category.
+
+Each file is checked against the category before it is sent: files whose size is
+outside the category's bounds, or whose content (not extension) is not one of its
+allowed formats, are rejected. Use 'digit file check' to validate files without
+uploading them.
+
+Upload progress is shown on stderr. After each upload the SHA-256 checksum of the
+local file is verified against the checksum reported by filestore; when filestore
+does not report one, the file is downloaded again and compared. Use --verify=false
+to skip verification.
+
+With --quiet only the file store IDs are printed, one per line, which is useful
+for attaching documents in scripts and integration tests.
+
+Examples:
+  digit file upload --category AADHAR ./aadhar.pdf
+  digit file upload --category RECEIPT ./receipt-1.pdf ./receipt-2.pdf
+  FILE_ID=$(digit file upload --category RECEIPT ./receipt.pdf --quiet)`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Get flag values
+		category, _ := cmd.Flags().GetString("category")
+		verify, _ := cmd.Flags().GetBool("verify")
+		quiet, _ := cmd.Flags().GetBool("quiet")
+		serverURL, _ := cmd.Flags().GetString("server")
+		jwtToken, _ := cmd.Flags().GetString("jwt-token")
+
+		serverURL, jwtToken, tenantID, err := serverSettings(serverURL, jwtToken)
+		if err != nil {
+			return err
+		}
+
+		failed := 0
+		for _, path := range args {
+			fileID, err := uploadFile(serverURL, jwtToken, tenantID, category, path, verify, quiet)
+			if err != nil {
+				failed++
+				fmt.Fprintf(os.Stderr, "✗ %s: %s\n", path, strings.ReplaceAll(err.Error(), "\n", "\n    "))
+				continue
+			}
+			if quiet {
+				fmt.Println(fileID)
+			}
+		}
+
+		if failed > 0 {
+			return fmt.Errorf("%d of %d file(s) failed to upload", failed, len(args))
+		}
+		return nil
+	},
+}
+
+// uploadFile uploads a single file, verifies its checksum and returns its file store ID
+func uploadFile(serverURL, jwtToken, tenantID, category, path string, verify, quiet bool) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to open file: %w", err)
+	}
+	defer file.Close()
+	stat, err := file.Stat()
+	if err != nil {
+		return "", fmt.Errorf("failed to read file: %w", err)
+	}
+	if stat.IsDir() {
+		return "", fmt.Errorf("is a directory")
+	}
+
+	// Hash the content as it is streamed to the server
+	sha256Hash, md5Hash := sha256.New(), md5.New()
+	var content io.Reader = io.TeeReader(file, io.MultiWriter(sha256Hash, md5Hash))
+	var progress *filestore.Progress
+	if !quiet {
+		progress = &filestore.Progress{Out: os.Stderr, Label: "Uploading " + filepath.Base(path), Total: stat.Size()}
+		content = progress.Reader(content)
+	}
+
+	responseBody, err := digit.UploadFile(serverURL, jwtToken, tenantID, category, path, content, stat.Size())
+	if progress != nil {
+		progress.Finish()
+	}
+	if err != nil {
+		return "", err
+	}
+	sha256Hex := hex.EncodeToString(sha256Hash.Sum(nil))
+	md5Hex := hex.EncodeToString(md5Hash.Sum(nil))
+
+	info, err := filestore.ParseFileInfo(responseBody)
+	if err != nil {
+		return "", err
+	}
+
+	if verify {
+		if err := verifyUpload(serverURL, jwtToken, tenantID, info, sha256Hex, md5Hex); err != nil {
+			return info.ID, err
+		}
+	}
+
+	if !quiet {
+		fmt.Printf("✓ %s → %s (%s, sha256 %s)\n", path, info.ID, filestore.FormatSize(stat.Size()), sha256Hex)
+	}
+	return info.ID, nil
+}
+
+// verifyUpload checks the checksum of an uploaded file against the local digests, using the
+// checksum reported by filestore or, when none is reported, the downloaded content
+func verifyUpload(serverURL, jwtToken, tenantID string, info *filestore.FileInfo, sha256Hex, md5Hex string) error {
+	reported := info.Checksum
+	if reported == "" {
+		if metadata, err := digit.GetFileMetadata(serverURL, jwtToken, tenantID, info.ID); err == nil {
+			if parsed, err := filestore.ParseFileInfo(metadata); err == nil {
+				reported = parsed.Checksum
+			}
+		}
+	}
+	if match, known := filestore.ChecksumMatches(reported, sha256Hex, md5Hex); known {
+		if !match {
+			return fmt.Errorf("checksum mismatch: uploaded %s, filestore reports %s", sha256Hex, reported)
+		}
+		return nil
+	}
+
+	// No usable checksum from filestore; compare the stored content instead
+	downloadHash := sha256.New()
+	if _, err := digit.DownloadFile(serverURL, jwtToken, tenantID, info.ID, downloadHash); err != nil {
+		return fmt.Errorf("failed to verify upload: %w", err)
+	}
+	if downloaded := hex.EncodeToString(downloadHash.Sum(nil)); downloaded != sha256Hex {
+		return fmt.Errorf("checksum mismatch: uploaded %s, stored file is %s", sha256Hex, downloaded)
+	}
+	return nil
+}
+
+func init() {
+	fileCmd.AddCommand(fileUploadCmd)
+
+	// Add flags
+	fileUploadCmd.Flags().String("category", "", "Document category code to upload the files under (required)")
+	fileUploadCmd.Flags().Bool("verify", true, "Verify the checksum of each uploaded file")
+	fileUploadCmd.Flags().BoolP("quiet", "q", false, "Print only the file store IDs")
+	fileUploadCmd.Flags().String("server", "", "Server URL (overrides config)")
+	fileUploadCmd.Flags().String("jwt-token", "", "JWT token for authentication (overrides config)")
+
+	// Mark required flags
+	fileUploadCmd.MarkFlagRequired("category")
+}
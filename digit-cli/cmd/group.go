@@ -0,0 +1,187 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/digitnxt/digit3/code/libraries/digit-library/digit"
+	"github.com/spf13/cobra"
+)
+
+// groupCmd represents the group command
+var groupCmd = &cobra.Command{
+	Use:   "group",
+	Short: "Manage Keycloak groups and their members",
+	Long: `Create, list, nest and delete the groups of an account (realm), and manage
+their members. Groups are written by path, e.g. /departments/pgr.
+
+Members of a group hold the roles mapped to it and to its parent groups;
+map roles to groups with 'digit role assign --group'. The account defaults to
+that of the stored login ('digit config set').`,
+}
+
+// groupPageSize is the page size for listing groups and members
+const groupPageSize = 100
+
+// findGroup fetches the group with the given path, or nil when there is none
+func findGroup(serverURL, jwtToken, realm, path string) (*digit.Group, error) {
+	responseBody, err := digit.GetGroupByPath(serverURL, jwtToken, realm, path)
+	if err != nil {
+		var keycloakErr *digit.KeycloakError
+		if errors.As(err, &keycloakErr) && keycloakErr.StatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to fetch group %s: %w", path, err)
+	}
+	return digit.ParseGroup(responseBody)
+}
+
+// mustGroup fetches a group like findGroup, but a group that does not exist is an error
+func mustGroup(serverURL, jwtToken, realm, path string) (*digit.Group, error) {
+	group, err := findGroup(serverURL, jwtToken, realm, path)
+	if err == nil && group == nil {
+		err = fmt.Errorf("group %s does not exist in account %s", path, realm)
+	}
+	return group, err
+}
+
+// cleanGroupPath writes a group path with a leading / and without a trailing one
+func cleanGroupPath(path string) string {
+	return "/" + strings.Trim(path, "/")
+}
+
+// parentGroupPath returns the path of the parent of a group, or "" for top-level groups
+func parentGroupPath(path string) string {
+	i := strings.LastIndex(path, "/")
+	if i <= 0 {
+		return ""
+	}
+	return path[:i]
+}
+
+// fetchGroupTree lists the top-level groups matching search with all their subgroups
+func fetchGroupTree(serverURL, jwtToken, realm, search string) ([]digit.Group, error) {
+	var groups []digit.Group
+	for first := 0; ; first += groupPageSize {
+		responseBody, err := digit.ListGroups(serverURL, jwtToken, realm, digit.GroupQuery{Search: search, First: first, Max: groupPageSize})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list groups: %w", err)
+		}
+		page, err := digit.ParseGroups(responseBody)
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, page...)
+		if len(page) < groupPageSize {
+			break
+		}
+	}
+	for i := range groups {
+		if err := fetchSubGroups(serverURL, jwtToken, realm, &groups[i]); err != nil {
+			return nil, err
+		}
+	}
+	return groups, nil
+}
+
+// fetchSubGroups fills in the subgroups of a group at every level; Keycloak 23 and later only
+// count them when listing groups
+func fetchSubGroups(serverURL, jwtToken, realm string, group *digit.Group) error {
+	if len(group.SubGroups) == 0 && group.SubGroupCount > 0 {
+		for first := 0; ; first += groupPageSize {
+			responseBody, err := digit.GetGroupChildren(serverURL, jwtToken, realm, group.ID, first, groupPageSize)
+			if err != nil {
+				return fmt.Errorf("failed to fetch the subgroups of %s: %w", group.Path, err)
+			}
+			page, err := digit.ParseGroups(responseBody)
+			if err != nil {
+				return err
+			}
+			group.SubGroups = append(group.SubGroups, page...)
+			if len(page) < groupPageSize {
+				break
+			}
+		}
+	}
+	for i := range group.SubGroups {
+		if err := fetchSubGroups(serverURL, jwtToken, realm, &group.SubGroups[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// flattenGroups returns groups and their subgroups in tree order, each group before its subgroups
+func flattenGroups(groups []digit.Group) []digit.Group {
+	var flat []digit.Group
+	for _, group := range groups {
+		flat = append(flat, group)
+		flat = append(flat, flattenGroups(group.SubGroups)...)
+	}
+	return flat
+}
+
+// eachGroupMember calls fn for every direct member of a group, a page at a time
+func eachGroupMember(serverURL, jwtToken, realm, groupID string, fn func(digit.User) error) error {
+	for first := 0; ; first += groupPageSize {
+		responseBody, err := digit.GetGroupMembers(serverURL, jwtToken, realm, groupID, first, groupPageSize)
+		if err != nil {
+			return fmt.Errorf("failed to fetch group members: %w", err)
+		}
+		users, err := digit.ParseUsers(responseBody)
+		if err != nil {
+			return err
+		}
+		for _, user := range users {
+			if err := fn(user); err != nil {
+				return err
+			}
+		}
+		if len(users) < groupPageSize {
+			return nil
+		}
+	}
+}
+
+// groupRoleNames fetches the names of the realm roles mapped directly to a group, or of the
+// roles of a client when clientUUID is set
+func groupRoleNames(serverURL, jwtToken, realm, groupID, clientUUID string) (map[string]bool, error) {
+	var responseBody string
+	var err error
+	if clientUUID == "" {
+		responseBody, err = digit.GetGroupRealmRoles(serverURL, jwtToken, realm, groupID)
+	} else {
+		responseBody, err = digit.GetGroupClientRoles(serverURL, jwtToken, realm, groupID, clientUUID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch group roles: %w", err)
+	}
+	roles, err := digit.ParseRoles(responseBody)
+	if err != nil {
+		return nil, err
+	}
+	names := map[string]bool{}
+	for _, role := range roles {
+		names[role.Name] = true
+	}
+	return names, nil
+}
+
+// formatGroupRoles formats the realm and client roles of a group, client roles as <clientId>/<role>
+func formatGroupRoles(group digit.Group) string {
+	roles := append([]string{}, group.RealmRoles...)
+	for clientID, names := range group.ClientRoles {
+		for _, name := range names {
+			roles = append(roles, roleRef(clientID, name))
+		}
+	}
+	sort.Strings(roles)
+	return formatNames(roles)
+}
+
+func init() {
+	rootCmd.AddCommand(groupCmd)
+}
@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/digitnxt/digit3/code/libraries/digit-library/digit"
+	"github.com/spf13/cobra"
+)
+
+// groupAddMemberCmd represents the group add-member command
+var groupAddMemberCmd = &cobra.Command{
+	Use:   "add-member <path> <username>...",
+	Short: "Add users to a group",
+	Long: `Make one or more users members of a group. They then hold the roles of the
+group and of its parent groups. Users that are already members are left as
+they are.
+
+Examples:
+  digit group add-member /departments/pgr jdoe asmith`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runGroupMembership(cmd, args[0], args[1:], true)
+	},
+}
+
+// runGroupMembership adds users to the group at path, or removes them from it
+func runGroupMembership(cmd *cobra.Command, path string, usernames []string, add bool) error {
+	// Get flag values
+	realm, _ := cmd.Flags().GetString("account")
+	serverURL, _ := cmd.Flags().GetString("server")
+	jwtToken, _ := cmd.Flags().GetString("jwt-token")
+
+	serverURL, jwtToken, realm, err := realmSettings(serverURL, jwtToken, realm)
+	if err != nil {
+		return err
+	}
+	groupPath := cleanGroupPath(path)
+	group, err := mustGroup(serverURL, jwtToken, realm, groupPath)
+	if err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, username := range usernames {
+		fail := func(err error) {
+			failed++
+			fmt.Printf("✗ %s: %v\n", username, err)
+		}
+		user, err := findUser(serverURL, jwtToken, realm, username)
+		if err != nil {
+			fail(err)
+			continue
+		}
+		if user == nil {
+			fail(fmt.Errorf("user does not exist in account %s", realm))
+			continue
+		}
+		member, err := userInGroup(serverURL, jwtToken, realm, user.ID, group.ID)
+		if err != nil {
+			fail(err)
+			continue
+		}
+
+		switch {
+		case add && member:
+			fmt.Printf("= %s is already in %s\n", user.Username, groupPath)
+		case !add && !member:
+			fmt.Printf("= %s is not in %s\n", user.Username, groupPath)
+		case add:
+			if _, err := digit.AddUserToGroup(serverURL, jwtToken, realm, user.ID, group.ID); err != nil {
+				fail(err)
+				continue
+			}
+			fmt.Printf("✓ Added %s to %s\n", user.Username, groupPath)
+		default:
+			if _, err := digit.RemoveUserFromGroup(serverURL, jwtToken, realm, user.ID, group.ID); err != nil {
+				fail(err)
+				continue
+			}
+			fmt.Printf("✓ Removed %s from %s\n", user.Username, groupPath)
+		}
+	}
+
+	if failed > 0 {
+		if add {
+			return fmt.Errorf("%d of %d users could not be added", failed, len(usernames))
+		}
+		return fmt.Errorf("%d of %d users could not be removed", failed, len(usernames))
+	}
+	return nil
+}
+
+// userGroups fetches the groups a user is a direct member of
+func userGroups(serverURL, jwtToken, realm, userID string) ([]digit.Group, error) {
+	responseBody, err := digit.GetUserGroups(serverURL, jwtToken, realm, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch groups: %w", err)
+	}
+	return digit.ParseGroups(responseBody)
+}
+
+// userInGroup reports whether a user is a direct member of a group
+func userInGroup(serverURL, jwtToken, realm, userID, groupID string) (bool, error) {
+	groups, err := userGroups(serverURL, jwtToken, realm, userID)
+	if err != nil {
+		return false, err
+	}
+	for _, group := range groups {
+		if group.ID == groupID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func init() {
+	groupCmd.AddCommand(groupAddMemberCmd)
+
+	// Add flags
+	groupAddMemberCmd.Flags().String("account", "", "Account (realm) (overrides config)")
+	groupAddMemberCmd.Flags().String("server", "", "Server URL (overrides config)")
+	groupAddMemberCmd.Flags().String("jwt-token", "", "JWT token for authentication (overrides config)")
+}
@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/digitnxt/digit3/code/libraries/digit-library/digit"
+	"github.com/spf13/cobra"
+)
+
+// groupCreateCmd represents the group create command
+var groupCreateCmd = &cobra.Command{
+	Use:   "create <path>...",
+	Short: "Create groups, with any missing parent groups",
+	Long: `Create one or more groups by path. Missing parent groups are created too, so
+/departments/pgr creates /departments first when it does not exist. Groups
+that already exist are left as they are.
+
+Examples:
+  digit group create /departments
+  digit group create /departments/pgr /departments/water`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Get flag values
+		realm, _ := cmd.Flags().GetString("account")
+		serverURL, _ := cmd.Flags().GetString("server")
+		jwtToken, _ := cmd.Flags().GetString("jwt-token")
+
+		serverURL, jwtToken, realm, err := realmSettings(serverURL, jwtToken, realm)
+		if err != nil {
+			return err
+		}
+
+		failed := 0
+		for _, arg := range args {
+			groupPath := cleanGroupPath(arg)
+			if groupPath == "/" {
+				failed++
+				fmt.Printf("✗ %s: group path cannot be empty\n", arg)
+				continue
+			}
+			existing, err := findGroup(serverURL, jwtToken, realm, groupPath)
+			if err != nil {
+				failed++
+				fmt.Printf("✗ %s: %v\n", groupPath, err)
+				continue
+			}
+			if existing != nil {
+				fmt.Printf("= %s (already exists)\n", groupPath)
+				continue
+			}
+			if err := createGroupPath(serverURL, jwtToken, realm, groupPath); err != nil {
+				failed++
+				fmt.Printf("✗ %s: %v\n", groupPath, err)
+			}
+		}
+
+		if failed > 0 {
+			return fmt.Errorf("%d of %d groups could not be created", failed, len(args))
+		}
+		return nil
+	},
+}
+
+// createGroupPath creates a group that does not exist, creating its missing parent groups first
+func createGroupPath(serverURL, jwtToken, realm, groupPath string) error {
+	parentPath := parentGroupPath(groupPath)
+	group := digit.Group{Name: path.Base(groupPath)}
+	if parentPath == "" {
+		if _, err := digit.CreateGroup(serverURL, jwtToken, realm, group); err != nil {
+			return err
+		}
+		fmt.Printf("✓ Created group %s\n", groupPath)
+		return nil
+	}
+
+	parent, err := findGroup(serverURL, jwtToken, realm, parentPath)
+	if err != nil {
+		return err
+	}
+	if parent == nil {
+		if err := createGroupPath(serverURL, jwtToken, realm, parentPath); err != nil {
+			return err
+		}
+		if parent, err = mustGroup(serverURL, jwtToken, realm, parentPath); err != nil {
+			return err
+		}
+	}
+	if _, err := digit.CreateChildGroup(serverURL, jwtToken, realm, parent.ID, group); err != nil {
+		return err
+	}
+	fmt.Printf("✓ Created group %s\n", groupPath)
+	return nil
+}
+
+func init() {
+	groupCmd.AddCommand(groupCreateCmd)
+
+	// Add flags
+	groupCreateCmd.Flags().String("account", "", "Account (realm) (overrides config)")
+	groupCreateCmd.Flags().String("server", "", "Server URL (overrides config)")
+	groupCreateCmd.Flags().String("jwt-token", "", "JWT token for authentication (overrides config)")
+}
@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/digitnxt/digit3/code/libraries/digit-library/digit"
+	"github.com/spf13/cobra"
+)
+
+// groupDeleteCmd represents the group delete command
+var groupDeleteCmd = &cobra.Command{
+	Use:   "delete <path>...",
+	Short: "Delete groups with their subgroups",
+	Long: `Delete one or more groups by path, with all their subgroups. The members are
+kept, but lose the roles they held through the deleted groups; review them
+first with 'digit group members --subgroups'.
+
+Examples:
+  digit group delete /departments/legacy
+  digit group delete /temp1 /temp2 --yes`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Get flag values
+		yes, _ := cmd.Flags().GetBool("yes")
+		realm, _ := cmd.Flags().GetString("account")
+		serverURL, _ := cmd.Flags().GetString("server")
+		jwtToken, _ := cmd.Flags().GetString("jwt-token")
+
+		serverURL, jwtToken, realm, err := realmSettings(serverURL, jwtToken, realm)
+		if err != nil {
+			return err
+		}
+
+		paths := make([]string, len(args))
+		for i, arg := range args {
+			paths[i] = cleanGroupPath(arg)
+		}
+		if !yes {
+			fmt.Printf("Delete %d group(s) (%s) and their subgroups from account %s? Their members lose the roles of the groups. [y/N]: ", len(paths), strings.Join(paths, ", "), realm)
+			answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+			answer = strings.ToLower(strings.TrimSpace(answer))
+			if answer != "y" && answer != "yes" {
+				fmt.Println("Aborted, no groups deleted")
+				return nil
+			}
+		}
+
+		failed := 0
+		for _, groupPath := range paths {
+			group, err := findGroup(serverURL, jwtToken, realm, groupPath)
+			if err != nil {
+				failed++
+				fmt.Printf("✗ %s: %v\n", groupPath, err)
+				continue
+			}
+			if group == nil {
+				fmt.Printf("= %s (does not exist)\n", groupPath)
+				continue
+			}
+			if _, err := digit.DeleteGroup(serverURL, jwtToken, realm, group.ID); err != nil {
+				failed++
+				fmt.Printf("✗ %s: %v\n", groupPath, err)
+				continue
+			}
+			fmt.Printf("✓ Deleted group %s\n", groupPath)
+		}
+
+		if failed > 0 {
+			return fmt.Errorf("%d of %d groups could not be deleted", failed, len(paths))
+		}
+		return nil
+	},
+}
+
+func init() {
+	groupCmd.AddCommand(groupDeleteCmd)
+
+	// Add flags
+	groupDeleteCmd.Flags().BoolP("yes", "y", false, "Delete without asking for confirmation")
+	groupDeleteCmd.Flags().String("account", "", "Account (realm) (overrides config)")
+	groupDeleteCmd.Flags().String("server", "", "Server URL (overrides config)")
+	groupDeleteCmd.Flags().String("jwt-token", "", "JWT token for authentication (overrides config)")
+}
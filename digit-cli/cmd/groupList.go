@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+)
+
+// groupListCmd represents the group list command
+var groupListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List groups with their subgroups and roles",
+	Long: `List the groups of an account with their subgroups, and the realm and client
+roles mapped to each group. Members of a group also hold the roles of its
+parent groups.
+
+Examples:
+  digit group list
+  digit group list --search pgr
+  digit group list --format json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Get flag values
+		search, _ := cmd.Flags().GetString("search")
+		format, _ := cmd.Flags().GetString("format")
+		realm, _ := cmd.Flags().GetString("account")
+		serverURL, _ := cmd.Flags().GetString("server")
+		jwtToken, _ := cmd.Flags().GetString("jwt-token")
+
+		if format != "table" && format != "json" {
+			return fmt.Errorf("unknown format %q, expected table or json", format)
+		}
+
+		serverURL, jwtToken, realm, err := realmSettings(serverURL, jwtToken, realm)
+		if err != nil {
+			return err
+		}
+		groups, err := fetchGroupTree(serverURL, jwtToken, realm, search)
+		if err != nil {
+			return err
+		}
+
+		if format == "json" {
+			data, err := json.MarshalIndent(groups, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to encode groups: %w", err)
+			}
+			fmt.Println(string(data))
+			return nil
+		}
+		if len(groups) == 0 {
+			fmt.Println("No groups found")
+			return nil
+		}
+		flat := flattenGroups(groups)
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "PATH\tROLES")
+		for _, group := range flat {
+			fmt.Fprintf(w, "%s\t%s\n", group.Path, formatGroupRoles(group))
+		}
+		w.Flush()
+		fmt.Printf("\n%d groups\n", len(flat))
+		return nil
+	},
+}
+
+func init() {
+	groupCmd.AddCommand(groupListCmd)
+
+	// Add flags
+	groupListCmd.Flags().String("search", "", "Only groups whose name contains this, with their parent groups")
+	groupListCmd.Flags().String("format", "table", "Output format: table or json")
+	groupListCmd.Flags().String("account", "", "Account (realm) (overrides config)")
+	groupListCmd.Flags().String("server", "", "Server URL (overrides config)")
+	groupListCmd.Flags().String("jwt-token", "", "JWT token for authentication (overrides config)")
+}
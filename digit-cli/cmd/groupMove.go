@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/digitnxt/digit3/code/libraries/digit-library/digit"
+	"github.com/spf13/cobra"
+)
+
+// groupMoveCmd represents the group move command
+var groupMoveCmd = &cobra.Command{
+	Use:   "move <path> <parent-path>",
+	Short: "Nest a group within another group, or move it to the top level",
+	Long: `Move a group, with its subgroups and members, into another group; a parent
+path of / moves it to the top level. Its members then hold the roles of the
+new parent groups instead of the old ones.
+
+Examples:
+  digit group move /pgr /departments
+  digit group move /departments/legacy /`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Get flag values
+		realm, _ := cmd.Flags().GetString("account")
+		serverURL, _ := cmd.Flags().GetString("server")
+		jwtToken, _ := cmd.Flags().GetString("jwt-token")
+
+		groupPath, parentPath := cleanGroupPath(args[0]), cleanGroupPath(args[1])
+		if groupPath == "/" {
+			return fmt.Errorf("group path cannot be empty")
+		}
+		if parentPath == groupPath || strings.HasPrefix(parentPath, groupPath+"/") {
+			return fmt.Errorf("cannot move group %s into itself", groupPath)
+		}
+
+		serverURL, jwtToken, realm, err := realmSettings(serverURL, jwtToken, realm)
+		if err != nil {
+			return err
+		}
+		group, err := mustGroup(serverURL, jwtToken, realm, groupPath)
+		if err != nil {
+			return err
+		}
+
+		parentID, newPath := "", "/"+group.Name
+		if parentPath != "/" {
+			parent, err := mustGroup(serverURL, jwtToken, realm, parentPath)
+			if err != nil {
+				return err
+			}
+			parentID, newPath = parent.ID, path.Join(parentPath, group.Name)
+		}
+		if newPath == groupPath {
+			fmt.Printf("= %s is already in %s\n", groupPath, parentPath)
+			return nil
+		}
+		if _, err := digit.MoveGroup(serverURL, jwtToken, realm, parentID, *group); err != nil {
+			return fmt.Errorf("failed to move group %s: %w", groupPath, err)
+		}
+		fmt.Printf("✓ Moved group %s to %s\n", groupPath, newPath)
+		return nil
+	},
+}
+
+func init() {
+	groupCmd.AddCommand(groupMoveCmd)
+
+	// Add flags
+	groupMoveCmd.Flags().String("account", "", "Account (realm) (overrides config)")
+	groupMoveCmd.Flags().String("server", "", "Server URL (overrides config)")
+	groupMoveCmd.Flags().String("jwt-token", "", "JWT token for authentication (overrides config)")
+}
@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// groupRemoveMemberCmd represents the group remove-member command
+var groupRemoveMemberCmd = &cobra.Command{
+	Use:   "remove-member <path> <username>...",
+	Short: "Remove users from a group",
+	Long: `Remove one or more users from a group. They lose the roles they held through
+the group, unless they hold them otherwise.
+
+Examples:
+  digit group remove-member /departments/pgr jdoe`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runGroupMembership(cmd, args[0], args[1:], false)
+	},
+}
+
+func init() {
+	groupCmd.AddCommand(groupRemoveMemberCmd)
+
+	// Add flags
+	groupRemoveMemberCmd.Flags().String("account", "", "Account (realm) (overrides config)")
+	groupRemoveMemberCmd.Flags().String("server", "", "Server URL (overrides config)")
+	groupRemoveMemberCmd.Flags().String("jwt-token", "", "JWT token for authentication (overrides config)")
+}
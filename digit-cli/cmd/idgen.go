@@ -0,0 +1,16 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// idgenCmd represents the idgen command
+var idgenCmd = &cobra.Command{
+	Use:   "idgen",
+	Short: "Generate IDs from ID generation templates",
+	Long:  `Generate IDs from ID generation templates registered with the IdGen service.`,
+}
+
+func init() {
+	rootCmd.AddCommand(idgenCmd)
+}
@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"digit-cli/pkg/config"
+	"digit-cli/pkg/jwt"
+	"github.com/digitnxt/digit3/code/libraries/digit-library/digit"
+	"github.com/spf13/cobra"
+)
+
+// idgenGenerateCmd represents the idgen generate command
+var idgenGenerateCmd = &cobra.Command{
+	Use:   "generate",
+	Short: "Generate IDs from an ID generation template",
+	Long: `Generate one or more IDs from an ID generation template.
+
+Template variables such as {ORG} are supplied with --var NAME=VALUE, which can
+be repeated. Generated IDs are printed one per line.
+
+Examples:
+  # Generate a single ID
+  digit idgen generate --template-code orgId --var ORG=PB
+
+  # Generate 10 IDs
+  digit idgen generate --template-code orgId --var ORG=PB --count 10
+
+  # With server override
+  digit idgen generate --template-code orgId --var ORG=PB --server http://localhost:8100`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Get flag values
+		templateCode, _ := cmd.Flags().GetString("template-code")
+		vars, _ := cmd.Flags().GetStringArray("var")
+		count, _ := cmd.Flags().GetInt("count")
+		serverURL, _ := cmd.Flags().GetString("server")
+		jwtToken, _ := cmd.Flags().GetString("jwt-token")
+
+		if count < 1 {
+			return fmt.Errorf("--count must be at least 1")
+		}
+
+		// Parse NAME=VALUE variables
+		variables := make(map[string]string)
+		for _, v := range vars {
+			name, value, found := strings.Cut(v, "=")
+			if !found || strings.TrimSpace(name) == "" {
+				return fmt.Errorf("invalid --var %q, expected NAME=VALUE", v)
+			}
+			variables[strings.TrimSpace(name)] = value
+		}
+
+		// Get server URL and JWT token from config if not provided
+		if serverURL == "" || jwtToken == "" {
+			cfg, _ := config.Load()
+			if serverURL == "" {
+				serverURL = cfg.Server
+			}
+			if jwtToken == "" {
+				jwtToken = cfg.JWTToken
+			}
+		}
+
+		if jwtToken == "" {
+			return fmt.Errorf("JWT token is required (set via config or --jwt-token flag)")
+		}
+		if serverURL == "" {
+			return fmt.Errorf("server URL is required (set via config or --server flag)")
+		}
+
+		// Extract client ID and tenant ID from JWT token
+		clientID, err := jwt.ExtractClientID(jwtToken)
+		if err != nil {
+			return fmt.Errorf("failed to extract client ID from JWT token: %w", err)
+		}
+
+		tenantID, err := jwt.ExtractTenantID(jwtToken)
+		if err != nil {
+			return fmt.Errorf("failed to extract tenant ID from JWT token: %w", err)
+		}
+
+		ids, err := digit.GenerateIDs(serverURL, jwtToken, clientID, tenantID, templateCode, variables, count)
+		for _, id := range ids {
+			fmt.Println(id)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to generate IDs: %w", err)
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	idgenCmd.AddCommand(idgenGenerateCmd)
+
+	// Add flags
+	idgenGenerateCmd.Flags().String("template-code", "", "Template code to generate IDs from (required)")
+	idgenGenerateCmd.Flags().StringArray("var", []string{}, "Template variable as NAME=VALUE (repeatable)")
+	idgenGenerateCmd.Flags().Int("count", 1, "Number of IDs to generate")
+	idgenGenerateCmd.Flags().StringP("server", "s", "", "Server URL (overrides config)")
+	idgenGenerateCmd.Flags().StringP("jwt-token", "t", "", "JWT token for authentication (overrides config)")
+
+	// Mark required flags
+	idgenGenerateCmd.MarkFlagRequired("template-code")
+}
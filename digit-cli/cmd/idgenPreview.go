@@ -0,0 +1,265 @@
+package cmd
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/digitnxt/digit3/code/libraries/digit-library/digit"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// idgenPreviewCmd represents the idgen preview command
+var idgenPreviewCmd = &cobra.Command{
+	Use:   "preview",
+	Short: "Preview IDs an ID generation template would produce",
+	Long: `Validate an ID generation template locally and preview the IDs it would produce.
+
+Nothing is sent to the server. The command parses the template pattern, checks
+its tokens and date formats, and renders sample IDs for each sequence period
+in the date range. It also reports how many sequence numbers fit in the padding
+before it overflows and estimates the collision probability of the random
+segment. A daily, monthly or yearly sequence restarts every period, so the
+command warns when no {DATE:<format>} token tells the periods apart and IDs
+would repeat.
+
+Supported tokens: {SEQ}, {RAND}, {DATE:<format>} and variables such as {ORG}.
+Date formats use yyyy, yy, MM, MMM, dd, HH, mm, ss and SSS.
+
+Examples:
+  # Preview the default template
+  digit idgen preview --default --var ORG=PB
+
+  # Preview a template over a month boundary
+  digit idgen preview --template "{ORG}-{DATE:yyyyMM}-{SEQ}" --scope monthly --padding-length 5 --var ORG=PB --from 2025-01-30 --to 2025-02-02
+
+  # Estimate collisions for a random-only template at 50000 IDs per day
+  digit idgen preview --template "DOC-{RAND}" --random-length 6 --volume 50000`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Get flag values
+		useDefault, _ := cmd.Flags().GetBool("default")
+		template, _ := cmd.Flags().GetString("template")
+		scope, _ := cmd.Flags().GetString("scope")
+		start, _ := cmd.Flags().GetInt("start")
+		paddingLength, _ := cmd.Flags().GetInt("padding-length")
+		paddingChar, _ := cmd.Flags().GetString("padding-char")
+		randomLength, _ := cmd.Flags().GetInt("random-length")
+		randomCharset, _ := cmd.Flags().GetString("random-charset")
+		vars, _ := cmd.Flags().GetStringArray("var")
+		fromStr, _ := cmd.Flags().GetString("from")
+		toStr, _ := cmd.Flags().GetString("to")
+		perPeriod, _ := cmd.Flags().GetInt("per-period")
+		volume, _ := cmd.Flags().GetInt64("volume")
+
+		if useDefault && template != "" {
+			return fmt.Errorf("cannot use both --template and --default flags together")
+		}
+		if useDefault {
+			// The embedded default configuration is plain YAML
+			var defaults struct {
+				Template string `yaml:"template"`
+			}
+			if err := yaml.Unmarshal([]byte(defaultIdGenConfig), &defaults); err != nil {
+				return fmt.Errorf("failed to parse default IdGen configuration: %w", err)
+			}
+			template = defaults.Template
+		}
+		if template == "" {
+			return fmt.Errorf("either --template or --default flag is required")
+		}
+		if perPeriod < 1 {
+			return fmt.Errorf("--per-period must be at least 1")
+		}
+
+		config := digit.IdGenConfig{
+			Template: template,
+			Sequence: digit.SequenceConfig{
+				Scope: scope,
+				Start: start,
+				Padding: digit.PaddingConfig{
+					Length: paddingLength,
+					Char:   paddingChar,
+				},
+			},
+			Random: digit.RandomConfig{
+				Length:  randomLength,
+				Charset: randomCharset,
+			},
+		}
+		if err := digit.ValidateIdGenConfig(config); err != nil {
+			return fmt.Errorf("invalid IdGen template:\n%w", err)
+		}
+		pattern, err := digit.ParseIdGenPattern(template)
+		if err != nil {
+			return err
+		}
+
+		// Parse date range
+		from := time.Now()
+		if fromStr != "" {
+			if from, err = time.Parse("2006-01-02", fromStr); err != nil {
+				return fmt.Errorf("invalid --from date %q, expected YYYY-MM-DD", fromStr)
+			}
+		}
+		to := from
+		if toStr != "" {
+			if to, err = time.Parse("2006-01-02", toStr); err != nil {
+				return fmt.Errorf("invalid --to date %q, expected YYYY-MM-DD", toStr)
+			}
+		}
+		if to.Before(from) {
+			return fmt.Errorf("--to date must not be before --from date")
+		}
+
+		// Parse NAME=VALUE variables; missing ones are shown as <NAME>
+		variables := make(map[string]string)
+		for _, v := range vars {
+			name, value, found := strings.Cut(v, "=")
+			if !found || strings.TrimSpace(name) == "" {
+				return fmt.Errorf("invalid --var %q, expected NAME=VALUE", v)
+			}
+			variables[strings.TrimSpace(name)] = value
+		}
+		var missing []string
+		for _, name := range pattern.Variables() {
+			if _, ok := variables[name]; !ok {
+				variables[name] = "<" + name + ">"
+				missing = append(missing, name)
+			}
+		}
+
+		fmt.Printf("Template:  %s\n", template)
+		fmt.Printf("Tokens:    %s\n", describeIdGenTokens(pattern))
+		if len(missing) > 0 {
+			fmt.Printf("Note: no value given for %s; use --var NAME=VALUE\n", strings.Join(missing, ", "))
+		}
+
+		// Render samples for each sequence period in the date range
+		fmt.Printf("\nSample IDs (%s scope):\n", scope)
+		rnd := rand.New(rand.NewSource(time.Now().UnixNano()))
+		seen := map[string]bool{}
+		seq := start
+		for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+			key := digit.IdGenScopeKey(scope, day)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			if scope != "global" {
+				seq = start
+			}
+			for i := 0; i < perPeriod; i++ {
+				id, err := pattern.Render(config, variables, day, seq, rnd)
+				if err != nil {
+					return err
+				}
+				label := ""
+				if i == 0 {
+					label = day.Format("2006-01-02")
+				}
+				fmt.Printf("  %-10s  %s\n", label, id)
+				seq++
+			}
+		}
+
+		// Sequence capacity
+		fmt.Println()
+		period := map[string]string{"daily": "day", "monthly": "month", "yearly": "year", "global": "scope"}[scope]
+		capacity := digit.SequenceCapacity(config.Sequence)
+		if pattern.Has(digit.IdGenTokenSequence) {
+			switch {
+			case capacity < 0:
+				fmt.Printf("Sequence capacity:  unbounded (no padding configured)\n")
+			case capacity == 0:
+				fmt.Printf("Sequence capacity:  0 — start %d already exceeds %d-character padding\n", start, paddingLength)
+			default:
+				fmt.Printf("Sequence capacity:  %d IDs per %s before the %d-character padding overflows\n", capacity, period, paddingLength)
+			}
+		}
+
+		// A sequence that restarts repeats its numbers, so the IDs repeat unless a date tells the periods apart
+		repeats := pattern.Has(digit.IdGenTokenSequence) && !pattern.CoversScope(scope)
+		if repeats && !pattern.Has(digit.IdGenTokenRandom) {
+			fmt.Printf("Collision chance:   100%% — the sequence restarts every %s and no {DATE:…} token includes the %s,\n", period, idGenScopeFields[scope])
+			fmt.Printf("                    so every %s repeats the IDs of the %s before\n", period, period)
+		}
+
+		// Random segment collision estimate
+		if pattern.Has(digit.IdGenTokenRandom) {
+			combinations, err := digit.RandomCombinations(config.Random)
+			if err != nil {
+				return err
+			}
+			if volume <= 0 {
+				volume = 1000
+				if capacity > 0 {
+					volume = capacity
+				}
+			}
+			probability, err := digit.RandomCollisionProbability(config.Random, volume)
+			if err != nil {
+				return err
+			}
+			charset, _ := digit.ExpandCharset(randomCharset)
+			fmt.Printf("Random segment:     %d characters from %d → %.0f combinations\n", randomLength, len([]rune(charset)), combinations)
+			fmt.Printf("Collision chance:   %.4f%% for %d IDs per %s\n", probability*100, volume, period)
+			if repeats {
+				fmt.Printf("Warning:            the sequence restarts every %s and no {DATE:…} token includes the %s,\n", period, idGenScopeFields[scope])
+				fmt.Printf("                    so IDs with the same {SEQ} in different %ss differ only by {RAND}\n", period)
+				fmt.Printf("                    (1 in %.0f chance of being equal)\n", combinations)
+			} else if pattern.Has(digit.IdGenTokenSequence) {
+				fmt.Printf("                    (full IDs stay unique within a %s because of {SEQ})\n", period)
+			}
+		}
+
+		return nil
+	},
+}
+
+// idGenScopeFields names the date fields a {DATE} token needs to tell apart the periods of a scope
+var idGenScopeFields = map[string]string{
+	"daily":   "year, month and day",
+	"monthly": "year and month",
+	"yearly":  "year",
+}
+
+// describeIdGenTokens renders the parsed tokens for display
+func describeIdGenTokens(pattern *digit.IdGenPattern) string {
+	var parts []string
+	for _, token := range pattern.Tokens {
+		switch token.Kind {
+		case digit.IdGenTokenLiteral:
+			parts = append(parts, fmt.Sprintf("%q", token.Value))
+		case digit.IdGenTokenVariable:
+			parts = append(parts, fmt.Sprintf("{%s} variable", token.Value))
+		case digit.IdGenTokenDate:
+			parts = append(parts, fmt.Sprintf("{DATE:%s} date", token.Value))
+		case digit.IdGenTokenSequence:
+			parts = append(parts, "{SEQ} sequence")
+		case digit.IdGenTokenRandom:
+			parts = append(parts, "{RAND} random")
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+func init() {
+	idgenCmd.AddCommand(idgenPreviewCmd)
+
+	// Add flags
+	idgenPreviewCmd.Flags().Bool("default", false, "Preview the default IdGen template pattern")
+	idgenPreviewCmd.Flags().String("template", "", "Template pattern (e.g., '{ORG}-{DATE:yyyyMMdd}-{SEQ}-{RAND}')")
+	idgenPreviewCmd.Flags().String("scope", "daily", "Sequence scope (daily, monthly, yearly, global)")
+	idgenPreviewCmd.Flags().Int("start", 1, "Starting number for sequence")
+	idgenPreviewCmd.Flags().Int("padding-length", 4, "Padding length for sequence numbers")
+	idgenPreviewCmd.Flags().String("padding-char", "0", "Padding character for sequence numbers")
+	idgenPreviewCmd.Flags().Int("random-length", 2, "Length of random string")
+	idgenPreviewCmd.Flags().String("random-charset", "A-Z0-9", "Character set for random string")
+	idgenPreviewCmd.Flags().StringArray("var", []string{}, "Template variable as NAME=VALUE (repeatable)")
+	idgenPreviewCmd.Flags().String("from", "", "First date to preview, YYYY-MM-DD (default: today)")
+	idgenPreviewCmd.Flags().String("to", "", "Last date to preview, YYYY-MM-DD (default: --from)")
+	idgenPreviewCmd.Flags().Int("per-period", 3, "Number of sample IDs per sequence period")
+	idgenPreviewCmd.Flags().Int64("volume", 0, "IDs per period used for the collision estimate (default: sequence capacity, or 1000)")
+}
@@ -0,0 +1,16 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// notifyCmd represents the notify command
+var notifyCmd = &cobra.Command{
+	Use:   "notify",
+	Short: "Send notifications from notification templates",
+	Long:  `Send email and SMS notifications rendered from notification templates.`,
+}
+
+func init() {
+	rootCmd.AddCommand(notifyCmd)
+}
@@ -0,0 +1,318 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"digit-cli/pkg/config"
+	"digit-cli/pkg/jwt"
+	"github.com/digitnxt/digit3/code/libraries/digit-library/digit"
+	"github.com/spf13/cobra"
+)
+
+// notificationMessage is a single send request built from flags or a CSV row
+type notificationMessage struct {
+	Channel    string
+	Recipients []string
+	Payload    map[string]interface{}
+}
+
+// notifySendCmd represents the notify send command
+var notifySendCmd = &cobra.Command{
+	Use:   "send",
+	Short: "Send an email or SMS from a notification template",
+	Long: `Send an email or SMS rendered from a notification template.
+
+The channel is taken from --channel, or inferred from the recipients: addresses
+containing '@' are sent as email, anything else as SMS. The payload file is a
+JSON object whose fields fill the template placeholders.
+
+In bulk mode (--csv) each row is one notification. The CSV must have a header
+row with a "to" column holding the recipient; every other non-empty column is
+added to the payload, overriding fields from --payload. Rows are sent one at a
+time and a per-row result is printed.
+
+Examples:
+  # Send an email
+  digit notify send --template-id welcome --version 1.0.0 --to a@b.c --payload payload.json
+
+  # Send an OTP SMS to two numbers
+  digit notify send --template-id otp --version 1.0.0 --to 9999999999,8888888888 --category OTP --payload payload.json
+
+  # Bulk send from CSV, previewing the requests first
+  digit notify send --template-id welcome --version 1.0.0 --csv recipients.csv --dry-run
+  digit notify send --template-id welcome --version 1.0.0 --csv recipients.csv`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Get flag values
+		templateID, _ := cmd.Flags().GetString("template-id")
+		version, _ := cmd.Flags().GetString("version")
+		channel, _ := cmd.Flags().GetString("channel")
+		recipients, _ := cmd.Flags().GetStringSlice("to")
+		payloadFile, _ := cmd.Flags().GetString("payload")
+		csvFile, _ := cmd.Flags().GetString("csv")
+		category, _ := cmd.Flags().GetString("category")
+		attachments, _ := cmd.Flags().GetStringSlice("attachment")
+		enrich, _ := cmd.Flags().GetBool("enrich")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		serverURL, _ := cmd.Flags().GetString("server")
+		jwtToken, _ := cmd.Flags().GetString("jwt-token")
+
+		// Validate flags - either recipients or CSV must be specified
+		if len(recipients) == 0 && csvFile == "" {
+			return fmt.Errorf("either --to or --csv flag is required")
+		}
+		if len(recipients) > 0 && csvFile != "" {
+			return fmt.Errorf("cannot use both --to and --csv flags together")
+		}
+		channel = strings.ToLower(channel)
+		if channel != "" && channel != "email" && channel != "sms" {
+			return fmt.Errorf("invalid channel %q, must be email or sms", channel)
+		}
+		category = strings.ToUpper(category)
+
+		// Load the base payload
+		basePayload := map[string]interface{}{}
+		if payloadFile != "" {
+			data, err := os.ReadFile(payloadFile)
+			if err != nil {
+				return fmt.Errorf("failed to read payload file: %w", err)
+			}
+			if err := json.Unmarshal(data, &basePayload); err != nil {
+				return fmt.Errorf("payload file must contain a JSON object: %w", err)
+			}
+		}
+
+		// Build the notifications to send
+		var notifications []notificationMessage
+		if csvFile != "" {
+			var err error
+			notifications, err = readNotificationCSV(csvFile, channel, basePayload)
+			if err != nil {
+				return err
+			}
+		} else {
+			resolved, err := notificationChannel(channel, recipients)
+			if err != nil {
+				return err
+			}
+			notifications = []notificationMessage{{Channel: resolved, Recipients: recipients, Payload: basePayload}}
+		}
+
+		for _, n := range notifications {
+			if n.Channel == "sms" && !containsString(digit.SMSCategories, category) {
+				return fmt.Errorf("invalid SMS category %q, must be one of %s", category, strings.Join(digit.SMSCategories, ", "))
+			}
+		}
+
+		if dryRun {
+			for i, n := range notifications {
+				request := notificationRequest(n, templateID, version, category, attachments, enrich)
+				requestJSON, _ := json.MarshalIndent(request, "", "  ")
+				fmt.Printf("[%d] %s to %s:\n%s\n", i+1, strings.ToUpper(n.Channel), strings.Join(n.Recipients, ", "), string(requestJSON))
+			}
+			fmt.Printf("\nDry run: %d notification(s) not sent\n", len(notifications))
+			return nil
+		}
+
+		// Get server URL and JWT token from config if not provided
+		if serverURL == "" || jwtToken == "" {
+			cfg, err := config.Load()
+			if err != nil {
+				return fmt.Errorf("failed to load config: %w", err)
+			}
+
+			if serverURL == "" {
+				serverURL = cfg.GetServer()
+				if serverURL == "" {
+					return fmt.Errorf("server URL not configured. Use 'digit config set --server <url>' or provide --server flag")
+				}
+			}
+
+			if jwtToken == "" {
+				jwtToken = cfg.GetJWTToken()
+				if jwtToken == "" {
+					return fmt.Errorf("JWT token not configured. Use 'digit config set --jwt-token <token>' or provide --jwt-token flag")
+				}
+			}
+		}
+
+		// Extract tenant ID from JWT token
+		tenantID, err := jwt.ExtractTenantID(jwtToken)
+		if err != nil {
+			return fmt.Errorf("failed to extract tenant ID from JWT token: %w", err)
+		}
+
+		failed := 0
+		for i, n := range notifications {
+			var responseBody string
+			if n.Channel == "email" {
+				responseBody, err = digit.SendEmail(serverURL, jwtToken, tenantID, templateID, version, n.Recipients, n.Payload, attachments, enrich)
+			} else {
+				responseBody, err = digit.SendSMS(serverURL, jwtToken, tenantID, templateID, version, n.Recipients, n.Payload, category, enrich)
+			}
+
+			label := strings.Join(n.Recipients, ", ")
+			if len(notifications) > 1 {
+				label = fmt.Sprintf("[%d] %s", i+1, label)
+			}
+			if err != nil {
+				failed++
+				fmt.Printf("✗ %s: %v\n", label, err)
+				continue
+			}
+			status := responseBody
+			var response struct {
+				Status string `json:"status"`
+			}
+			if json.Unmarshal([]byte(responseBody), &response) == nil && response.Status != "" {
+				status = response.Status
+			}
+			fmt.Printf("✓ %s (%s): %s\n", label, strings.ToUpper(n.Channel), status)
+		}
+
+		if len(notifications) > 1 {
+			fmt.Printf("\nSent %d of %d notification(s)\n", len(notifications)-failed, len(notifications))
+		}
+		if failed > 0 {
+			return fmt.Errorf("%d notification(s) failed", failed)
+		}
+		return nil
+	},
+}
+
+// notificationChannel resolves the channel for a set of recipients, inferring it when not given
+func notificationChannel(channel string, recipients []string) (string, error) {
+	for _, recipient := range recipients {
+		inferred := "sms"
+		if strings.Contains(recipient, "@") {
+			inferred = "email"
+		}
+		if channel == "" {
+			channel = inferred
+		}
+		if inferred != channel {
+			return "", fmt.Errorf("recipient %q is not a valid %s recipient", recipient, channel)
+		}
+	}
+	return channel, nil
+}
+
+// containsString reports whether list contains value
+func containsString(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
+
+// readNotificationCSV builds one notification per CSV row
+func readNotificationCSV(path, channel string, basePayload map[string]interface{}) ([]notificationMessage, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open CSV file: %w", err)
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.TrimLeadingSpace = true
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+	toColumn := -1
+	for i, name := range header {
+		header[i] = strings.TrimSpace(name)
+		if header[i] == "to" {
+			toColumn = i
+		}
+	}
+	if toColumn < 0 {
+		return nil, fmt.Errorf("CSV file must have a \"to\" column")
+	}
+
+	var notifications []notificationMessage
+	for line := 2; ; line++ {
+		row, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CSV file: %w", err)
+		}
+
+		recipient := strings.TrimSpace(row[toColumn])
+		if recipient == "" {
+			return nil, fmt.Errorf("line %d: recipient is empty", line)
+		}
+		resolved, err := notificationChannel(channel, []string{recipient})
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		payload := make(map[string]interface{}, len(basePayload)+len(row))
+		for key, value := range basePayload {
+			payload[key] = value
+		}
+		for i, value := range row {
+			if i != toColumn && value != "" {
+				payload[header[i]] = value
+			}
+		}
+		notifications = append(notifications, notificationMessage{Channel: resolved, Recipients: []string{recipient}, Payload: payload})
+	}
+
+	if len(notifications) == 0 {
+		return nil, fmt.Errorf("CSV file has no recipients")
+	}
+	return notifications, nil
+}
+
+// notificationRequest builds the request body that would be sent for a notification
+func notificationRequest(n notificationMessage, templateID, version, category string, attachments []string, enrich bool) interface{} {
+	if n.Channel == "email" {
+		return digit.SendEmailRequest{
+			TemplateID:  templateID,
+			Version:     version,
+			EmailIDs:    n.Recipients,
+			Enrich:      enrich,
+			Payload:     n.Payload,
+			Attachments: attachments,
+		}
+	}
+	return digit.SendSMSRequest{
+		TemplateID:    templateID,
+		Version:       version,
+		MobileNumbers: n.Recipients,
+		Enrich:        enrich,
+		Payload:       n.Payload,
+		Category:      category,
+	}
+}
+
+func init() {
+	notifyCmd.AddCommand(notifySendCmd)
+
+	// Add flags
+	notifySendCmd.Flags().String("template-id", "", "Template ID of the notification template (required)")
+	notifySendCmd.Flags().String("version", "", "Version of the notification template (required)")
+	notifySendCmd.Flags().String("channel", "", "Notification channel: email or sms (default: inferred from recipients)")
+	notifySendCmd.Flags().StringSlice("to", []string{}, "Recipient email addresses or mobile numbers (comma-separated or repeated)")
+	notifySendCmd.Flags().String("payload", "", "Path to JSON file with template payload")
+	notifySendCmd.Flags().String("csv", "", "Path to CSV file with a \"to\" column and per-recipient payload columns")
+	notifySendCmd.Flags().String("category", digit.SMSCategoryNotification, "SMS category (OTP, TRANSACTION, PROMOTION, NOTIFICATION, OTHERS)")
+	notifySendCmd.Flags().StringSlice("attachment", []string{}, "Filestore ID to attach to emails (comma-separated or repeated)")
+	notifySendCmd.Flags().Bool("enrich", false, "Ask the notification service to enrich the payload")
+	notifySendCmd.Flags().Bool("dry-run", false, "Print the requests without sending them")
+	notifySendCmd.Flags().String("server", "", "Server URL (overrides config)")
+	notifySendCmd.Flags().String("jwt-token", "", "JWT token for authentication (overrides config)")
+
+	// Mark required flags
+	notifySendCmd.MarkFlagRequired("template-id")
+	notifySendCmd.MarkFlagRequired("version")
+}
@@ -0,0 +1,274 @@
+package cmd
+
+import (
+	"fmt"
+
+	"digit-cli/pkg/rbac"
+	"github.com/digitnxt/digit3/code/libraries/digit-library/digit"
+	"github.com/spf13/cobra"
+)
+
+// rbacCmd represents the rbac command
+var rbacCmd = &cobra.Command{
+	Use:   "rbac",
+	Short: "Manage roles and role assignments as code",
+	Long: `Declare the roles of an account (realm), the roles composite roles include, and
+the roles of users and groups in a YAML file kept in git, and reconcile the
+realm with it:
+
+  roles:
+    - name: GRO
+      description: Grievance routing officer
+    - name: SUPERVISOR
+      composites: [GRO, CSR]
+  client-roles:
+    dashboard:
+      - name: reports-viewer
+  users:
+    jdoe: [SUPERVISOR, dashboard/reports-viewer]
+  groups:
+    /departments/pgr: [GRO]
+
+Realm roles are written by name and client roles as <clientId>/<role>. The
+file manages the roles it declares, includes or assigns; assignments of other
+roles, such as the realm's default roles, are left alone. Roles are never
+deleted.`,
+}
+
+// rbacState is the live state of the roles an RBAC file manages, with the IDs needed to change it
+type rbacState struct {
+	resolver *roleResolver
+	live     *rbac.Live
+	userIDs  map[string]string
+	groupIDs map[string]string
+	roles    map[string]*digit.Role
+}
+
+// fetchRBACState fetches the managed roles of an RBAC file and the users and groups holding them
+func fetchRBACState(resolver *roleResolver, file *rbac.File) (*rbacState, error) {
+	st := &rbacState{
+		resolver: resolver,
+		live:     &rbac.Live{Roles: map[string]rbac.LiveRole{}, Users: map[string]rbac.Holder{}, Groups: map[string]rbac.Holder{}},
+		userIDs:  map[string]string{},
+		groupIDs: map[string]string{},
+		roles:    map[string]*digit.Role{},
+	}
+	holder := func(holders map[string]rbac.Holder, name string) rbac.Holder {
+		h, ok := holders[name]
+		if !ok {
+			h = rbac.Holder{Exists: true, Roles: map[string]bool{}}
+			holders[name] = h
+		}
+		return h
+	}
+
+	for _, ref := range file.ManagedRoles() {
+		clientID, name := splitRoleRef(ref)
+		clientUUID, err := resolver.clientUUID(clientID)
+		if err != nil {
+			return nil, err
+		}
+		role, err := resolver.role(clientID, name)
+		if err != nil {
+			return nil, err
+		}
+		if role == nil {
+			continue
+		}
+		st.roles[ref] = role
+		liveRole := rbac.LiveRole{Description: role.Description}
+		if role.Composite {
+			if liveRole.Composites, err = roleComposites(resolver, clientUUID, name); err != nil {
+				return nil, err
+			}
+		}
+		st.live.Roles[ref] = liveRole
+
+		// The users and groups the role is assigned to directly
+		query := digit.UserQuery{Role: name, Client: clientUUID}
+		err = digit.EachUser(resolver.serverURL, resolver.jwtToken, resolver.realm, query, func(user digit.User) error {
+			holder(st.live.Users, user.Username).Roles[ref] = true
+			st.userIDs[user.Username] = user.ID
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch the users of role %s: %w", ref, err)
+		}
+		for first := 0; ; {
+			responseBody, err := digit.GetRoleGroups(resolver.serverURL, resolver.jwtToken, resolver.realm, clientUUID, name, first, 100)
+			if err != nil {
+				return nil, fmt.Errorf("failed to fetch the groups of role %s: %w", ref, err)
+			}
+			groups, err := digit.ParseGroups(responseBody)
+			if err != nil {
+				return nil, err
+			}
+			for _, group := range groups {
+				holder(st.live.Groups, group.Path).Roles[ref] = true
+				st.groupIDs[group.Path] = group.ID
+			}
+			if len(groups) < 100 {
+				break
+			}
+			first += len(groups)
+		}
+	}
+
+	// Declared users and groups that hold none of the managed roles yet
+	for username := range file.Users {
+		if _, ok := st.live.Users[username]; ok {
+			continue
+		}
+		user, err := findUser(resolver.serverURL, resolver.jwtToken, resolver.realm, username)
+		if err != nil {
+			return nil, err
+		}
+		if user == nil {
+			st.live.Users[username] = rbac.Holder{}
+			continue
+		}
+		holder(st.live.Users, username)
+		st.userIDs[username] = user.ID
+	}
+	for path := range file.Groups {
+		if _, ok := st.live.Groups[path]; ok {
+			continue
+		}
+		group, err := findGroup(resolver.serverURL, resolver.jwtToken, resolver.realm, path)
+		if err != nil {
+			return nil, err
+		}
+		if group == nil {
+			st.live.Groups[path] = rbac.Holder{}
+			continue
+		}
+		holder(st.live.Groups, path)
+		st.groupIDs[path] = group.ID
+	}
+	return st, nil
+}
+
+// role fetches a role by reference, caching it
+func (st *rbacState) role(ref string) (*digit.Role, error) {
+	if role, ok := st.roles[ref]; ok {
+		return role, nil
+	}
+	role, err := st.resolver.mustRole(splitRoleRef(ref))
+	if err != nil {
+		return nil, err
+	}
+	st.roles[ref] = role
+	return role, nil
+}
+
+// apply makes a change of an RBAC plan in the realm
+func (st *rbacState) apply(change rbac.Change) error {
+	r := st.resolver
+	if change.Action == rbac.ActionProblem {
+		return fmt.Errorf("%s %s %s", change.Kind, change.Subject, change.Problem)
+	}
+
+	if change.Kind == rbac.KindRole {
+		clientID, name := splitRoleRef(change.Subject)
+		clientUUID, err := r.clientUUID(clientID)
+		if err != nil {
+			return err
+		}
+		if change.Action == rbac.ActionAdd {
+			if clientUUID == "" {
+				_, err = digit.CreateRole(r.serverURL, r.jwtToken, r.realm, name, change.Description)
+			} else {
+				_, err = digit.CreateClientRole(r.serverURL, r.jwtToken, r.realm, clientUUID, digit.Role{Name: name, Description: change.Description})
+			}
+			return err
+		}
+		role, err := st.role(change.Subject)
+		if err != nil {
+			return err
+		}
+		role.Description = change.Description
+		_, err = digit.UpdateRole(r.serverURL, r.jwtToken, r.realm, clientUUID, name, *role)
+		return err
+	}
+
+	role, err := st.role(change.Role)
+	if err != nil {
+		return err
+	}
+	roleClientID, _ := splitRoleRef(change.Role)
+	roleClientUUID, err := r.clientUUID(roleClientID)
+	if err != nil {
+		return err
+	}
+	add := change.Action == rbac.ActionAdd
+	roles := []digit.Role{*role}
+
+	switch change.Kind {
+	case rbac.KindComposite:
+		clientID, name := splitRoleRef(change.Subject)
+		clientUUID, err := r.clientUUID(clientID)
+		if err != nil {
+			return err
+		}
+		if add {
+			_, err = digit.AddRoleComposites(r.serverURL, r.jwtToken, r.realm, clientUUID, name, roles)
+		} else {
+			_, err = digit.RemoveRoleComposites(r.serverURL, r.jwtToken, r.realm, clientUUID, name, roles)
+		}
+		return err
+	case rbac.KindUser:
+		userID := st.userIDs[change.Subject]
+		switch {
+		case add && roleClientUUID == "":
+			_, err = digit.AddUserRealmRoles(r.serverURL, r.jwtToken, r.realm, userID, roles)
+		case add:
+			_, err = digit.AddUserClientRoles(r.serverURL, r.jwtToken, r.realm, userID, roleClientUUID, roles)
+		case roleClientUUID == "":
+			_, err = digit.RemoveUserRealmRoles(r.serverURL, r.jwtToken, r.realm, userID, roles)
+		default:
+			_, err = digit.RemoveUserClientRoles(r.serverURL, r.jwtToken, r.realm, userID, roleClientUUID, roles)
+		}
+		return err
+	case rbac.KindGroup:
+		groupID := st.groupIDs[change.Subject]
+		switch {
+		case add && roleClientUUID == "":
+			_, err = digit.AddGroupRealmRoles(r.serverURL, r.jwtToken, r.realm, groupID, roles)
+		case add:
+			_, err = digit.AddGroupClientRoles(r.serverURL, r.jwtToken, r.realm, groupID, roleClientUUID, roles)
+		case roleClientUUID == "":
+			_, err = digit.RemoveGroupRealmRoles(r.serverURL, r.jwtToken, r.realm, groupID, roles)
+		default:
+			_, err = digit.RemoveGroupClientRoles(r.serverURL, r.jwtToken, r.realm, groupID, roleClientUUID, roles)
+		}
+		return err
+	}
+	return fmt.Errorf("unknown change %s", change)
+}
+
+// loadRBACPlan loads an RBAC file and plans the changes that make the realm match it
+func loadRBACPlan(cmd *cobra.Command) (*rbacState, []rbac.Change, error) {
+	// Get flag values
+	filePath, _ := cmd.Flags().GetString("file")
+	realm, _ := cmd.Flags().GetString("account")
+	serverURL, _ := cmd.Flags().GetString("server")
+	jwtToken, _ := cmd.Flags().GetString("jwt-token")
+
+	file, err := rbac.Load(filePath)
+	if err != nil {
+		return nil, nil, err
+	}
+	serverURL, jwtToken, realm, err = realmSettings(serverURL, jwtToken, realm)
+	if err != nil {
+		return nil, nil, err
+	}
+	st, err := fetchRBACState(newRoleResolver(serverURL, jwtToken, realm), file)
+	if err != nil {
+		return nil, nil, err
+	}
+	return st, rbac.Plan(file, st.live), nil
+}
+
+func init() {
+	rootCmd.AddCommand(rbacCmd)
+}
@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"fmt"
+
+	"digit-cli/pkg/rbac"
+	"github.com/spf13/cobra"
+)
+
+// rbacApplyCmd represents the rbac apply command
+var rbacApplyCmd = &cobra.Command{
+	Use:   "apply",
+	Short: "Make the roles and assignments of an account match an RBAC file",
+	Long: `Create the declared roles, update their descriptions, add the roles composite
+roles include, and assign the declared roles to users and groups.
+
+Composite roles, users and groups holding managed roles the file does not
+declare for them are reported and kept, unless --prune removes them. Users and
+groups must already exist; create them with 'digit user import' or
+'digit create-user'.
+
+Examples:
+  digit rbac apply -f rbac.yaml --dry-run
+  digit rbac apply -f rbac.yaml
+  digit rbac apply -f rbac.yaml --prune`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Get flag values
+		prune, _ := cmd.Flags().GetBool("prune")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+		st, changes, err := loadRBACPlan(cmd)
+		if err != nil {
+			return err
+		}
+
+		applied, kept, failed := 0, 0, 0
+		for _, change := range changes {
+			switch {
+			case change.Prune() && !prune:
+				kept++
+				fmt.Printf("%s (kept; use --prune to remove)\n", change)
+			case change.Action == rbac.ActionProblem:
+				failed++
+				fmt.Println(change)
+			case dryRun:
+				applied++
+				fmt.Println(change)
+			default:
+				if err := st.apply(change); err != nil {
+					failed++
+					fmt.Printf("✗ %s: %v\n", change, err)
+					continue
+				}
+				applied++
+				fmt.Printf("✓ %s\n", change)
+			}
+		}
+
+		if len(changes) == 0 {
+			filePath, _ := cmd.Flags().GetString("file")
+			fmt.Printf("Account %s already matches %s\n", st.resolver.realm, filePath)
+			return nil
+		}
+		summary := fmt.Sprintf("%d applied, %d kept, %d failed", applied, kept, failed)
+		if dryRun {
+			summary = fmt.Sprintf("Dry run: %d to apply, %d kept, %d failed", applied, kept, failed)
+		}
+		fmt.Printf("\n%s\n", summary)
+		if failed > 0 {
+			return fmt.Errorf("%d of %d changes could not be applied", failed, len(changes)-kept)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rbacCmd.AddCommand(rbacApplyCmd)
+
+	// Add flags
+	rbacApplyCmd.Flags().StringP("file", "f", "", "RBAC file (required)")
+	rbacApplyCmd.Flags().Bool("prune", false, "Remove composite roles and assignments of managed roles that the file does not declare")
+	rbacApplyCmd.Flags().Bool("dry-run", false, "Show the changes without making them")
+	rbacApplyCmd.Flags().String("account", "", "Account (realm) (overrides config)")
+	rbacApplyCmd.Flags().String("server", "", "Server URL (overrides config)")
+	rbacApplyCmd.Flags().String("jwt-token", "", "JWT token for authentication (overrides config)")
+
+	// Mark required flags
+	rbacApplyCmd.MarkFlagRequired("file")
+}
@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"fmt"
+
+	"digit-cli/pkg/rbac"
+	"github.com/spf13/cobra"
+)
+
+// rbacDiffCmd represents the rbac diff command
+var rbacDiffCmd = &cobra.Command{
+	Use:   "diff",
+	Short: "Show the drift between an RBAC file and an account",
+	Long: `Show the differences between an RBAC file and the live account (realm): roles
+to create or update, and roles included or assigned that the file adds (+)
+or does not declare (-). Declared users, groups and roles that cannot be
+found are marked with !.
+
+The command fails when the account differs from the file, so that drift
+can be detected in CI.
+
+Examples:
+  digit rbac diff -f rbac.yaml`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		filePath, _ := cmd.Flags().GetString("file")
+
+		st, changes, err := loadRBACPlan(cmd)
+		if err != nil {
+			return err
+		}
+		if len(changes) == 0 {
+			fmt.Printf("Account %s matches %s\n", st.resolver.realm, filePath)
+			return nil
+		}
+		for _, change := range changes {
+			fmt.Println(change)
+		}
+		fmt.Printf("\n%s\n", rbac.Summary(changes))
+		return fmt.Errorf("account %s differs from %s", st.resolver.realm, filePath)
+	},
+}
+
+func init() {
+	rbacCmd.AddCommand(rbacDiffCmd)
+
+	// Add flags
+	rbacDiffCmd.Flags().StringP("file", "f", "", "RBAC file (required)")
+	rbacDiffCmd.Flags().String("account", "", "Account (realm) (overrides config)")
+	rbacDiffCmd.Flags().String("server", "", "Server URL (overrides config)")
+	rbacDiffCmd.Flags().String("jwt-token", "", "JWT token for authentication (overrides config)")
+
+	// Mark required flags
+	rbacDiffCmd.MarkFlagRequired("file")
+}
@@ -0,0 +1,16 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// registryCmd represents the registry command
+var registryCmd = &cobra.Command{
+	Use:   "registry",
+	Short: "Work with registry schemas and data",
+	Long:  `Validate and maintain registry schema definitions and the data stored against them.`,
+}
+
+func init() {
+	rootCmd.AddCommand(registryCmd)
+}
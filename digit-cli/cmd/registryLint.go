@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"digit-cli/pkg/registry"
+	"github.com/spf13/cobra"
+)
+
+// registryLintCmd represents the registry lint command
+var registryLintCmd = &cobra.Command{
+	Use:   "lint",
+	Short: "Lint a registry schema definition",
+	Long: `Lint a registry schema definition before it is sent to the registry service.
+
+The linter checks that:
+  - the definition is a valid JSON Schema draft 2020-12 document
+  - every x-indexes fieldPath exists in properties
+  - each index method suits the type of the indexed field
+  - index names are unique
+  - every required field exists in properties
+
+Findings are reported with their line and column in the YAML file. The command
+fails when any error is found (or any warning, with --strict).
+
+Examples:
+  digit registry lint -f registry-schema.yaml
+  digit registry lint -f registry-schema.yaml --strict
+  digit registry lint --default`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Get flag values
+		filePath, _ := cmd.Flags().GetString("file")
+		useDefault, _ := cmd.Flags().GetBool("default")
+		strict, _ := cmd.Flags().GetBool("strict")
+
+		// Validate flags - either file or default must be specified
+		if !useDefault && filePath == "" {
+			return fmt.Errorf("either --file or --default flag is required")
+		}
+		if useDefault && filePath != "" {
+			return fmt.Errorf("cannot use both --file and --default flags together")
+		}
+
+		// Get YAML data - either from file or default configuration
+		var yamlData []byte
+		source := filePath
+		if useDefault {
+			yamlData = []byte(defaultRegistrySchemaYAML)
+			source = "<default>"
+		} else {
+			var err error
+			yamlData, err = os.ReadFile(filePath)
+			if err != nil {
+				return fmt.Errorf("failed to read YAML file: %w", err)
+			}
+		}
+
+		findings, err := registry.LintSchema(yamlData)
+		if err != nil {
+			return fmt.Errorf("failed to lint registry schema: %w", err)
+		}
+
+		errorCount, warningCount := 0, 0
+		for _, finding := range findings {
+			fmt.Printf("%s:%s\n", source, finding)
+			if finding.Severity == registry.SeverityError {
+				errorCount++
+			} else {
+				warningCount++
+			}
+		}
+
+		if len(findings) == 0 {
+			fmt.Printf("✓ %s: no problems found\n", source)
+			return nil
+		}
+
+		fmt.Printf("\n%d error(s), %d warning(s)\n", errorCount, warningCount)
+		if errorCount > 0 || (strict && warningCount > 0) {
+			return fmt.Errorf("registry schema lint failed")
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	registryCmd.AddCommand(registryLintCmd)
+
+	registryLintCmd.Flags().StringP("file", "f", "", "Path to YAML file containing the registry schema")
+	registryLintCmd.Flags().Bool("default", false, "Lint the embedded default registry schema")
+	registryLintCmd.Flags().Bool("strict", false, "Treat warnings as errors")
+}
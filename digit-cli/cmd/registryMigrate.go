@@ -0,0 +1,332 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"digit-cli/pkg/config"
+	"digit-cli/pkg/jwt"
+	"digit-cli/pkg/registry"
+	"github.com/digitnxt/digit3/code/libraries/digit-library/digit"
+	"github.com/spf13/cobra"
+)
+
+// migrationResult records the outcome of migrating a single registry record
+type migrationResult struct {
+	RegistryID string   `json:"registryId"`
+	Status     string   `json:"status"`
+	Changes    []string `json:"changes,omitempty"`
+	Errors     []string `json:"errors,omitempty"`
+}
+
+// registryMigrateCmd represents the registry migrate command
+var registryMigrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Register a new schema version and migrate existing records",
+	Long: `Register a new version of a registry schema and bring existing records along.
+
+The transform file holds the new schema definition and the declarative
+transforms applied to every record:
+
+  schemaCode: "license-registry"
+  definition:
+    $schema: "https://json-schema.org/draft/2020-12/schema"
+    type: "object"
+    properties: ...
+  transforms:
+    rename:
+      holderName: holder.name
+    convert:
+      licenseNumber: string
+    defaults:
+      status: ACTIVE
+    remove:
+      - legacyCode
+
+Transforms run in the order rename, convert, defaults, remove. Supported
+conversions are string, integer, number and boolean. The new version must be
+the next version of the schema.
+
+Every record is transformed and validated against the new definition before
+anything is changed. While any record fails, neither the schema nor any
+record is updated; the failing records are reported so the transforms can be
+fixed first.
+
+Examples:
+  # Preview the migration without changing anything
+  digit registry migrate --schema license-registry --to-version 2 --transform transform.yaml --dry-run
+
+  # Run the migration and save a per-record report
+  digit registry migrate --schema license-registry --to-version 2 --transform transform.yaml --report report.json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Get flag values
+		schemaCode, _ := cmd.Flags().GetString("schema")
+		toVersion, _ := cmd.Flags().GetInt("to-version")
+		transformPath, _ := cmd.Flags().GetString("transform")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		reportPath, _ := cmd.Flags().GetString("report")
+		serverURL, _ := cmd.Flags().GetString("server")
+		jwtToken, _ := cmd.Flags().GetString("jwt-token")
+
+		if toVersion < 1 {
+			return fmt.Errorf("--to-version must be a positive number")
+		}
+
+		yamlData, err := os.ReadFile(transformPath)
+		if err != nil {
+			return fmt.Errorf("failed to read transform file: %w", err)
+		}
+
+		// Refuse to register a definition the linter rejects
+		findings, err := registry.LintSchema(yamlData)
+		if err != nil {
+			return fmt.Errorf("failed to lint transform file: %w", err)
+		}
+		if registry.HasErrors(findings) {
+			for _, finding := range findings {
+				fmt.Printf("%s:%s\n", transformPath, finding)
+			}
+			return fmt.Errorf("new schema definition has lint errors")
+		}
+
+		spec, err := registry.LoadMigrationSpec(yamlData)
+		if err != nil {
+			return err
+		}
+		if spec.SchemaCode != schemaCode {
+			return fmt.Errorf("transform file is for schema %q, not %q", spec.SchemaCode, schemaCode)
+		}
+
+		// Get server URL and JWT token from config if not provided
+		if serverURL == "" || jwtToken == "" {
+			cfg, err := config.Load()
+			if err != nil {
+				return fmt.Errorf("failed to load config: %w", err)
+			}
+
+			if serverURL == "" {
+				serverURL = cfg.GetServer()
+				if serverURL == "" {
+					serverURL = "http://localhost:8085" // Default server URL for registry
+				}
+			}
+
+			if jwtToken == "" {
+				jwtToken = cfg.GetJWTToken()
+			}
+		}
+
+		// Extract tenant ID and client ID from JWT token
+		if jwtToken == "" {
+			return fmt.Errorf("JWT token is required")
+		}
+
+		tenantID, err := jwt.ExtractTenantID(jwtToken)
+		if err != nil {
+			return fmt.Errorf("failed to extract tenant ID from JWT token: %w", err)
+		}
+
+		clientID, err := jwt.ExtractClientID(jwtToken)
+		if err != nil {
+			return fmt.Errorf("failed to extract client ID from JWT token: %w", err)
+		}
+
+		// Versions are registered one at a time, so records only ever move up by one version
+		currentSchema, err := digit.SearchRegistrySchema(serverURL, jwtToken, tenantID, clientID, schemaCode, "")
+		if err != nil {
+			return fmt.Errorf("failed to fetch current registry schema: %w", err)
+		}
+		current, ok := registry.SchemaVersion(currentSchema)
+		if !ok {
+			return fmt.Errorf("could not determine the current version of schema %s", schemaCode)
+		}
+		if current >= toVersion {
+			return fmt.Errorf("schema %s is already at version %d", schemaCode, current)
+		}
+		if toVersion != current+1 {
+			return fmt.Errorf("schema %s is at version %d; migrate to version %d first", schemaCode, current, current+1)
+		}
+		fmt.Printf("Migrating schema %s from version %d to %d\n", schemaCode, current, toVersion)
+
+		// A registered schema version cannot be rolled back, so every record is transformed
+		// and validated before the schema or any record is changed
+		var records []registry.Record
+		var results []migrationResult
+		counts := map[string]int{}
+		total, err := eachRegistryRecord(serverURL, jwtToken, tenantID, clientID, schemaCode, func(record registry.Record) error {
+			result := migrateRecord(spec, record, nil)
+			counts[result.Status]++
+			records = append(records, record)
+			results = append(results, result)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Found %d record(s)\n", total)
+
+		blocked := counts["invalid"] + counts["failed"]
+		switch {
+		case blocked > 0:
+			fmt.Println("The schema and records will not be changed while records cannot be migrated")
+		case dryRun:
+			fmt.Println("Dry run: the schema and records will not be changed")
+		default:
+			if _, err := digit.UpdateRegistrySchema(serverURL, jwtToken, tenantID, clientID, schemaCode, spec.Definition); err != nil {
+				return fmt.Errorf("failed to register new schema version: %w", err)
+			}
+			if _, err := digit.SearchRegistrySchema(serverURL, jwtToken, tenantID, clientID, schemaCode, strconv.Itoa(toVersion)); err != nil {
+				return fmt.Errorf("schema was updated but version %d could not be found: %w", toVersion, err)
+			}
+			fmt.Printf("✓ Registered schema %s version %d\n", schemaCode, toVersion)
+
+			// Rewrite the records read before, rather than paging through the list again while
+			// it changes under the updates, which could skip records
+			results = results[:0]
+			counts = map[string]int{}
+			update := func(record registry.Record, migrated map[string]interface{}) error {
+				_, err := digit.UpdateRegistryData(serverURL, jwtToken, tenantID, clientID, record.ID, schemaCode, migrated)
+				return err
+			}
+			for _, record := range records {
+				result := migrateRecord(spec, record, update)
+				counts[result.Status]++
+				results = append(results, result)
+			}
+		}
+
+		fmt.Println()
+		for _, result := range results {
+			printMigrationResult(result)
+		}
+
+		fmt.Printf("\nSummary: ")
+		var parts []string
+		for _, status := range []string{"migrated", "would-migrate", "unchanged", "invalid", "failed"} {
+			if counts[status] > 0 {
+				parts = append(parts, fmt.Sprintf("%d %s", counts[status], status))
+			}
+		}
+		if len(parts) == 0 {
+			parts = append(parts, "no records")
+		}
+		fmt.Println(strings.Join(parts, ", "))
+
+		if reportPath != "" {
+			reportJSON, err := json.MarshalIndent(results, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal report: %w", err)
+			}
+			if err := os.WriteFile(reportPath, reportJSON, 0644); err != nil {
+				return fmt.Errorf("failed to write report: %w", err)
+			}
+			fmt.Printf("Report written to %s\n", reportPath)
+		}
+
+		if counts["invalid"] > 0 || counts["failed"] > 0 {
+			return fmt.Errorf("%d record(s) could not be migrated", counts["invalid"]+counts["failed"])
+		}
+		return nil
+	},
+}
+
+// registryPageSize is the number of records fetched per request
+const registryPageSize = 100
+
+// eachRegistryRecord calls fn once for every record of a schema, fetching the records a page at
+// a time, and returns the number of records
+func eachRegistryRecord(serverURL, jwtToken, tenantID, clientID, schemaCode string, fn func(registry.Record) error) (int, error) {
+	total := 0
+	seen := map[string]bool{}
+	for offset := 0; ; offset += registryPageSize {
+		responseBody, err := digit.SearchRegistryDataPage(serverURL, jwtToken, tenantID, clientID, schemaCode, offset, registryPageSize)
+		if err != nil {
+			return total, fmt.Errorf("failed to fetch registry data: %w", err)
+		}
+		records, err := registry.ParseRecords(responseBody)
+		if err != nil {
+			return total, err
+		}
+		fresh := 0
+		for _, record := range records {
+			if seen[record.ID] {
+				continue
+			}
+			seen[record.ID] = true
+			fresh++
+			if err := fn(record); err != nil {
+				return total, err
+			}
+			total++
+		}
+		// A page of records seen before comes from a server that ignores the offset
+		if fresh == 0 {
+			return total, nil
+		}
+		// A short page is the last; a server that ignores the limit returns all records at once
+		if len(records) != registryPageSize {
+			return total, nil
+		}
+	}
+}
+
+// migrateRecord transforms a record and validates it against the new definition. The record
+// is rewritten with update when that is given, else the result says whether it would be.
+func migrateRecord(spec *registry.MigrationSpec, record registry.Record, update func(registry.Record, map[string]interface{}) error) migrationResult {
+	result := migrationResult{RegistryID: record.ID}
+	migrated, changes, err := spec.Transforms.Apply(record.Data)
+	result.Changes = changes
+	if err != nil {
+		result.Status = "failed"
+		result.Errors = []string{err.Error()}
+	} else if violations := registry.ValidateData(spec.Definition, migrated); len(violations) > 0 {
+		result.Status = "invalid"
+		result.Errors = violations
+	} else if len(changes) == 0 {
+		result.Status = "unchanged"
+	} else if update == nil {
+		result.Status = "would-migrate"
+	} else if err := update(record, migrated); err != nil {
+		result.Status = "failed"
+		result.Errors = []string{err.Error()}
+	} else {
+		result.Status = "migrated"
+	}
+	return result
+}
+
+// printMigrationResult prints one line per record followed by its changes and errors
+func printMigrationResult(result migrationResult) {
+	symbol := "✓"
+	if result.Status == "invalid" || result.Status == "failed" {
+		symbol = "✗"
+	}
+	fmt.Printf("%s %s: %s\n", symbol, result.RegistryID, result.Status)
+	for _, change := range result.Changes {
+		fmt.Printf("    %s\n", change)
+	}
+	for _, e := range result.Errors {
+		fmt.Printf("    error: %s\n", e)
+	}
+}
+
+func init() {
+	registryCmd.AddCommand(registryMigrateCmd)
+
+	// Add flags
+	registryMigrateCmd.Flags().String("schema", "", "Schema code to migrate (required)")
+	registryMigrateCmd.Flags().Int("to-version", 0, "Schema version to migrate to (required)")
+	registryMigrateCmd.Flags().String("transform", "", "YAML file with the new definition and record transforms (required)")
+	registryMigrateCmd.Flags().Bool("dry-run", false, "Show the changes without updating the schema or records")
+	registryMigrateCmd.Flags().String("report", "", "Write a per-record JSON report to this file")
+	registryMigrateCmd.Flags().String("server", "", "Server URL (overrides config)")
+	registryMigrateCmd.Flags().String("jwt-token", "", "JWT token for authentication (overrides config)")
+
+	// Mark required flags
+	registryMigrateCmd.MarkFlagRequired("schema")
+	registryMigrateCmd.MarkFlagRequired("to-version")
+	registryMigrateCmd.MarkFlagRequired("transform")
+}
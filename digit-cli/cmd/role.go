@@ -0,0 +1,168 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/digitnxt/digit3/code/libraries/digit-library/digit"
+	"github.com/spf13/cobra"
+)
+
+// roleCmd represents the role command
+var roleCmd = &cobra.Command{
+	Use:   "role",
+	Short: "Manage Keycloak realm and client roles",
+	Long: `List, create, update and delete the realm and client roles of an account
+(realm), build composite roles, assign and unassign roles, and review who
+holds a role.
+
+Roles are realm roles unless --client names a client (by its clientId, e.g.
+auth-server). Where realm and client roles can be mixed, client roles are
+written as <clientId>/<role>. The account defaults to that of the stored
+login ('digit config set').`,
+}
+
+// roleResolver fetches roles by name, caching the clients they belong to
+type roleResolver struct {
+	serverURL string
+	jwtToken  string
+	realm     string
+	clients   map[string]*digit.Client
+}
+
+// newRoleResolver returns a role resolver for a realm
+func newRoleResolver(serverURL, jwtToken, realm string) *roleResolver {
+	return &roleResolver{serverURL: serverURL, jwtToken: jwtToken, realm: realm, clients: map[string]*digit.Client{}}
+}
+
+// client fetches a client by its clientId
+func (r *roleResolver) client(clientID string) (*digit.Client, error) {
+	if client, ok := r.clients[clientID]; ok {
+		return client, nil
+	}
+	responseBody, err := digit.GetClients(r.serverURL, r.jwtToken, r.realm, clientID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch client %s: %w", clientID, err)
+	}
+	clients, err := digit.ParseClients(responseBody)
+	if err != nil {
+		return nil, err
+	}
+	for i := range clients {
+		if clients[i].ClientID == clientID {
+			r.clients[clientID] = &clients[i]
+			return &clients[i], nil
+		}
+	}
+	return nil, fmt.Errorf("client %s does not exist in account %s", clientID, r.realm)
+}
+
+// clientUUID returns the ID of a client by its clientId, or "" for realm roles
+func (r *roleResolver) clientUUID(clientID string) (string, error) {
+	if clientID == "" {
+		return "", nil
+	}
+	client, err := r.client(clientID)
+	if err != nil {
+		return "", err
+	}
+	return client.ID, nil
+}
+
+// role fetches a role of the client with the given clientId, or a realm role when clientID is
+// empty; a role that does not exist is nil without an error
+func (r *roleResolver) role(clientID, name string) (*digit.Role, error) {
+	clientUUID, err := r.clientUUID(clientID)
+	if err != nil {
+		return nil, err
+	}
+	var responseBody string
+	if clientUUID == "" {
+		responseBody, err = digit.GetRole(r.serverURL, r.jwtToken, r.realm, name)
+	} else {
+		responseBody, err = digit.GetClientRole(r.serverURL, r.jwtToken, r.realm, clientUUID, name)
+	}
+	if err != nil {
+		var keycloakErr *digit.KeycloakError
+		if errors.As(err, &keycloakErr) && keycloakErr.StatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to fetch role %s: %w", roleRef(clientID, name), err)
+	}
+	return digit.ParseRole(responseBody)
+}
+
+// mustRole fetches a role like role, but a role that does not exist is an error
+func (r *roleResolver) mustRole(clientID, name string) (*digit.Role, error) {
+	role, err := r.role(clientID, name)
+	if err == nil && role == nil {
+		err = fmt.Errorf("role %s does not exist in account %s", roleRef(clientID, name), r.realm)
+	}
+	return role, err
+}
+
+// splitRoleRef splits a role written as <role> or <clientId>/<role>
+func splitRoleRef(ref string) (clientID, name string) {
+	if i := strings.LastIndex(ref, "/"); i > 0 {
+		return ref[:i], ref[i+1:]
+	}
+	return "", ref
+}
+
+// roleRef writes a role as <role> for realm roles or <clientId>/<role> for client roles
+func roleRef(clientID, name string) string {
+	if clientID == "" {
+		return name
+	}
+	return clientID + "/" + name
+}
+
+// roleClientID returns the clientId of a role returned by Keycloak, found among the cached clients
+func (r *roleResolver) roleClientID(role digit.Role) (string, error) {
+	if !role.ClientRole {
+		return "", nil
+	}
+	for clientID, client := range r.clients {
+		if client.ID == role.ContainerID {
+			return clientID, nil
+		}
+	}
+	responseBody, err := digit.GetClients(r.serverURL, r.jwtToken, r.realm, "")
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch clients: %w", err)
+	}
+	clients, err := digit.ParseClients(responseBody)
+	if err != nil {
+		return "", err
+	}
+	for i := range clients {
+		r.clients[clients[i].ClientID] = &clients[i]
+		if clients[i].ID == role.ContainerID {
+			return clients[i].ClientID, nil
+		}
+	}
+	return role.ContainerID, nil
+}
+
+// fetchRoleList lists all the realm roles, or the roles of a client when clientUUID is set
+func fetchRoleList(serverURL, jwtToken, realm, clientUUID string) ([]digit.Role, error) {
+	responseBody, err := digit.ListRoles(serverURL, jwtToken, realm, clientUUID, digit.RoleQuery{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list roles: %w", err)
+	}
+	return digit.ParseRoles(responseBody)
+}
+
+// formatNames formats names as a comma-separated list, or - when there are none
+func formatNames(names []string) string {
+	if len(names) == 0 {
+		return "-"
+	}
+	return strings.Join(names, ", ")
+}
+
+func init() {
+	rootCmd.AddCommand(roleCmd)
+}
@@ -0,0 +1,212 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/digitnxt/digit3/code/libraries/digit-library/digit"
+	"github.com/spf13/cobra"
+)
+
+// roleAssignCmd represents the role assign command
+var roleAssignCmd = &cobra.Command{
+	Use:   "assign <role>...",
+	Short: "Assign realm and client roles to a user or group",
+	Long: `Assign one or more roles to a user, or to a group with --group; the members of
+the group and of its subgroups then hold them. Realm roles are given by name
+and client roles as <clientId>/<role>. Roles already assigned are left as
+they are.
+
+Examples:
+  digit role assign --username jdoe GRO CSR
+  digit role assign --username jdoe dashboard/reports-viewer
+  digit role assign --group /departments/pgr GRO`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runRoleChange(cmd, args, true)
+	},
+}
+
+// runRoleChange assigns the roles of the arguments to the user of the --username flag or the
+// group of the --group flag, or removes them
+func runRoleChange(cmd *cobra.Command, refs []string, assign bool) error {
+	// Get flag values
+	username, _ := cmd.Flags().GetString("username")
+	groupPath, _ := cmd.Flags().GetString("group")
+	realm, _ := cmd.Flags().GetString("account")
+	serverURL, _ := cmd.Flags().GetString("server")
+	jwtToken, _ := cmd.Flags().GetString("jwt-token")
+
+	if (username == "") == (groupPath == "") {
+		return fmt.Errorf("either --username or --group is required")
+	}
+	serverURL, jwtToken, realm, err := realmSettings(serverURL, jwtToken, realm)
+	if err != nil {
+		return err
+	}
+
+	// The user or group whose roles are changed
+	var holder string
+	var currentRoles func(clientUUID string) (map[string]bool, error)
+	var changeRoles func(clientUUID string, roles []digit.Role) error
+	if groupPath != "" {
+		group, err := mustGroup(serverURL, jwtToken, realm, cleanGroupPath(groupPath))
+		if err != nil {
+			return err
+		}
+		holder = group.Path
+		currentRoles = func(clientUUID string) (map[string]bool, error) {
+			return groupRoleNames(serverURL, jwtToken, realm, group.ID, clientUUID)
+		}
+		changeRoles = func(clientUUID string, roles []digit.Role) error {
+			var err error
+			switch {
+			case assign && clientUUID == "":
+				_, err = digit.AddGroupRealmRoles(serverURL, jwtToken, realm, group.ID, roles)
+			case assign:
+				_, err = digit.AddGroupClientRoles(serverURL, jwtToken, realm, group.ID, clientUUID, roles)
+			case clientUUID == "":
+				_, err = digit.RemoveGroupRealmRoles(serverURL, jwtToken, realm, group.ID, roles)
+			default:
+				_, err = digit.RemoveGroupClientRoles(serverURL, jwtToken, realm, group.ID, clientUUID, roles)
+			}
+			return err
+		}
+	} else {
+		user, err := mustUser(serverURL, jwtToken, realm, username)
+		if err != nil {
+			return err
+		}
+		holder = user.Username
+		currentRoles = func(clientUUID string) (map[string]bool, error) {
+			return userRoleNames(serverURL, jwtToken, realm, user.ID, clientUUID)
+		}
+		changeRoles = func(clientUUID string, roles []digit.Role) error {
+			var err error
+			switch {
+			case assign && clientUUID == "":
+				_, err = digit.AddUserRealmRoles(serverURL, jwtToken, realm, user.ID, roles)
+			case assign:
+				_, err = digit.AddUserClientRoles(serverURL, jwtToken, realm, user.ID, clientUUID, roles)
+			case clientUUID == "":
+				_, err = digit.RemoveUserRealmRoles(serverURL, jwtToken, realm, user.ID, roles)
+			default:
+				_, err = digit.RemoveUserClientRoles(serverURL, jwtToken, realm, user.ID, clientUUID, roles)
+			}
+			return err
+		}
+	}
+
+	// Realm roles and the roles of each client are changed with one request each
+	resolver := newRoleResolver(serverURL, jwtToken, realm)
+	var clientIDs []string
+	byClient := map[string][]string{}
+	for _, ref := range refs {
+		clientID, name := splitRoleRef(ref)
+		if _, ok := byClient[clientID]; !ok {
+			clientIDs = append(clientIDs, clientID)
+		}
+		if !containsString(byClient[clientID], name) {
+			byClient[clientID] = append(byClient[clientID], name)
+		}
+	}
+
+	failed := 0
+	for _, clientID := range clientIDs {
+		names := byClient[clientID]
+		fail := func(err error) {
+			failed += len(names)
+			for _, name := range names {
+				fmt.Printf("✗ %s: %v\n", roleRef(clientID, name), err)
+			}
+		}
+
+		clientUUID, err := resolver.clientUUID(clientID)
+		if err != nil {
+			fail(err)
+			continue
+		}
+		current, err := currentRoles(clientUUID)
+		if err != nil {
+			fail(err)
+			continue
+		}
+
+		var change []digit.Role
+		var changed []string
+		for _, name := range names {
+			ref := roleRef(clientID, name)
+			if assign == current[name] {
+				if assign {
+					fmt.Printf("= %s already has %s\n", holder, ref)
+				} else {
+					fmt.Printf("= %s does not have %s\n", holder, ref)
+				}
+				continue
+			}
+			role, err := resolver.mustRole(clientID, name)
+			if err != nil {
+				failed++
+				fmt.Printf("✗ %s: %v\n", ref, err)
+				continue
+			}
+			change = append(change, *role)
+			changed = append(changed, ref)
+		}
+		if len(change) == 0 {
+			continue
+		}
+
+		err = changeRoles(clientUUID, change)
+		for _, ref := range changed {
+			switch {
+			case err != nil:
+				failed++
+				fmt.Printf("✗ %s: %v\n", ref, err)
+			case assign:
+				fmt.Printf("✓ Assigned %s to %s\n", ref, holder)
+			default:
+				fmt.Printf("✓ Removed %s from %s\n", ref, holder)
+			}
+		}
+	}
+
+	if failed > 0 {
+		if assign {
+			return fmt.Errorf("%d of %d roles could not be assigned", failed, len(refs))
+		}
+		return fmt.Errorf("%d of %d roles could not be removed", failed, len(refs))
+	}
+	return nil
+}
+
+// userRoleNames fetches the names of the realm roles mapped directly to a user, or of the
+// roles of a client when clientUUID is set
+func userRoleNames(serverURL, jwtToken, realm, userID, clientUUID string) (map[string]bool, error) {
+	if clientUUID == "" {
+		return userRealmRoleNames(serverURL, jwtToken, realm, userID)
+	}
+	responseBody, err := digit.GetUserClientRoles(serverURL, jwtToken, realm, userID, clientUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch roles: %w", err)
+	}
+	roles, err := digit.ParseRoles(responseBody)
+	if err != nil {
+		return nil, err
+	}
+	names := map[string]bool{}
+	for _, role := range roles {
+		names[role.Name] = true
+	}
+	return names, nil
+}
+
+func init() {
+	roleCmd.AddCommand(roleAssignCmd)
+
+	// Add flags
+	roleAssignCmd.Flags().String("username", "", "Username of the user to assign the roles to")
+	roleAssignCmd.Flags().String("group", "", "Path of the group to assign the roles to, instead of a user")
+	roleAssignCmd.Flags().String("account", "", "Account (realm) (overrides config)")
+	roleAssignCmd.Flags().String("server", "", "Server URL (overrides config)")
+	roleAssignCmd.Flags().String("jwt-token", "", "JWT token for authentication (overrides config)")
+}
@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/digitnxt/digit3/code/libraries/digit-library/digit"
+	"github.com/spf13/cobra"
+)
+
+// roleCompositeCmd represents the role composite command
+var roleCompositeCmd = &cobra.Command{
+	Use:   "composite",
+	Short: "Build composite roles from other roles",
+	Long: `List, add and remove the roles a composite role includes. Everyone holding a
+composite role also holds the roles it includes. Realm roles are given by
+name and client roles as <clientId>/<role>.`,
+}
+
+// runCompositeChange adds the roles of refs to the composite role of parentRef, or removes them
+func runCompositeChange(cmd *cobra.Command, parentRef string, refs []string, add bool) error {
+	// Get flag values
+	realm, _ := cmd.Flags().GetString("account")
+	serverURL, _ := cmd.Flags().GetString("server")
+	jwtToken, _ := cmd.Flags().GetString("jwt-token")
+
+	serverURL, jwtToken, realm, err := realmSettings(serverURL, jwtToken, realm)
+	if err != nil {
+		return err
+	}
+	resolver := newRoleResolver(serverURL, jwtToken, realm)
+	parentClientID, parentName := splitRoleRef(parentRef)
+	parentClientUUID, err := resolver.clientUUID(parentClientID)
+	if err != nil {
+		return err
+	}
+	if _, err := resolver.mustRole(parentClientID, parentName); err != nil {
+		return err
+	}
+	current, err := roleComposites(resolver, parentClientUUID, parentName)
+	if err != nil {
+		return err
+	}
+
+	failed := 0
+	var change []digit.Role
+	var changed []string
+	for _, ref := range refs {
+		clientID, name := splitRoleRef(ref)
+		ref = roleRef(clientID, name)
+		if containsString(changed, ref) {
+			continue
+		}
+		if ref == roleRef(parentClientID, parentName) {
+			failed++
+			fmt.Printf("✗ %s: a role cannot include itself\n", ref)
+			continue
+		}
+		if add == containsString(current, ref) {
+			if add {
+				fmt.Printf("= %s already includes %s\n", parentRef, ref)
+			} else {
+				fmt.Printf("= %s does not include %s\n", parentRef, ref)
+			}
+			continue
+		}
+		role, err := resolver.mustRole(clientID, name)
+		if err != nil {
+			failed++
+			fmt.Printf("✗ %s: %v\n", ref, err)
+			continue
+		}
+		change = append(change, *role)
+		changed = append(changed, ref)
+	}
+
+	if len(change) > 0 {
+		if add {
+			_, err = digit.AddRoleComposites(serverURL, jwtToken, realm, parentClientUUID, parentName, change)
+		} else {
+			_, err = digit.RemoveRoleComposites(serverURL, jwtToken, realm, parentClientUUID, parentName, change)
+		}
+		for _, ref := range changed {
+			switch {
+			case err != nil:
+				failed++
+				fmt.Printf("✗ %s: %v\n", ref, err)
+			case add:
+				fmt.Printf("✓ %s now includes %s\n", parentRef, ref)
+			default:
+				fmt.Printf("✓ %s no longer includes %s\n", parentRef, ref)
+			}
+		}
+	}
+
+	if failed > 0 {
+		if add {
+			return fmt.Errorf("%d of %d roles could not be added to %s", failed, len(refs), parentRef)
+		}
+		return fmt.Errorf("%d of %d roles could not be removed from %s", failed, len(refs), parentRef)
+	}
+	return nil
+}
+
+func init() {
+	roleCmd.AddCommand(roleCompositeCmd)
+}
@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// roleCompositeAddCmd represents the role composite add command
+var roleCompositeAddCmd = &cobra.Command{
+	Use:   "add <composite-role> <role>...",
+	Short: "Add roles to a composite role",
+	Long: `Add roles to a role, making it a composite role. Everyone holding the composite
+role gets the added roles too.
+
+Examples:
+  digit role composite add SUPERVISOR GRO CSR
+  digit role composite add ADMIN realm-management/view-users`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runCompositeChange(cmd, args[0], args[1:], true)
+	},
+}
+
+func init() {
+	roleCompositeCmd.AddCommand(roleCompositeAddCmd)
+
+	// Add flags
+	roleCompositeAddCmd.Flags().String("account", "", "Account (realm) (overrides config)")
+	roleCompositeAddCmd.Flags().String("server", "", "Server URL (overrides config)")
+	roleCompositeAddCmd.Flags().String("jwt-token", "", "JWT token for authentication (overrides config)")
+}
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// roleCompositeListCmd represents the role composite list command
+var roleCompositeListCmd = &cobra.Command{
+	Use:   "list <composite-role>",
+	Short: "List the roles a composite role includes",
+	Long: `List the roles a composite role includes directly.
+
+Examples:
+  digit role composite list SUPERVISOR
+  digit role composite list dashboard/admin`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Get flag values
+		realm, _ := cmd.Flags().GetString("account")
+		serverURL, _ := cmd.Flags().GetString("server")
+		jwtToken, _ := cmd.Flags().GetString("jwt-token")
+
+		serverURL, jwtToken, realm, err := realmSettings(serverURL, jwtToken, realm)
+		if err != nil {
+			return err
+		}
+		resolver := newRoleResolver(serverURL, jwtToken, realm)
+		clientID, name := splitRoleRef(args[0])
+		clientUUID, err := resolver.clientUUID(clientID)
+		if err != nil {
+			return err
+		}
+		role, err := resolver.mustRole(clientID, name)
+		if err != nil {
+			return err
+		}
+		if !role.Composite {
+			fmt.Printf("%s is not a composite role\n", args[0])
+			return nil
+		}
+
+		composites, err := roleComposites(resolver, clientUUID, name)
+		if err != nil {
+			return err
+		}
+		for _, composite := range composites {
+			fmt.Println(composite)
+		}
+		return nil
+	},
+}
+
+func init() {
+	roleCompositeCmd.AddCommand(roleCompositeListCmd)
+
+	// Add flags
+	roleCompositeListCmd.Flags().String("account", "", "Account (realm) (overrides config)")
+	roleCompositeListCmd.Flags().String("server", "", "Server URL (overrides config)")
+	roleCompositeListCmd.Flags().String("jwt-token", "", "JWT token for authentication (overrides config)")
+}
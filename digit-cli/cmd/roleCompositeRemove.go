@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// roleCompositeRemoveCmd represents the role composite remove command
+var roleCompositeRemoveCmd = &cobra.Command{
+	Use:   "remove <composite-role> <role>...",
+	Short: "Remove roles from a composite role",
+	Long: `Remove roles from a composite role. Users holding the composite role lose the
+removed roles unless they hold them in another way.
+
+Examples:
+  digit role composite remove SUPERVISOR CSR`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runCompositeChange(cmd, args[0], args[1:], false)
+	},
+}
+
+func init() {
+	roleCompositeCmd.AddCommand(roleCompositeRemoveCmd)
+
+	// Add flags
+	roleCompositeRemoveCmd.Flags().String("account", "", "Account (realm) (overrides config)")
+	roleCompositeRemoveCmd.Flags().String("server", "", "Server URL (overrides config)")
+	roleCompositeRemoveCmd.Flags().String("jwt-token", "", "JWT token for authentication (overrides config)")
+}
@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/digitnxt/digit3/code/libraries/digit-library/digit"
+	"github.com/spf13/cobra"
+)
+
+// roleCreateCmd represents the role create command
+var roleCreateCmd = &cobra.Command{
+	Use:   "create <role>...",
+	Short: "Create realm or client roles",
+	Long: `Create one or more realm roles, or roles of a client with --client. Roles that
+already exist are left as they are.
+
+Examples:
+  digit role create GRO CSR LME
+  digit role create ADMIN --description "Administrators"
+  digit role create reports-viewer --client dashboard`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Get flag values
+		clientID, _ := cmd.Flags().GetString("client")
+		description, _ := cmd.Flags().GetString("description")
+		realm, _ := cmd.Flags().GetString("account")
+		serverURL, _ := cmd.Flags().GetString("server")
+		jwtToken, _ := cmd.Flags().GetString("jwt-token")
+
+		serverURL, jwtToken, realm, err := realmSettings(serverURL, jwtToken, realm)
+		if err != nil {
+			return err
+		}
+		resolver := newRoleResolver(serverURL, jwtToken, realm)
+		clientUUID, err := resolver.clientUUID(clientID)
+		if err != nil {
+			return err
+		}
+
+		failed := 0
+		for _, name := range args {
+			ref := roleRef(clientID, name)
+			existing, err := resolver.role(clientID, name)
+			if err != nil {
+				failed++
+				fmt.Printf("✗ %s: %v\n", ref, err)
+				continue
+			}
+			if existing != nil {
+				fmt.Printf("= %s (already exists)\n", ref)
+				continue
+			}
+
+			if clientUUID == "" {
+				_, err = digit.CreateRole(serverURL, jwtToken, realm, name, description)
+			} else {
+				_, err = digit.CreateClientRole(serverURL, jwtToken, realm, clientUUID, digit.Role{Name: name, Description: description})
+			}
+			if err != nil {
+				failed++
+				fmt.Printf("✗ %s: %v\n", ref, err)
+				continue
+			}
+			fmt.Printf("✓ Created role %s\n", ref)
+		}
+
+		if failed > 0 {
+			return fmt.Errorf("%d of %d roles could not be created", failed, len(args))
+		}
+		return nil
+	},
+}
+
+func init() {
+	roleCmd.AddCommand(roleCreateCmd)
+
+	// Add flags
+	roleCreateCmd.Flags().String("client", "", "Create roles of this client (clientId) instead of realm roles")
+	roleCreateCmd.Flags().String("description", "", "Description of the roles")
+	roleCreateCmd.Flags().String("account", "", "Account (realm) (overrides config)")
+	roleCreateCmd.Flags().String("server", "", "Server URL (overrides config)")
+	roleCreateCmd.Flags().String("jwt-token", "", "JWT token for authentication (overrides config)")
+}
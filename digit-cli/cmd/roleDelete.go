@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/digitnxt/digit3/code/libraries/digit-library/digit"
+	"github.com/spf13/cobra"
+)
+
+// roleDeleteCmd represents the role delete command
+var roleDeleteCmd = &cobra.Command{
+	Use:   "delete <role>...",
+	Short: "Delete realm or client roles",
+	Long: `Delete one or more realm roles, or roles of a client with --client. Keycloak
+removes deleted roles from every user, group and composite role holding them;
+review the holders first with 'digit role members'.
+
+Examples:
+  digit role delete OLD_ROLE
+  digit role delete TEMP1 TEMP2 --yes
+  digit role delete reports-viewer --client dashboard`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Get flag values
+		clientID, _ := cmd.Flags().GetString("client")
+		yes, _ := cmd.Flags().GetBool("yes")
+		realm, _ := cmd.Flags().GetString("account")
+		serverURL, _ := cmd.Flags().GetString("server")
+		jwtToken, _ := cmd.Flags().GetString("jwt-token")
+
+		serverURL, jwtToken, realm, err := realmSettings(serverURL, jwtToken, realm)
+		if err != nil {
+			return err
+		}
+		resolver := newRoleResolver(serverURL, jwtToken, realm)
+		clientUUID, err := resolver.clientUUID(clientID)
+		if err != nil {
+			return err
+		}
+
+		if !yes {
+			refs := make([]string, len(args))
+			for i, name := range args {
+				refs[i] = roleRef(clientID, name)
+			}
+			fmt.Printf("Delete %d role(s) (%s) from account %s? Everyone holding them loses them. [y/N]: ", len(args), strings.Join(refs, ", "), realm)
+			answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+			answer = strings.ToLower(strings.TrimSpace(answer))
+			if answer != "y" && answer != "yes" {
+				fmt.Println("Aborted, no roles deleted")
+				return nil
+			}
+		}
+
+		failed := 0
+		for _, name := range args {
+			ref := roleRef(clientID, name)
+			role, err := resolver.role(clientID, name)
+			if err != nil {
+				failed++
+				fmt.Printf("✗ %s: %v\n", ref, err)
+				continue
+			}
+			if role == nil {
+				fmt.Printf("= %s (does not exist)\n", ref)
+				continue
+			}
+			if _, err := digit.DeleteRole(serverURL, jwtToken, realm, clientUUID, name); err != nil {
+				failed++
+				fmt.Printf("✗ %s: %v\n", ref, err)
+				continue
+			}
+			fmt.Printf("✓ Deleted role %s\n", ref)
+		}
+
+		if failed > 0 {
+			return fmt.Errorf("%d of %d roles could not be deleted", failed, len(args))
+		}
+		return nil
+	},
+}
+
+func init() {
+	roleCmd.AddCommand(roleDeleteCmd)
+
+	// Add flags
+	roleDeleteCmd.Flags().String("client", "", "Delete roles of this client (clientId) instead of realm roles")
+	roleDeleteCmd.Flags().BoolP("yes", "y", false, "Delete without asking for confirmation")
+	roleDeleteCmd.Flags().String("account", "", "Account (realm) (overrides config)")
+	roleDeleteCmd.Flags().String("server", "", "Server URL (overrides config)")
+	roleDeleteCmd.Flags().String("jwt-token", "", "JWT token for authentication (overrides config)")
+}
@@ -0,0 +1,143 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/digitnxt/digit3/code/libraries/digit-library/digit"
+	"github.com/spf13/cobra"
+)
+
+// roleListCmd represents the role list command
+var roleListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List realm or client roles",
+	Long: `List the realm roles of an account (realm), or the roles of a client with
+--client, sorted by name. Composite roles are listed with the roles they
+include.
+
+Examples:
+  digit role list
+  digit role list --search ADMIN
+  digit role list --client auth-server
+  digit role list --format json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Get flag values
+		clientID, _ := cmd.Flags().GetString("client")
+		search, _ := cmd.Flags().GetString("search")
+		format, _ := cmd.Flags().GetString("format")
+		realm, _ := cmd.Flags().GetString("account")
+		serverURL, _ := cmd.Flags().GetString("server")
+		jwtToken, _ := cmd.Flags().GetString("jwt-token")
+
+		if format != "table" && format != "json" {
+			return fmt.Errorf("unknown format %q, expected table or json", format)
+		}
+
+		serverURL, jwtToken, realm, err := realmSettings(serverURL, jwtToken, realm)
+		if err != nil {
+			return err
+		}
+		resolver := newRoleResolver(serverURL, jwtToken, realm)
+		clientUUID, err := resolver.clientUUID(clientID)
+		if err != nil {
+			return err
+		}
+
+		responseBody, err := digit.ListRoles(serverURL, jwtToken, realm, clientUUID, digit.RoleQuery{Search: search})
+		if err != nil {
+			return fmt.Errorf("failed to list roles: %w", err)
+		}
+		roles, err := digit.ParseRoles(responseBody)
+		if err != nil {
+			return err
+		}
+		sort.Slice(roles, func(i, j int) bool { return roles[i].Name < roles[j].Name })
+
+		// Composite roles are listed with the roles they include
+		includes := map[string][]string{}
+		for _, role := range roles {
+			if !role.Composite {
+				continue
+			}
+			composites, err := roleComposites(resolver, clientUUID, role.Name)
+			if err != nil {
+				return err
+			}
+			includes[role.Name] = composites
+		}
+
+		if format == "json" {
+			type roleOutput struct {
+				digit.Role
+				Includes []string `json:"includes,omitempty"`
+			}
+			output := make([]roleOutput, 0, len(roles))
+			for _, role := range roles {
+				output = append(output, roleOutput{Role: role, Includes: includes[role.Name]})
+			}
+			data, err := json.MarshalIndent(output, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to encode roles: %w", err)
+			}
+			fmt.Println(string(data))
+			return nil
+		}
+
+		if len(roles) == 0 {
+			fmt.Println("No roles found")
+			return nil
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "NAME\tDESCRIPTION\tINCLUDES")
+		for _, role := range roles {
+			description := role.Description
+			if description == "" {
+				description = "-"
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\n", role.Name, description, formatNames(includes[role.Name]))
+		}
+		w.Flush()
+		fmt.Printf("\n%d roles\n", len(roles))
+		return nil
+	},
+}
+
+// roleComposites fetches the sorted names of the roles a composite role includes, with
+// client roles written as <clientId>/<role>
+func roleComposites(resolver *roleResolver, clientUUID, roleName string) ([]string, error) {
+	responseBody, err := digit.GetRoleComposites(resolver.serverURL, resolver.jwtToken, resolver.realm, clientUUID, roleName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch the roles of composite role %s: %w", roleName, err)
+	}
+	composites, err := digit.ParseRoles(responseBody)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(composites))
+	for _, composite := range composites {
+		clientID, err := resolver.roleClientID(composite)
+		if err != nil {
+			return nil, err
+		}
+		names = append(names, roleRef(clientID, composite.Name))
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func init() {
+	roleCmd.AddCommand(roleListCmd)
+
+	// Add flags
+	roleListCmd.Flags().String("client", "", "List the roles of this client (clientId) instead of the realm roles")
+	roleListCmd.Flags().String("search", "", "Only roles whose name contains this")
+	roleListCmd.Flags().String("format", "table", "Output format: table or json")
+	roleListCmd.Flags().String("account", "", "Account (realm) (overrides config)")
+	roleListCmd.Flags().String("server", "", "Server URL (overrides config)")
+	roleListCmd.Flags().String("jwt-token", "", "JWT token for authentication (overrides config)")
+}
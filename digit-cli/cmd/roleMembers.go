@@ -0,0 +1,286 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/digitnxt/digit3/code/libraries/digit-library/digit"
+	"github.com/spf13/cobra"
+)
+
+// roleMembersCmd represents the role members command
+var roleMembersCmd = &cobra.Command{
+	Use:   "members <role>",
+	Short: "List everyone holding a role, for access reviews",
+	Long: `List every user holding a role: users the role is assigned to directly, users
+holding it through a composite role that includes it, directly or through
+other composite roles, and members of the groups these roles are mapped to
+or of their subgroups. The VIA column shows how each user holds the role,
+e.g. "direct", "SUPERVISOR" or "group /departments/pgr". Realm roles are
+given by name and client roles as <clientId>/<role>.
+
+Examples:
+  digit role members GRO
+  digit role members GRO --direct
+  digit role members realm-management/manage-users --format csv > review.csv`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Get flag values
+		direct, _ := cmd.Flags().GetBool("direct")
+		format, _ := cmd.Flags().GetString("format")
+		realm, _ := cmd.Flags().GetString("account")
+		serverURL, _ := cmd.Flags().GetString("server")
+		jwtToken, _ := cmd.Flags().GetString("jwt-token")
+
+		if format != "table" && format != "json" && format != "csv" {
+			return fmt.Errorf("unknown format %q, expected table, json or csv", format)
+		}
+
+		serverURL, jwtToken, realm, err := realmSettings(serverURL, jwtToken, realm)
+		if err != nil {
+			return err
+		}
+		resolver := newRoleResolver(serverURL, jwtToken, realm)
+		clientID, name := splitRoleRef(args[0])
+		holders, err := roleHolders(resolver, clientID, name, direct)
+		if err != nil {
+			return err
+		}
+
+		switch format {
+		case "json":
+			type holderOutput struct {
+				ID       string   `json:"id"`
+				Username string   `json:"username"`
+				Email    string   `json:"email,omitempty"`
+				Enabled  bool     `json:"enabled"`
+				Via      []string `json:"via"`
+			}
+			output := make([]holderOutput, 0, len(holders))
+			for _, h := range holders {
+				output = append(output, holderOutput{ID: h.User.ID, Username: h.User.Username, Email: h.User.Email, Enabled: h.User.Enabled, Via: h.Via})
+			}
+			data, err := json.MarshalIndent(output, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to encode members: %w", err)
+			}
+			fmt.Println(string(data))
+		case "csv":
+			w := csv.NewWriter(os.Stdout)
+			w.Write([]string{"username", "email", "enabled", "via"})
+			for _, h := range holders {
+				w.Write([]string{h.User.Username, h.User.Email, strconv.FormatBool(h.User.Enabled), strings.Join(h.Via, ";")})
+			}
+			w.Flush()
+			return w.Error()
+		default:
+			if len(holders) == 0 {
+				fmt.Printf("Nobody holds %s\n", args[0])
+				return nil
+			}
+			directCount := 0
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "USERNAME\tEMAIL\tENABLED\tVIA")
+			for _, h := range holders {
+				email := h.User.Email
+				if email == "" {
+					email = "-"
+				}
+				fmt.Fprintf(w, "%s\t%s\t%t\t%s\n", h.User.Username, email, h.User.Enabled, strings.Join(h.Via, ", "))
+				if containsString(h.Via, viaDirect) {
+					directCount++
+				}
+			}
+			w.Flush()
+			fmt.Printf("\n%d users hold %s, %d directly\n", len(holders), args[0], directCount)
+		}
+		return nil
+	},
+}
+
+// viaDirect is how a user holds a role that is assigned to them
+const viaDirect = "direct"
+
+// roleHolder is a user holding a role, with the ways they hold it: directly, through the
+// composite roles that include it, or through the groups it is mapped to
+type roleHolder struct {
+	User digit.User
+	Via  []string
+}
+
+// roleHolders finds the users holding a role, sorted by username. Unless direct is set, the
+// holders of the composite roles including the role, and the members of the groups these
+// roles are mapped to and of their subgroups, are found too; composite roles of other clients
+// than the role's own are not searched.
+func roleHolders(resolver *roleResolver, clientID, name string, direct bool) ([]roleHolder, error) {
+	if _, err := resolver.mustRole(clientID, name); err != nil {
+		return nil, err
+	}
+	target := roleRef(clientID, name)
+	sources := []string{target}
+	if !direct {
+		ancestors, err := compositeAncestors(resolver, clientID, target)
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, ancestors...)
+	}
+
+	holders := map[string]*roleHolder{}
+	add := func(user digit.User, via string) {
+		holder, ok := holders[user.ID]
+		if !ok {
+			holder = &roleHolder{User: user}
+			holders[user.ID] = holder
+		}
+		if !containsString(holder.Via, via) {
+			holder.Via = append(holder.Via, via)
+		}
+	}
+	for _, source := range sources {
+		via := source
+		if source == target {
+			via = viaDirect
+		}
+		sourceClientID, sourceName := splitRoleRef(source)
+		clientUUID, err := resolver.clientUUID(sourceClientID)
+		if err != nil {
+			return nil, err
+		}
+		query := digit.UserQuery{Role: sourceName, Client: clientUUID}
+		err = digit.EachUser(resolver.serverURL, resolver.jwtToken, resolver.realm, query, func(user digit.User) error {
+			add(user, via)
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch the users of role %s: %w", source, err)
+		}
+		if direct {
+			continue
+		}
+
+		// Members of the groups the role is mapped to, and of their subgroups
+		groups, err := roleGroups(resolver, clientUUID, sourceName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch the groups of role %s: %w", source, err)
+		}
+		for _, group := range groups {
+			groupVia := "group " + group.Path
+			if source != target {
+				groupVia += " (" + source + ")"
+			}
+			members, err := groupMembers(resolver.serverURL, resolver.jwtToken, resolver.realm, flattenGroups([]digit.Group{group}))
+			if err != nil {
+				return nil, err
+			}
+			for _, member := range members {
+				add(member.User, groupVia)
+			}
+		}
+	}
+
+	result := make([]roleHolder, 0, len(holders))
+	for _, holder := range holders {
+		result = append(result, *holder)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].User.Username < result[j].User.Username })
+	return result, nil
+}
+
+// roleGroups fetches the groups a role is mapped to directly, with all their subgroups
+func roleGroups(resolver *roleResolver, clientUUID, name string) ([]digit.Group, error) {
+	var groups []digit.Group
+	for first := 0; ; first += groupPageSize {
+		responseBody, err := digit.GetRoleGroups(resolver.serverURL, resolver.jwtToken, resolver.realm, clientUUID, name, first, groupPageSize)
+		if err != nil {
+			return nil, err
+		}
+		page, err := digit.ParseGroups(responseBody)
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, page...)
+		if len(page) < groupPageSize {
+			break
+		}
+	}
+	for i := range groups {
+		// The groups of a role are listed without their subgroups
+		group, err := mustGroup(resolver.serverURL, resolver.jwtToken, resolver.realm, groups[i].Path)
+		if err != nil {
+			return nil, err
+		}
+		if err := fetchSubGroups(resolver.serverURL, resolver.jwtToken, resolver.realm, group); err != nil {
+			return nil, err
+		}
+		groups[i] = *group
+	}
+	return groups, nil
+}
+
+// compositeAncestors finds the composite roles that include a role, directly or through other
+// composite roles, among the realm roles and the roles of the client clientID
+func compositeAncestors(resolver *roleResolver, clientID, target string) ([]string, error) {
+	// Map every role to the composite roles including it
+	parents := map[string][]string{}
+	scopes := []string{""}
+	if clientID != "" {
+		scopes = append(scopes, clientID)
+	}
+	for _, scope := range scopes {
+		clientUUID, err := resolver.clientUUID(scope)
+		if err != nil {
+			return nil, err
+		}
+		roles, err := fetchRoleList(resolver.serverURL, resolver.jwtToken, resolver.realm, clientUUID)
+		if err != nil {
+			return nil, err
+		}
+		for _, role := range roles {
+			if !role.Composite {
+				continue
+			}
+			children, err := roleComposites(resolver, clientUUID, role.Name)
+			if err != nil {
+				return nil, err
+			}
+			for _, child := range children {
+				parents[child] = append(parents[child], roleRef(scope, role.Name))
+			}
+		}
+	}
+
+	// Walk up from the role; composite roles can include each other, so each is visited once
+	var ancestors []string
+	seen := map[string]bool{target: true}
+	queue := []string{target}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, parent := range parents[current] {
+			if !seen[parent] {
+				seen[parent] = true
+				ancestors = append(ancestors, parent)
+				queue = append(queue, parent)
+			}
+		}
+	}
+	return ancestors, nil
+}
+
+func init() {
+	roleCmd.AddCommand(roleMembersCmd)
+
+	// Add flags
+	roleMembersCmd.Flags().Bool("direct", false, "Only list users the role is assigned to directly")
+	roleMembersCmd.Flags().String("format", "table", "Output format: table, json or csv")
+	roleMembersCmd.Flags().String("account", "", "Account (realm) (overrides config)")
+	roleMembersCmd.Flags().String("server", "", "Server URL (overrides config)")
+	roleMembersCmd.Flags().String("jwt-token", "", "JWT token for authentication (overrides config)")
+}
@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// roleUnassignCmd represents the role unassign command
+var roleUnassignCmd = &cobra.Command{
+	Use:   "unassign <role>...",
+	Short: "Remove realm and client roles from a user or group",
+	Long: `Remove one or more roles from a user, or from a group with --group. Realm roles
+are given by name and client roles as <clientId>/<role>. Only roles assigned
+to the user or group directly can be removed; roles a user has through a
+composite role or a group stay until the composite role or group is changed.
+
+Examples:
+  digit role unassign --username jdoe GRO
+  digit role unassign --username jdoe CSR dashboard/reports-viewer
+  digit role unassign --group /departments/pgr GRO`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runRoleChange(cmd, args, false)
+	},
+}
+
+func init() {
+	roleCmd.AddCommand(roleUnassignCmd)
+
+	// Add flags
+	roleUnassignCmd.Flags().String("username", "", "Username of the user to remove the roles from")
+	roleUnassignCmd.Flags().String("group", "", "Path of the group to remove the roles from, instead of a user")
+	roleUnassignCmd.Flags().String("account", "", "Account (realm) (overrides config)")
+	roleUnassignCmd.Flags().String("server", "", "Server URL (overrides config)")
+	roleUnassignCmd.Flags().String("jwt-token", "", "JWT token for authentication (overrides config)")
+}
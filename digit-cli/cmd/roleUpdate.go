@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/digitnxt/digit3/code/libraries/digit-library/digit"
+	"github.com/spf13/cobra"
+)
+
+// roleUpdateCmd represents the role update command
+var roleUpdateCmd = &cobra.Command{
+	Use:   "update <role>",
+	Short: "Update the description of a role",
+	Long: `Update the description of a realm role, or of a client role with --client.
+Roles cannot be renamed; create a new role and move its holders instead.
+
+Examples:
+  digit role update GRO --description "Grievance routing officer"
+  digit role update reports-viewer --client dashboard --description "Can view reports"`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Get flag values
+		clientID, _ := cmd.Flags().GetString("client")
+		description, _ := cmd.Flags().GetString("description")
+		realm, _ := cmd.Flags().GetString("account")
+		serverURL, _ := cmd.Flags().GetString("server")
+		jwtToken, _ := cmd.Flags().GetString("jwt-token")
+
+		serverURL, jwtToken, realm, err := realmSettings(serverURL, jwtToken, realm)
+		if err != nil {
+			return err
+		}
+		resolver := newRoleResolver(serverURL, jwtToken, realm)
+		clientUUID, err := resolver.clientUUID(clientID)
+		if err != nil {
+			return err
+		}
+		role, err := resolver.mustRole(clientID, args[0])
+		if err != nil {
+			return err
+		}
+
+		ref := roleRef(clientID, role.Name)
+		if role.Description == description {
+			fmt.Printf("= %s (unchanged)\n", ref)
+			return nil
+		}
+		role.Description = description
+		if _, err := digit.UpdateRole(serverURL, jwtToken, realm, clientUUID, role.Name, *role); err != nil {
+			return fmt.Errorf("failed to update role %s: %w", ref, err)
+		}
+		fmt.Printf("✓ Updated role %s\n", ref)
+		return nil
+	},
+}
+
+func init() {
+	roleCmd.AddCommand(roleUpdateCmd)
+
+	// Add flags
+	roleUpdateCmd.Flags().String("client", "", "Update a role of this client (clientId) instead of a realm role")
+	roleUpdateCmd.Flags().String("description", "", "New description of the role (required)")
+	roleUpdateCmd.Flags().String("account", "", "Account (realm) (overrides config)")
+	roleUpdateCmd.Flags().String("server", "", "Server URL (overrides config)")
+	roleUpdateCmd.Flags().String("jwt-token", "", "JWT token for authentication (overrides config)")
+
+	// Mark required flags
+	roleUpdateCmd.MarkFlagRequired("description")
+}
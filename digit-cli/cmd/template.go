@@ -0,0 +1,218 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"digit-cli/pkg/notification"
+	"github.com/digitnxt/digit3/code/libraries/digit-library/digit"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// templateCmd represents the template command
+var templateCmd = &cobra.Command{
+	Use:   "template",
+	Short: "Work with notification templates locally",
+	Long:  `Render, check and maintain notification templates defined in template configuration files.`,
+}
+
+// loadTemplateConfig reads a template configuration file, or the embedded default when path is empty,
+// and resolves content-file into Content
+func loadTemplateConfig(path string) (*TemplateConfig, error) {
+	yamlData := []byte(defaultNotificationTemplateYAML)
+	if path != "" {
+		var err error
+		yamlData, err = os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read YAML file: %w", err)
+		}
+	}
+
+	var templateConfig TemplateConfig
+	if err := yaml.Unmarshal(yamlData, &templateConfig); err != nil {
+		return nil, fmt.Errorf("failed to parse YAML file: %w", err)
+	}
+
+	if templateConfig.Content != "" && templateConfig.ContentFile != "" {
+		return nil, fmt.Errorf("cannot use both content and content-file together")
+	}
+	if templateConfig.ContentFile != "" {
+		fileContent, err := os.ReadFile(templateConfig.ContentFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read content file: %w", err)
+		}
+		templateConfig.Content = string(fileContent)
+	}
+
+	return &templateConfig, nil
+}
+
+// compiledTemplate is a final template built from a configuration; composed configurations produce one per locale
+type compiledTemplate struct {
+	Locale     string
+	TemplateID string
+	Version    string
+	Subject    string
+	Content    string
+}
+
+// isComposed reports whether the configuration uses a layout, partials or locale variants
+func (c *TemplateConfig) isComposed() bool {
+	return c.Layout != "" || len(c.Partials) > 0 || len(c.Locales) > 0
+}
+
+// compileTemplateConfig expands the layout and partials of a configuration into final templates.
+// With locales, one template per locale is returned, named according to locale-mode:
+// "template-id" (default) appends the locale to the template ID, "version" appends it to the version.
+func compileTemplateConfig(templateConfig *TemplateConfig) ([]compiledTemplate, error) {
+	layout := ""
+	if templateConfig.Layout != "" {
+		data, err := os.ReadFile(templateConfig.Layout)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read layout file: %w", err)
+		}
+		layout = string(data)
+	}
+
+	partials := make(map[string]string, len(templateConfig.Partials))
+	for name, path := range templateConfig.Partials {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read partial %s: %w", name, err)
+		}
+		partials[name] = string(data)
+	}
+
+	if len(templateConfig.Locales) == 0 {
+		content, err := notification.Compile(layout, templateConfig.Content, partials)
+		if err != nil {
+			return nil, err
+		}
+		return []compiledTemplate{{
+			TemplateID: templateConfig.TemplateID,
+			Version:    templateConfig.Version,
+			Subject:    templateConfig.Subject,
+			Content:    content,
+		}}, nil
+	}
+
+	mode := templateConfig.LocaleMode
+	if mode == "" {
+		mode = "template-id"
+	}
+	if mode != "template-id" && mode != "version" {
+		return nil, fmt.Errorf("invalid locale-mode %q, must be template-id or version", mode)
+	}
+
+	locales := make([]string, 0, len(templateConfig.Locales))
+	for locale := range templateConfig.Locales {
+		if strings.TrimSpace(locale) == "" || strings.ContainsAny(locale, " /") {
+			return nil, fmt.Errorf("invalid locale %q", locale)
+		}
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+
+	var compiled []compiledTemplate
+	for _, locale := range locales {
+		variant := templateConfig.Locales[locale]
+		if variant.Content != "" && variant.ContentFile != "" {
+			return nil, fmt.Errorf("locale %s: cannot use both content and content-file together", locale)
+		}
+
+		subject := variant.Subject
+		if subject == "" {
+			subject = templateConfig.Subject
+		}
+		content := variant.Content
+		if variant.ContentFile != "" {
+			data, err := os.ReadFile(variant.ContentFile)
+			if err != nil {
+				return nil, fmt.Errorf("locale %s: failed to read content file: %w", locale, err)
+			}
+			content = string(data)
+		}
+		if content == "" {
+			content = templateConfig.Content
+		}
+
+		body, err := notification.Compile(layout, content, partials)
+		if err != nil {
+			return nil, fmt.Errorf("locale %s: %w", locale, err)
+		}
+
+		entry := compiledTemplate{
+			Locale:     locale,
+			TemplateID: templateConfig.TemplateID,
+			Version:    templateConfig.Version,
+			Subject:    subject,
+			Content:    body,
+		}
+		if mode == "version" {
+			entry.Version = templateConfig.Version + "-" + locale
+		} else {
+			entry.TemplateID = templateConfig.TemplateID + "-" + locale
+		}
+		compiled = append(compiled, entry)
+	}
+	return compiled, nil
+}
+
+// selectCompiledTemplate picks the template for a locale, defaulting to the first one
+func selectCompiledTemplate(compiled []compiledTemplate, locale string) (*compiledTemplate, error) {
+	if locale == "" {
+		return &compiled[0], nil
+	}
+	var available []string
+	for i := range compiled {
+		if compiled[i].Locale == locale {
+			return &compiled[i], nil
+		}
+		available = append(available, compiled[i].Locale)
+	}
+	return nil, fmt.Errorf("locale %s is not defined (available: %s)", locale, strings.Join(available, ", "))
+}
+
+// searchTemplateVersions fetches all versions of a template, ordered from oldest to newest
+func searchTemplateVersions(serverURL, jwtToken, tenantID, templateID string) ([]notification.TemplateVersion, error) {
+	responseBody, err := digit.SearchNotificationTemplate(serverURL, jwtToken, tenantID, templateID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search notification template: %w", err)
+	}
+	versions, err := notification.ParseTemplateVersions(responseBody)
+	if err != nil {
+		return nil, err
+	}
+
+	// The search may match on prefix; keep only exact matches
+	matched := versions[:0]
+	for _, v := range versions {
+		if v.TemplateID == "" || v.TemplateID == templateID {
+			matched = append(matched, v)
+		}
+	}
+	if len(matched) == 0 {
+		return nil, fmt.Errorf("template %s not found", templateID)
+	}
+	notification.SortVersions(matched)
+	return matched, nil
+}
+
+// findTemplateVersion returns the given version from a list of template versions
+func findTemplateVersion(versions []notification.TemplateVersion, version string) (*notification.TemplateVersion, error) {
+	var available []string
+	for i := range versions {
+		if versions[i].Version == version {
+			return &versions[i], nil
+		}
+		available = append(available, versions[i].Version)
+	}
+	return nil, fmt.Errorf("version %s not found (available: %s)", version, strings.Join(available, ", "))
+}
+
+func init() {
+	rootCmd.AddCommand(templateCmd)
+}
@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"fmt"
+
+	"digit-cli/pkg/notification"
+	"github.com/spf13/cobra"
+)
+
+// templateDiffCmd represents the template diff command
+var templateDiffCmd = &cobra.Command{
+	Use:   "diff <template-id>",
+	Short: "Show the differences between two versions of a notification template",
+	Long: `Show a unified text diff of the type, subject and content of two versions of
+a notification template.
+
+Examples:
+  digit template diff welcome --from 1.0.0 --to 1.1.0
+  digit template diff welcome --from 1.0.0 --to 1.1.0 --context 5`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		templateID := args[0]
+
+		// Get flag values
+		from, _ := cmd.Flags().GetString("from")
+		to, _ := cmd.Flags().GetString("to")
+		context, _ := cmd.Flags().GetInt("context")
+		serverURL, _ := cmd.Flags().GetString("server")
+		jwtToken, _ := cmd.Flags().GetString("jwt-token")
+
+		if context < 0 {
+			return fmt.Errorf("--context cannot be negative")
+		}
+
+		serverURL, jwtToken, tenantID, err := serverSettings(serverURL, jwtToken)
+		if err != nil {
+			return err
+		}
+
+		versions, err := searchTemplateVersions(serverURL, jwtToken, tenantID, templateID)
+		if err != nil {
+			return err
+		}
+		fromVersion, err := findTemplateVersion(versions, from)
+		if err != nil {
+			return err
+		}
+		toVersion, err := findTemplateVersion(versions, to)
+		if err != nil {
+			return err
+		}
+
+		fields := []struct {
+			name     string
+			from, to string
+		}{
+			{"type", fromVersion.Type, toVersion.Type},
+			{"html", fmt.Sprint(fromVersion.HTML), fmt.Sprint(toVersion.HTML)},
+			{"subject", fromVersion.Subject, toVersion.Subject},
+			{"content", fromVersion.Content, toVersion.Content},
+		}
+
+		changed := false
+		for _, field := range fields {
+			diff := notification.UnifiedDiff(
+				fmt.Sprintf("%s@%s (%s)", templateID, from, field.name),
+				fmt.Sprintf("%s@%s (%s)", templateID, to, field.name),
+				field.from, field.to, context)
+			if diff != "" {
+				fmt.Print(diff)
+				changed = true
+			}
+		}
+
+		if !changed {
+			fmt.Printf("Versions %s and %s of %s are identical\n", from, to, templateID)
+		}
+		return nil
+	},
+}
+
+func init() {
+	templateCmd.AddCommand(templateDiffCmd)
+
+	// Add flags
+	templateDiffCmd.Flags().String("from", "", "Version to compare from (required)")
+	templateDiffCmd.Flags().String("to", "", "Version to compare to (required)")
+	templateDiffCmd.Flags().Int("context", 3, "Number of unchanged lines to show around each change")
+	templateDiffCmd.Flags().String("server", "", "Server URL (overrides config)")
+	templateDiffCmd.Flags().String("jwt-token", "", "JWT token for authentication (overrides config)")
+
+	// Mark required flags
+	templateDiffCmd.MarkFlagRequired("from")
+	templateDiffCmd.MarkFlagRequired("to")
+}
@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"fmt"
+
+	"digit-cli/pkg/notification"
+	"github.com/spf13/cobra"
+)
+
+// templateLintCmd represents the template lint command
+var templateLintCmd = &cobra.Command{
+	Use:   "lint",
+	Short: "Lint a notification template configuration",
+	Long: `Lint a notification template configuration before it is uploaded.
+
+The linter checks that:
+  - every placeholder in the subject or content is declared in variables,
+    and every declared variable is used
+  - mustache sections are balanced
+  - HTML content (html: true) is well formed, and does not rely on scripts,
+    external stylesheets, remote images or other resources email clients block
+  - SMS content (type: SMS) fits the GSM-7 alphabet, and reports its encoding
+    and segment count
+
+Layouts, partials and locale variants are compiled first and each locale is
+linted separately; findings are prefixed with file[locale].
+
+The command fails when any error is found (or any warning, with --strict).
+
+Examples:
+  digit template lint -f template-config.yaml
+  digit template lint -f sms-template-config.yaml --strict
+  digit template lint --default`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Get flag values
+		filePath, _ := cmd.Flags().GetString("file")
+		useDefault, _ := cmd.Flags().GetBool("default")
+		strict, _ := cmd.Flags().GetBool("strict")
+
+		// Validate flags - either file or default must be specified
+		if !useDefault && filePath == "" {
+			return fmt.Errorf("either --file or --default flag is required")
+		}
+		if useDefault && filePath != "" {
+			return fmt.Errorf("cannot use both --file and --default flags together")
+		}
+
+		source := filePath
+		if useDefault {
+			source = "<default>"
+		}
+
+		templateConfig, err := loadTemplateConfig(filePath)
+		if err != nil {
+			return err
+		}
+
+		compiled, err := compileTemplateConfig(templateConfig)
+		if err != nil {
+			return err
+		}
+
+		errorCount, warningCount := 0, 0
+		for _, t := range compiled {
+			label := source
+			if t.Locale != "" {
+				label = fmt.Sprintf("%s[%s]", source, t.Locale)
+			}
+			findings := notification.Lint(notification.Template{
+				Type:      templateConfig.Type,
+				Subject:   t.Subject,
+				Content:   t.Content,
+				HTML:      templateConfig.HTML,
+				Variables: templateConfig.Variables,
+			})
+			for _, finding := range findings {
+				fmt.Printf("%s:%s\n", label, finding)
+				switch finding.Severity {
+				case notification.SeverityError:
+					errorCount++
+				case notification.SeverityWarning:
+					warningCount++
+				}
+			}
+		}
+
+		if errorCount == 0 && warningCount == 0 {
+			fmt.Printf("✓ %s: no problems found\n", source)
+			return nil
+		}
+
+		fmt.Printf("\n%d error(s), %d warning(s)\n", errorCount, warningCount)
+		if errorCount > 0 || (strict && warningCount > 0) {
+			return fmt.Errorf("template lint failed")
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	templateCmd.AddCommand(templateLintCmd)
+
+	templateLintCmd.Flags().StringP("file", "f", "", "Path to template configuration YAML file")
+	templateLintCmd.Flags().Bool("default", false, "Lint the embedded default template configuration")
+	templateLintCmd.Flags().Bool("strict", false, "Treat warnings as errors")
+}
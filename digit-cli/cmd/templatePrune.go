@@ -0,0 +1,170 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"digit-cli/pkg/notification"
+	"github.com/digitnxt/digit3/code/libraries/digit-library/digit"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// templatePruneCmd represents the template prune command
+var templatePruneCmd = &cobra.Command{
+	Use:   "prune <template-id>",
+	Short: "Delete old versions of a notification template",
+	Long: `Delete all but the newest --keep versions of a notification template.
+
+Versions are ordered by version number; a pre-release such as 2.0.0-beta is
+older than 2.0.0. Locale variants created with locale-mode "version", such as
+1.2.0-en_IN, are pruned per locale, so --keep applies to each locale
+separately. Suffixes of the ll_CC form are read as locales; pass the template
+configuration with --file to also read its other locales, such as 1.2.0-hi.
+The versions to delete are listed and must be confirmed before anything is
+deleted, unless --yes is given.
+
+Examples:
+  # Keep the three newest versions
+  digit template prune welcome --keep 3
+
+  # List what would be deleted
+  digit template prune welcome --keep 3 --dry-run
+
+  # Prune per locale, with the locales of the template configuration
+  digit template prune welcome --keep 3 --file welcome.yaml
+
+  # Skip the confirmation prompt, e.g. in scripts
+  digit template prune welcome --keep 3 --yes`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		templateID := args[0]
+
+		// Get flag values
+		keep, _ := cmd.Flags().GetInt("keep")
+		yes, _ := cmd.Flags().GetBool("yes")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		configPath, _ := cmd.Flags().GetString("file")
+		serverURL, _ := cmd.Flags().GetString("server")
+		jwtToken, _ := cmd.Flags().GetString("jwt-token")
+
+		if keep < 1 {
+			return fmt.Errorf("--keep must be at least 1")
+		}
+
+		var configLocales []string
+		if configPath != "" {
+			yamlData, err := os.ReadFile(configPath)
+			if err != nil {
+				return fmt.Errorf("failed to read YAML file: %w", err)
+			}
+			var templateConfig TemplateConfig
+			if err := yaml.Unmarshal(yamlData, &templateConfig); err != nil {
+				return fmt.Errorf("failed to parse YAML file: %w", err)
+			}
+			if templateConfig.TemplateID != "" && templateConfig.TemplateID != templateID {
+				return fmt.Errorf("%s is the configuration of template %s, not %s", configPath, templateConfig.TemplateID, templateID)
+			}
+			for locale := range templateConfig.Locales {
+				configLocales = append(configLocales, locale)
+			}
+		}
+
+		serverURL, jwtToken, tenantID, err := serverSettings(serverURL, jwtToken)
+		if err != nil {
+			return err
+		}
+
+		versions, err := searchTemplateVersions(serverURL, jwtToken, tenantID, templateID)
+		if err != nil {
+			return err
+		}
+		// Locale variants such as 1.2.0-en_IN are pruned per locale, so the variants of
+		// kept versions are kept too
+		groups := notification.GroupByLocale(versions, configLocales)
+		locales := make([]string, 0, len(groups))
+		for locale := range groups {
+			locales = append(locales, locale)
+		}
+		sort.Strings(locales)
+
+		var prune []notification.TemplateVersion
+		for _, locale := range locales {
+			group := groups[locale]
+			if len(group) <= keep {
+				continue
+			}
+			label := templateID
+			if locale != "" {
+				label += " (" + locale + ")"
+			}
+			fmt.Printf("Keeping %d newest version(s) of %s:", keep, label)
+			for _, v := range group[len(group)-keep:] {
+				fmt.Printf(" %s", v.Version)
+			}
+			fmt.Println()
+			prune = append(prune, group[:len(group)-keep]...)
+		}
+		if len(prune) == 0 {
+			if len(locales) == 1 && locales[0] == "" {
+				fmt.Printf("Template %s has %d version(s); nothing to prune\n", templateID, len(versions))
+			} else {
+				fmt.Printf("Template %s has at most %d version(s) per locale; nothing to prune\n", templateID, keep)
+			}
+			return nil
+		}
+
+		fmt.Printf("Versions to delete:\n")
+		for _, v := range prune {
+			fmt.Printf("  %s (created %s)\n", v.Version, formatTemplateTime(v.Created))
+		}
+
+		if dryRun {
+			fmt.Printf("\nDry run: %d version(s) not deleted\n", len(prune))
+			return nil
+		}
+
+		if !yes {
+			fmt.Printf("\nDelete %d version(s) of %s? [y/N]: ", len(prune), templateID)
+			answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+			answer = strings.ToLower(strings.TrimSpace(answer))
+			if answer != "y" && answer != "yes" {
+				fmt.Println("Aborted, no versions deleted")
+				return nil
+			}
+		}
+
+		failed := 0
+		for _, v := range prune {
+			if _, err := digit.DeleteNotificationTemplate(serverURL, jwtToken, tenantID, templateID, v.Version); err != nil {
+				failed++
+				fmt.Printf("✗ %s: %v\n", v.Version, err)
+				continue
+			}
+			fmt.Printf("✓ Deleted version %s\n", v.Version)
+		}
+
+		if failed > 0 {
+			return fmt.Errorf("%d of %d version(s) could not be deleted", failed, len(prune))
+		}
+		return nil
+	},
+}
+
+func init() {
+	templateCmd.AddCommand(templatePruneCmd)
+
+	// Add flags
+	templatePruneCmd.Flags().Int("keep", 0, "Number of newest versions to keep (required)")
+	templatePruneCmd.Flags().BoolP("yes", "y", false, "Delete without asking for confirmation")
+	templatePruneCmd.Flags().Bool("dry-run", false, "List the versions that would be deleted without deleting them")
+	templatePruneCmd.Flags().String("file", "", "Template configuration whose locales are read as version suffixes")
+	templatePruneCmd.Flags().String("server", "", "Server URL (overrides config)")
+	templatePruneCmd.Flags().String("jwt-token", "", "JWT token for authentication (overrides config)")
+
+	// Mark required flags
+	templatePruneCmd.MarkFlagRequired("keep")
+}
@@ -0,0 +1,281 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"digit-cli/pkg/notification"
+	"github.com/spf13/cobra"
+)
+
+// renderedTemplate holds the rendered subject and body of a notification template
+type renderedTemplate struct {
+	Config  *TemplateConfig
+	Locale  string
+	Subject string
+	Body    string
+	Missing []string
+}
+
+// templateRenderCmd represents the template render command
+var templateRenderCmd = &cobra.Command{
+	Use:   "render",
+	Short: "Render a notification template locally",
+	Long: `Render a notification template locally by substituting placeholders from a payload.
+
+Both the server's mustache syntax ({{name}}, {{{raw}}}, {{#items}}...{{/items}},
+{{^items}}...{{/items}}) and [USERNAME]-style markers are supported. Values are
+HTML-escaped for templates with html: true. Placeholders with no value in the
+payload are listed after the output.
+
+Layouts, partials and locale variants are compiled first; use --locale to pick
+the variant to render (default: the first locale in alphabetical order).
+
+With --serve, the rendered template is hosted on a local HTTP server and the
+page reloads whenever the configuration, content file or payload changes.
+
+Examples:
+  # Print the rendered subject and body
+  digit template render -f template-with-file-config.yaml --payload sample.json
+
+  # Render the default welcome email
+  digit template render --default --payload sample.json
+
+  # Render the Hindi variant of a localized template
+  digit template render -f examples/welcome-localized-config.yaml --payload sample.json --locale hi_IN
+
+  # Preview in the browser with live reload
+  digit template render -f template-with-file-config.yaml --payload sample.json --serve`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Get flag values
+		filePath, _ := cmd.Flags().GetString("file")
+		useDefault, _ := cmd.Flags().GetBool("default")
+		payloadFile, _ := cmd.Flags().GetString("payload")
+		serve, _ := cmd.Flags().GetBool("serve")
+		addr, _ := cmd.Flags().GetString("addr")
+		locale, _ := cmd.Flags().GetString("locale")
+
+		// Validate flags - either file or default must be specified
+		if !useDefault && filePath == "" {
+			return fmt.Errorf("either --file or --default flag is required")
+		}
+		if useDefault && filePath != "" {
+			return fmt.Errorf("cannot use both --file and --default flags together")
+		}
+
+		if serve {
+			return serveTemplatePreview(addr, filePath, payloadFile, locale)
+		}
+
+		rendered, err := renderTemplateFiles(filePath, payloadFile, locale)
+		if err != nil {
+			return err
+		}
+
+		if rendered.Locale != "" {
+			fmt.Printf("Locale: %s\n", rendered.Locale)
+		}
+		fmt.Printf("Subject: %s\n\n", rendered.Subject)
+		fmt.Println(rendered.Body)
+		if len(rendered.Missing) > 0 {
+			fmt.Fprintf(os.Stderr, "\nWarning: no value in payload for %s\n", strings.Join(rendered.Missing, ", "))
+		}
+		return nil
+	},
+}
+
+// renderTemplateFiles loads a template configuration and payload from disk and renders them
+func renderTemplateFiles(filePath, payloadFile, locale string) (*renderedTemplate, error) {
+	templateConfig, err := loadTemplateConfig(filePath)
+	if err != nil {
+		return nil, err
+	}
+	compiled, err := compileTemplateConfig(templateConfig)
+	if err != nil {
+		return nil, err
+	}
+	selected, err := selectCompiledTemplate(compiled, locale)
+	if err != nil {
+		return nil, err
+	}
+
+	payload := map[string]interface{}{}
+	if payloadFile != "" {
+		data, err := os.ReadFile(payloadFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read payload file: %w", err)
+		}
+		if err := json.Unmarshal(data, &payload); err != nil {
+			return nil, fmt.Errorf("payload file must contain a JSON object: %w", err)
+		}
+	}
+
+	subject, err := notification.Render(selected.Subject, payload, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render subject: %w", err)
+	}
+	body, err := notification.Render(selected.Content, payload, templateConfig.HTML)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render content: %w", err)
+	}
+
+	missing := append([]string{}, subject.Missing...)
+	for _, name := range body.Missing {
+		if !containsString(missing, name) {
+			missing = append(missing, name)
+		}
+	}
+
+	return &renderedTemplate{
+		Config:  templateConfig,
+		Locale:  selected.Locale,
+		Subject: subject.Output,
+		Body:    body.Output,
+		Missing: missing,
+	}, nil
+}
+
+// serveTemplatePreview hosts the rendered template and reloads the page when its files change
+func serveTemplatePreview(addr, filePath, payloadFile, locale string) error {
+	var mu sync.Mutex
+	clients := map[chan struct{}]bool{}
+
+	// Poll the watched files and notify connected pages of changes
+	go func() {
+		last := ""
+		for {
+			watched := []string{filePath, payloadFile}
+			if templateConfig, err := loadTemplateConfig(filePath); err == nil {
+				watched = append(watched, templateConfig.ContentFile, templateConfig.Layout)
+				for _, path := range templateConfig.Partials {
+					watched = append(watched, path)
+				}
+				for _, variant := range templateConfig.Locales {
+					watched = append(watched, variant.ContentFile)
+				}
+			}
+			var stamp strings.Builder
+			for _, path := range watched {
+				if path == "" {
+					continue
+				}
+				if info, err := os.Stat(path); err == nil {
+					fmt.Fprintf(&stamp, "%s:%d:%d;", path, info.ModTime().UnixNano(), info.Size())
+				}
+			}
+			if last != "" && stamp.String() != last {
+				fmt.Printf("%s change detected, reloading\n", time.Now().Format("15:04:05"))
+				mu.Lock()
+				for client := range clients {
+					select {
+					case client <- struct{}{}:
+					default:
+					}
+				}
+				mu.Unlock()
+			}
+			last = stamp.String()
+			time.Sleep(500 * time.Millisecond)
+		}
+	}()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		fmt.Fprint(w, templatePreviewPage(filePath, payloadFile, locale))
+	})
+	mux.HandleFunc("/events", func(w http.ResponseWriter, r *http.Request) {
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+
+		client := make(chan struct{}, 1)
+		mu.Lock()
+		clients[client] = true
+		mu.Unlock()
+		defer func() {
+			mu.Lock()
+			delete(clients, client)
+			mu.Unlock()
+		}()
+
+		for {
+			select {
+			case <-client:
+				fmt.Fprint(w, "data: reload\n\n")
+				flusher.Flush()
+			case <-r.Context().Done():
+				return
+			}
+		}
+	})
+
+	fmt.Printf("Serving template preview at http://%s (press Ctrl+C to stop)\n", addr)
+	return http.ListenAndServe(addr, mux)
+}
+
+// templatePreviewPage renders the preview page; render errors are shown on the page
+func templatePreviewPage(filePath, payloadFile, locale string) string {
+	var body strings.Builder
+	rendered, err := renderTemplateFiles(filePath, payloadFile, locale)
+	if err != nil {
+		fmt.Fprintf(&body, `<div class="error">%s</div>`, html.EscapeString(err.Error()))
+	} else {
+		fmt.Fprintf(&body, `<div class="meta"><b>%s</b> v%s (%s) %s<br>Subject: %s</div>`,
+			html.EscapeString(rendered.Config.TemplateID), html.EscapeString(rendered.Config.Version),
+			html.EscapeString(rendered.Config.Type), html.EscapeString(rendered.Locale), html.EscapeString(rendered.Subject))
+		if len(rendered.Missing) > 0 {
+			fmt.Fprintf(&body, `<div class="warning">No value in payload for %s</div>`, html.EscapeString(strings.Join(rendered.Missing, ", ")))
+		}
+		content := rendered.Body
+		if !rendered.Config.HTML {
+			content = "<pre>" + html.EscapeString(content) + "</pre>"
+		}
+		fmt.Fprintf(&body, `<iframe srcdoc="%s"></iframe>`, html.EscapeString(content))
+	}
+
+	return `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="UTF-8">
+<title>Template preview</title>
+<style>
+  body { margin: 0; font-family: Arial, sans-serif; display: flex; flex-direction: column; height: 100vh; }
+  .meta, .warning, .error { padding: 8px 12px; border-bottom: 1px solid #ddd; }
+  .warning { background: #fff4ce; }
+  .error { background: #fde7e9; white-space: pre-wrap; }
+  iframe { flex: 1; border: 0; width: 100%; }
+</style>
+</head>
+<body>
+` + body.String() + `
+<script>new EventSource("/events").onmessage = function () { location.reload(); };</script>
+</body>
+</html>`
+}
+
+func init() {
+	templateCmd.AddCommand(templateRenderCmd)
+
+	// Add flags
+	templateRenderCmd.Flags().StringP("file", "f", "", "Path to template configuration YAML file")
+	templateRenderCmd.Flags().Bool("default", false, "Render the embedded default template configuration")
+	templateRenderCmd.Flags().String("payload", "", "Path to JSON file with sample payload")
+	templateRenderCmd.Flags().Bool("serve", false, "Serve a live-reloading HTML preview")
+	templateRenderCmd.Flags().String("addr", "localhost:8089", "Address for the preview server")
+	templateRenderCmd.Flags().String("locale", "", "Locale variant to render (default: first locale)")
+}
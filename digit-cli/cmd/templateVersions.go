@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// templateVersionsCmd represents the template versions command
+var templateVersionsCmd = &cobra.Command{
+	Use:   "versions <template-id>",
+	Short: "List all versions of a notification template",
+	Long: `List all versions of a notification template, oldest first, with their
+creation and last modification times.
+
+Examples:
+  digit template versions welcome
+  digit template versions welcome --server http://localhost:8091`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		templateID := args[0]
+
+		// Get flag values
+		serverURL, _ := cmd.Flags().GetString("server")
+		jwtToken, _ := cmd.Flags().GetString("jwt-token")
+
+		serverURL, jwtToken, tenantID, err := serverSettings(serverURL, jwtToken)
+		if err != nil {
+			return err
+		}
+
+		versions, err := searchTemplateVersions(serverURL, jwtToken, tenantID, templateID)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Template %s (%d version(s)):\n", templateID, len(versions))
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tTYPE\tCREATED\tMODIFIED\tSUBJECT")
+		for _, v := range versions {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", v.Version, v.Type, formatTemplateTime(v.Created), formatTemplateTime(v.Modified), v.Subject)
+		}
+		return w.Flush()
+	},
+}
+
+// formatTemplateTime formats a template timestamp in local time, or "-" when unknown
+func formatTemplateTime(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.Local().Format("2006-01-02 15:04:05")
+}
+
+func init() {
+	templateCmd.AddCommand(templateVersionsCmd)
+
+	// Add flags
+	templateVersionsCmd.Flags().String("server", "", "Server URL (overrides config)")
+	templateVersionsCmd.Flags().String("jwt-token", "", "JWT token for authentication (overrides config)")
+}
@@ -0,0 +1,158 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/digitnxt/digit3/code/libraries/digit-library/digit"
+	"github.com/spf13/cobra"
+)
+
+// userCmd represents the user command
+var userCmd = &cobra.Command{
+	Use:   "user",
+	Short: "Manage Keycloak users in bulk",
+	Long: `Import, list, export and manage the Keycloak users of an account (realm) in bulk.
+
+The account defaults to that of the stored login ('digit config set'), or else
+the tenant of the JWT token. Single users are managed with 'digit create-user',
+'digit update-user' and 'digit assign-role'.`,
+}
+
+// findUser fetches the user with the given username, or nil when there is none
+func findUser(serverURL, jwtToken, realm, username string) (*digit.User, error) {
+	responseBody, err := digit.SearchUsers(serverURL, jwtToken, realm, digit.UserQuery{Username: username, Exact: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search user %s: %w", username, err)
+	}
+	users, err := digit.ParseUsers(responseBody)
+	if err != nil {
+		return nil, err
+	}
+	for i := range users {
+		if strings.EqualFold(users[i].Username, username) {
+			return &users[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// mustUser fetches a user like findUser, but a user that does not exist is an error
+func mustUser(serverURL, jwtToken, realm, username string) (*digit.User, error) {
+	user, err := findUser(serverURL, jwtToken, realm, username)
+	if err == nil && user == nil {
+		err = fmt.Errorf("user %s does not exist in account %s", username, realm)
+	}
+	return user, err
+}
+
+// fetchRoles fetches realm roles by name, returning the names of the roles that do not exist
+func fetchRoles(serverURL, jwtToken, realm string, names []string) (map[string]digit.Role, []string, error) {
+	resolver := newRoleResolver(serverURL, jwtToken, realm)
+	roles := map[string]digit.Role{}
+	var missing []string
+	for _, name := range names {
+		if _, ok := roles[name]; ok || containsString(missing, name) {
+			continue
+		}
+		role, err := resolver.role("", name)
+		if err != nil {
+			return nil, nil, err
+		}
+		if role == nil {
+			missing = append(missing, name)
+			continue
+		}
+		roles[name] = *role
+	}
+	return roles, missing, nil
+}
+
+// userRealmRoleNames fetches the names of the realm roles mapped directly to a user
+func userRealmRoleNames(serverURL, jwtToken, realm, userID string) (map[string]bool, error) {
+	roles, err := userRealmRoles(serverURL, jwtToken, realm, userID, false)
+	if err != nil {
+		return nil, err
+	}
+	names := map[string]bool{}
+	for _, name := range roles {
+		names[name] = true
+	}
+	return names, nil
+}
+
+// userRealmRoles fetches the sorted names of the realm roles of a user; effective adds the
+// roles the user has through composite roles
+func userRealmRoles(serverURL, jwtToken, realm, userID string, effective bool) ([]string, error) {
+	fetch := digit.GetUserRealmRoles
+	if effective {
+		fetch = digit.GetUserEffectiveRealmRoles
+	}
+	responseBody, err := fetch(serverURL, jwtToken, realm, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch roles: %w", err)
+	}
+	roles, err := digit.ParseRoles(responseBody)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(roles))
+	for _, role := range roles {
+		names = append(names, role.Name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// userQueryFlags adds the user filter flags shared by user list and user export
+func userQueryFlags(cmd *cobra.Command) {
+	cmd.Flags().String("username", "", "Only users whose username contains this")
+	cmd.Flags().String("email", "", "Only users whose email contains this")
+	cmd.Flags().String("search", "", "Only users whose username, email or name contains this")
+	cmd.Flags().String("enabled", "", "Only enabled (true) or disabled (false) users")
+	cmd.Flags().String("role", "", "Only users the realm role is assigned to directly")
+	cmd.Flags().String("created-after", "", "Only users created on or after this date (YYYY-MM-DD)")
+	cmd.Flags().String("created-before", "", "Only users created before this date (YYYY-MM-DD)")
+}
+
+// userQueryFromFlags reads the user filter flags added by userQueryFlags
+func userQueryFromFlags(cmd *cobra.Command) (digit.UserQuery, error) {
+	query := digit.UserQuery{}
+	query.Username, _ = cmd.Flags().GetString("username")
+	query.Email, _ = cmd.Flags().GetString("email")
+	query.Search, _ = cmd.Flags().GetString("search")
+	query.Role, _ = cmd.Flags().GetString("role")
+	enabledStr, _ := cmd.Flags().GetString("enabled")
+	createdAfter, _ := cmd.Flags().GetString("created-after")
+	createdBefore, _ := cmd.Flags().GetString("created-before")
+
+	if enabledStr != "" {
+		enabled, err := strconv.ParseBool(enabledStr)
+		if err != nil {
+			return query, fmt.Errorf("enabled flag must be 'true' or 'false'")
+		}
+		query.Enabled = &enabled
+	}
+	var err error
+	if createdAfter != "" {
+		if query.CreatedAfter, err = time.ParseInLocation("2006-01-02", createdAfter, time.Local); err != nil {
+			return query, fmt.Errorf("invalid --created-after date %q, expected YYYY-MM-DD", createdAfter)
+		}
+	}
+	if createdBefore != "" {
+		if query.CreatedBefore, err = time.ParseInLocation("2006-01-02", createdBefore, time.Local); err != nil {
+			return query, fmt.Errorf("invalid --created-before date %q, expected YYYY-MM-DD", createdBefore)
+		}
+	}
+	if !query.CreatedAfter.IsZero() && !query.CreatedBefore.IsZero() && !query.CreatedBefore.After(query.CreatedAfter) {
+		return query, fmt.Errorf("--created-before date must be after --created-after date")
+	}
+	return query, nil
+}
+
+func init() {
+	rootCmd.AddCommand(userCmd)
+}
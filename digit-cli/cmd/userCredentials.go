@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/digitnxt/digit3/code/libraries/digit-library/digit"
+	"github.com/spf13/cobra"
+)
+
+// userCredentialsCmd represents the user credentials command
+var userCredentialsCmd = &cobra.Command{
+	Use:   "credentials <username>",
+	Short: "List the credentials of a user",
+	Long: `List the credentials of a user, such as their password and OTP devices. Secrets
+are never shown. Remove credentials with 'digit user remove-credential'.
+
+Examples:
+  digit user credentials jdoe
+  digit user credentials jdoe --format json`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Get flag values
+		format, _ := cmd.Flags().GetString("format")
+		realm, _ := cmd.Flags().GetString("account")
+		serverURL, _ := cmd.Flags().GetString("server")
+		jwtToken, _ := cmd.Flags().GetString("jwt-token")
+
+		if format != "table" && format != "json" {
+			return fmt.Errorf("unknown format %q, expected table or json", format)
+		}
+
+		serverURL, jwtToken, realm, err := realmSettings(serverURL, jwtToken, realm)
+		if err != nil {
+			return err
+		}
+		user, err := mustUser(serverURL, jwtToken, realm, args[0])
+		if err != nil {
+			return err
+		}
+		credentials, err := userCredentials(serverURL, jwtToken, realm, user.ID)
+		if err != nil {
+			return err
+		}
+
+		if format == "json" {
+			data, err := json.MarshalIndent(credentials, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to encode credentials: %w", err)
+			}
+			fmt.Println(string(data))
+			return nil
+		}
+		if len(credentials) == 0 {
+			fmt.Printf("%s has no credentials\n", user.Username)
+			return nil
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tTYPE\tLABEL\tCREATED")
+		for _, credential := range credentials {
+			label, created := credential.UserLabel, "-"
+			if label == "" {
+				label = "-"
+			}
+			if credential.CreatedDate != 0 {
+				created = credential.Created().Format("2006-01-02 15:04")
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", credential.ID, credential.Type, label, created)
+		}
+		w.Flush()
+		fmt.Printf("\n%d credentials\n", len(credentials))
+		return nil
+	},
+}
+
+// userCredentials fetches the credentials of a user
+func userCredentials(serverURL, jwtToken, realm, userID string) ([]digit.Credential, error) {
+	responseBody, err := digit.GetUserCredentials(serverURL, jwtToken, realm, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch credentials: %w", err)
+	}
+	return digit.ParseCredentials(responseBody)
+}
+
+func init() {
+	userCmd.AddCommand(userCredentialsCmd)
+
+	// Add flags
+	userCredentialsCmd.Flags().String("format", "table", "Output format: table or json")
+	userCredentialsCmd.Flags().String("account", "", "Account (realm) (overrides config)")
+	userCredentialsCmd.Flags().String("server", "", "Server URL (overrides config)")
+	userCredentialsCmd.Flags().String("jwt-token", "", "JWT token for authentication (overrides config)")
+}
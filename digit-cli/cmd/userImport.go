@@ -0,0 +1,601 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"digit-cli/pkg/users"
+	"github.com/digitnxt/digit3/code/libraries/digit-library/digit"
+	"github.com/spf13/cobra"
+)
+
+// userImportCmd represents the user import command
+var userImportCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Create users and assign their roles from a CSV file",
+	Long: `Create users and assign their realm roles from a CSV file with a header row and
+the columns username (required), email, firstName, lastName, enabled, roles,
+groups and password. Roles and groups are separated by ; or |, e.g.
+"GRO;CSR", and groups are written by path, e.g. /departments/pgr. The other
+columns of 'digit user list --format csv' are ignored. All roles and groups
+must exist; create them first with 'digit role create' and
+'digit group create'.
+
+Users are added to their groups, and hold the roles mapped to those groups
+and their parent groups. Roles a user holds through a group are not also
+assigned to the user directly.
+
+Users without a password get a generated one. Generated passwords, and
+passwords given in the file when they are temporary (the default, so users
+must change them at first login), are written to a passwords file readable
+only by you. Hand them over securely and delete the file.
+
+Users that already exist are skipped, or with --update-existing have their
+email, names and enabled state updated and the missing groups and roles
+added; their passwords are never changed. Users are imported --concurrency at a time, and
+the result of every row is reported and can be written to a CSV file.
+
+Examples:
+  digit user import --file staff.csv --dry-run
+  digit user import --file staff.csv
+  digit user import --file staff.csv --update-existing --report staff-report.csv
+  digit user import --file staff.csv --passwords-file ~/secure/staff-passwords.csv --account pb`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Get flag values
+		filePath, _ := cmd.Flags().GetString("file")
+		updateExisting, _ := cmd.Flags().GetBool("update-existing")
+		temporary, _ := cmd.Flags().GetBool("temporary-passwords")
+		passwordLength, _ := cmd.Flags().GetInt("password-length")
+		passwordsPath, _ := cmd.Flags().GetString("passwords-file")
+		reportPath, _ := cmd.Flags().GetString("report")
+		concurrency, _ := cmd.Flags().GetInt("concurrency")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		realm, _ := cmd.Flags().GetString("account")
+		serverURL, _ := cmd.Flags().GetString("server")
+		jwtToken, _ := cmd.Flags().GetString("jwt-token")
+
+		if concurrency < 1 {
+			return fmt.Errorf("--concurrency must be at least 1")
+		}
+		if passwordLength < 12 {
+			return fmt.Errorf("--password-length must be at least 12")
+		}
+
+		rows, err := users.ReadImportCSV(filePath)
+		if err != nil {
+			return err
+		}
+
+		serverURL, jwtToken, realm, err = realmSettings(serverURL, jwtToken, realm)
+		if err != nil {
+			return err
+		}
+
+		// Check that every role exists before changing anything
+		var roleNames []string
+		for _, row := range rows {
+			roleNames = append(roleNames, row.Roles...)
+		}
+		roles, missing, err := fetchRoles(serverURL, jwtToken, realm, roleNames)
+		if err != nil {
+			return err
+		}
+		if len(missing) > 0 {
+			return fmt.Errorf("%d roles do not exist in account %s: %s; create them with 'digit create-role'", len(missing), realm, strings.Join(missing, ", "))
+		}
+
+		// And that every group exists
+		groups := newGroupRoleCache(serverURL, jwtToken, realm)
+		var missingGroups []string
+		for _, row := range rows {
+			for _, path := range row.Groups {
+				group, err := groups.group(path)
+				if err != nil {
+					return err
+				}
+				if group == nil && !containsString(missingGroups, path) {
+					missingGroups = append(missingGroups, path)
+				}
+			}
+		}
+		if len(missingGroups) > 0 {
+			return fmt.Errorf("%d groups do not exist in account %s: %s; create them with 'digit group create'", len(missingGroups), realm, strings.Join(missingGroups, ", "))
+		}
+
+		// Generated and temporary passwords go to a file only the current user can read
+		var passwords *passwordsFile
+		needsPasswords := temporary
+		for _, row := range rows {
+			needsPasswords = needsPasswords || row.Password == ""
+		}
+		if needsPasswords && !dryRun {
+			if passwordsPath == "" {
+				passwordsPath = strings.TrimSuffix(filePath, filepath.Ext(filePath)) + "-passwords.csv"
+			}
+			if passwords, err = newPasswordsFile(passwordsPath); err != nil {
+				return err
+			}
+			defer passwords.Close()
+		}
+
+		importer := &userImporter{
+			serverURL:      serverURL,
+			jwtToken:       jwtToken,
+			realm:          realm,
+			roles:          roles,
+			groups:         groups,
+			updateExisting: updateExisting,
+			temporary:      temporary,
+			passwordLength: passwordLength,
+			dryRun:         dryRun,
+			passwords:      passwords,
+		}
+		results := make([]userImportResult, len(rows))
+		jobs := make(chan int)
+		var wg sync.WaitGroup
+		var printMu sync.Mutex
+		for w := 0; w < concurrency; w++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := range jobs {
+					results[i] = importer.importRow(rows[i])
+					printMu.Lock()
+					fmt.Println(results[i])
+					printMu.Unlock()
+				}
+			}()
+		}
+		for i := range rows {
+			jobs <- i
+		}
+		close(jobs)
+		wg.Wait()
+
+		counts := map[string]int{}
+		for _, result := range results {
+			counts[result.Status]++
+		}
+		fmt.Printf("\n%d created, %d updated, %d unchanged, %d skipped, %d failed\n",
+			counts[importCreated], counts[importUpdated], counts[importUnchanged], counts[importSkipped], counts[importFailed])
+		if passwords != nil {
+			if err := passwords.Close(); err != nil {
+				return err
+			}
+			if passwords.count > 0 {
+				fmt.Printf("%d passwords written to %s; hand them over securely and delete the file\n", passwords.count, passwordsPath)
+			}
+		}
+		if reportPath != "" {
+			if err := writeUserImportReport(reportPath, results); err != nil {
+				return err
+			}
+			fmt.Printf("Report written to %s\n", reportPath)
+		}
+		if dryRun {
+			fmt.Println("\nDry run: no changes were made.")
+		}
+		if counts[importFailed] > 0 {
+			return fmt.Errorf("%d of %d users could not be imported", counts[importFailed], len(rows))
+		}
+		return nil
+	},
+}
+
+// Results of importing a row
+const (
+	importCreated   = "created"
+	importUpdated   = "updated"
+	importUnchanged = "unchanged"
+	importSkipped   = "skipped"
+	importFailed    = "failed"
+)
+
+// userImportResult is the result of importing one CSV row
+type userImportResult struct {
+	Line     int
+	Username string
+	Status   string
+	Detail   string
+}
+
+// String formats the result as a report line, e.g. "✓ line 2 jdoe: created (roles GRO, CSR)"
+func (r userImportResult) String() string {
+	symbol := map[string]string{importCreated: "✓", importUpdated: "~", importUnchanged: "=", importSkipped: "=", importFailed: "✗"}[r.Status]
+	line := fmt.Sprintf("%s line %d %s: %s", symbol, r.Line, r.Username, r.Status)
+	if r.Detail != "" {
+		if r.Status == importFailed {
+			return line + ": " + r.Detail
+		}
+		line += " (" + r.Detail + ")"
+	}
+	return line
+}
+
+// userImporter imports CSV rows into a realm
+type userImporter struct {
+	serverURL      string
+	jwtToken       string
+	realm          string
+	roles          map[string]digit.Role
+	groups         *groupRoleCache
+	updateExisting bool
+	temporary      bool
+	passwordLength int
+	dryRun         bool
+	passwords      *passwordsFile
+}
+
+// importRow creates, updates or skips the user of a row
+func (im *userImporter) importRow(row users.ImportRow) userImportResult {
+	result := userImportResult{Line: row.Line, Username: row.Username}
+	fail := func(err error) userImportResult {
+		result.Status, result.Detail = importFailed, err.Error()
+		return result
+	}
+
+	existing, err := findUser(im.serverURL, im.jwtToken, im.realm, row.Username)
+	if err != nil {
+		return fail(err)
+	}
+	if existing == nil {
+		return im.createUser(row, result)
+	}
+	if !im.updateExisting {
+		result.Status, result.Detail = importSkipped, "already exists"
+		return result
+	}
+
+	// Work out the changes to the existing user
+	var changes []string
+	update := digit.UserUpdateRequest{}
+	if row.Email != "" && !strings.EqualFold(row.Email, existing.Email) {
+		update.Email = row.Email
+		changes = append(changes, "email")
+	}
+	if row.FirstName != "" && row.FirstName != existing.FirstName {
+		update.FirstName = row.FirstName
+		changes = append(changes, "firstName")
+	}
+	if row.LastName != "" && row.LastName != existing.LastName {
+		update.LastName = row.LastName
+		changes = append(changes, "lastName")
+	}
+	if row.Enabled != nil && *row.Enabled != existing.Enabled {
+		update.Enabled = row.Enabled
+		changes = append(changes, "enabled="+strconv.FormatBool(*row.Enabled))
+	}
+	updateDetails := len(changes) > 0
+
+	// Groups to join, and the roles the user will hold through all its groups
+	memberOf, err := userGroups(im.serverURL, im.jwtToken, im.realm, existing.ID)
+	if err != nil {
+		return fail(err)
+	}
+	paths := make([]string, 0, len(memberOf))
+	for _, group := range memberOf {
+		paths = append(paths, group.Path)
+	}
+	var addGroups []*digit.Group
+	for _, path := range row.Groups {
+		if !containsString(paths, path) {
+			group, err := im.groups.group(path)
+			if err != nil {
+				return fail(err)
+			}
+			addGroups = append(addGroups, group)
+			paths = append(paths, path)
+			changes = append(changes, "+group "+path)
+		}
+	}
+	inherited, err := im.groups.heldRoles(paths)
+	if err != nil {
+		return fail(err)
+	}
+	current, err := userRealmRoleNames(im.serverURL, im.jwtToken, im.realm, existing.ID)
+	if err != nil {
+		return fail(err)
+	}
+	var addRoles []digit.Role
+	for _, name := range row.Roles {
+		if !current[name] && inherited[name] == "" {
+			addRoles = append(addRoles, im.roles[name])
+			changes = append(changes, "+"+name)
+		}
+	}
+	if len(changes) == 0 {
+		result.Status = importUnchanged
+		return result
+	}
+
+	result.Status, result.Detail = importUpdated, strings.Join(changes, ", ")
+	if im.dryRun {
+		return result
+	}
+	if updateDetails {
+		if _, err := digit.UpdateUserByID(im.serverURL, im.jwtToken, im.realm, existing.ID, update); err != nil {
+			return fail(fmt.Errorf("failed to update user: %w", err))
+		}
+	}
+	for _, group := range addGroups {
+		if _, err := digit.AddUserToGroup(im.serverURL, im.jwtToken, im.realm, existing.ID, group.ID); err != nil {
+			return fail(fmt.Errorf("updated, but failed to add to group %s: %w", group.Path, err))
+		}
+	}
+	if _, err := digit.AddUserRealmRoles(im.serverURL, im.jwtToken, im.realm, existing.ID, addRoles); err != nil {
+		return fail(fmt.Errorf("updated, but failed to assign roles: %w", err))
+	}
+	return result
+}
+
+// createUser creates the user of a row with its password and roles
+func (im *userImporter) createUser(row users.ImportRow, result userImportResult) userImportResult {
+	// Roles held through the groups of the user are not assigned directly
+	inherited, err := im.groups.heldRoles(row.Groups)
+	if err != nil {
+		result.Status, result.Detail = importFailed, err.Error()
+		return result
+	}
+	var roleNames, viaGroups []string
+	for _, name := range row.Roles {
+		if path := inherited[name]; path != "" {
+			viaGroups = append(viaGroups, name+" through "+path)
+		} else {
+			roleNames = append(roleNames, name)
+		}
+	}
+
+	var details []string
+	if len(roleNames) > 0 {
+		details = append(details, "roles "+strings.Join(roleNames, ", "))
+	}
+	if len(row.Groups) > 0 {
+		details = append(details, "groups "+strings.Join(row.Groups, ", "))
+	}
+	if len(viaGroups) > 0 {
+		details = append(details, strings.Join(viaGroups, ", "))
+	}
+	password, generated := row.Password, row.Password == ""
+	if generated {
+		details = append(details, "generated password")
+	}
+	if row.Enabled != nil && !*row.Enabled {
+		details = append(details, "disabled")
+	}
+	result.Status, result.Detail = importCreated, strings.Join(details, "; ")
+	if im.dryRun {
+		return result
+	}
+
+	if generated {
+		var err error
+		if password, err = users.GeneratePassword(im.passwordLength); err != nil {
+			result.Status, result.Detail = importFailed, err.Error()
+			return result
+		}
+	}
+	request := digit.UserRequest{
+		Username:      row.Username,
+		Email:         row.Email,
+		FirstName:     row.FirstName,
+		LastName:      row.LastName,
+		Enabled:       row.Enabled == nil || *row.Enabled,
+		EmailVerified: true,
+		Credentials:   []digit.UserCredential{{Type: "password", Value: password, Temporary: im.temporary}},
+	}
+	// The password is written before the user is created, so a created user's password is never lost
+	written := generated || im.temporary
+	if written {
+		if err := im.passwords.Write(row.Username, password, im.temporary); err != nil {
+			result.Status, result.Detail = importFailed, fmt.Sprintf("not created: %v", err)
+			return result
+		}
+	}
+	if _, err := digit.CreateUserFromRequest(im.serverURL, im.jwtToken, im.realm, request); err != nil {
+		result.Status, result.Detail = importFailed, fmt.Sprintf("failed to create user: %v", err)
+		if written {
+			result.Detail += "; its entry in the passwords file is unused"
+		}
+		return result
+	}
+
+	if len(roleNames) > 0 || len(row.Groups) > 0 {
+		created, err := findUser(im.serverURL, im.jwtToken, im.realm, row.Username)
+		if err == nil && created == nil {
+			err = fmt.Errorf("user not found after creating it")
+		}
+		for _, path := range row.Groups {
+			if err != nil {
+				break
+			}
+			var group *digit.Group
+			if group, err = im.groups.group(path); err == nil {
+				_, err = digit.AddUserToGroup(im.serverURL, im.jwtToken, im.realm, created.ID, group.ID)
+			}
+		}
+		if err == nil {
+			var roles []digit.Role
+			for _, name := range roleNames {
+				roles = append(roles, im.roles[name])
+			}
+			_, err = digit.AddUserRealmRoles(im.serverURL, im.jwtToken, im.realm, created.ID, roles)
+		}
+		if err != nil {
+			result.Status, result.Detail = importFailed, fmt.Sprintf("created, but failed to assign groups and roles: %v", err)
+			return result
+		}
+	}
+	return result
+}
+
+// groupRoleCache looks up groups by path and the realm roles their members hold, caching
+// both for the workers of an import
+type groupRoleCache struct {
+	mu        sync.Mutex
+	serverURL string
+	jwtToken  string
+	realm     string
+	groups    map[string]*digit.Group
+	roles     map[string]map[string]bool
+}
+
+// newGroupRoleCache returns an empty group cache for a realm
+func newGroupRoleCache(serverURL, jwtToken, realm string) *groupRoleCache {
+	return &groupRoleCache{serverURL: serverURL, jwtToken: jwtToken, realm: realm, groups: map[string]*digit.Group{}, roles: map[string]map[string]bool{}}
+}
+
+// group fetches the group with the given path, or nil when there is none
+func (c *groupRoleCache) group(path string) (*digit.Group, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lookup(path)
+}
+
+// lookup is group for callers holding the lock
+func (c *groupRoleCache) lookup(path string) (*digit.Group, error) {
+	if group, ok := c.groups[path]; ok {
+		return group, nil
+	}
+	group, err := findGroup(c.serverURL, c.jwtToken, c.realm, path)
+	if err != nil {
+		return nil, err
+	}
+	c.groups[path] = group
+	return group, nil
+}
+
+// heldRoles maps the realm roles members of the groups hold, through the groups or their
+// parent groups, to the path of a group they come from
+func (c *groupRoleCache) heldRoles(paths []string) (map[string]string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	held := map[string]string{}
+	for _, path := range paths {
+		// The group and each of its parents
+		for p := path; p != ""; p = parentGroupPath(p) {
+			roles, ok := c.roles[p]
+			if !ok {
+				group, err := c.lookup(p)
+				if err != nil {
+					return nil, err
+				}
+				if group == nil {
+					return nil, fmt.Errorf("group %s does not exist in account %s", p, c.realm)
+				}
+				if roles, err = groupRoleNames(c.serverURL, c.jwtToken, c.realm, group.ID, ""); err != nil {
+					return nil, err
+				}
+				c.roles[p] = roles
+			}
+			for name := range roles {
+				if _, ok := held[name]; !ok {
+					held[name] = p
+				}
+			}
+		}
+	}
+	return held, nil
+}
+
+// passwordsFile is a CSV file of usernames and their initial passwords, readable only by the
+// owner. It is created with the first password, so an import that creates no users leaves none.
+type passwordsFile struct {
+	mu     sync.Mutex
+	path   string
+	file   *os.File
+	writer *csv.Writer
+	count  int
+}
+
+// newPasswordsFile checks that the passwords file does not exist yet; an existing file is
+// never overwritten, so that the passwords of an earlier import are not lost
+func newPasswordsFile(path string) (*passwordsFile, error) {
+	if _, err := os.Stat(path); err == nil {
+		return nil, fmt.Errorf("passwords file %s already exists; move it away or choose another with --passwords-file", path)
+	}
+	return &passwordsFile{path: path}, nil
+}
+
+// Write adds a password to the file and flushes it at once, so no password is lost if the import stops
+func (p *passwordsFile) Write(username, password string, temporary bool) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.file == nil {
+		file, err := os.OpenFile(p.path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+		if err != nil {
+			return fmt.Errorf("failed to create passwords file: %w", err)
+		}
+		p.file, p.writer = file, csv.NewWriter(file)
+		p.writer.Write([]string{"username", "password", "temporary"})
+	}
+	p.writer.Write([]string{username, password, strconv.FormatBool(temporary)})
+	p.writer.Flush()
+	if err := p.writer.Error(); err != nil {
+		return fmt.Errorf("failed to write password to passwords file: %w", err)
+	}
+	p.count++
+	return nil
+}
+
+// Close closes the file when it was created
+func (p *passwordsFile) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.file == nil {
+		return nil
+	}
+	err := p.file.Close()
+	p.file = nil
+	if err != nil {
+		return fmt.Errorf("failed to close passwords file: %w", err)
+	}
+	return nil
+}
+
+// writeUserImportReport writes the result of every row to a CSV file, in the order of the rows
+func writeUserImportReport(path string, results []userImportResult) error {
+	sort.Slice(results, func(i, j int) bool { return results[i].Line < results[j].Line })
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create report: %w", err)
+	}
+	defer file.Close()
+
+	writer := csv.NewWriter(file)
+	writer.Write([]string{"line", "username", "result", "detail"})
+	for _, r := range results {
+		writer.Write([]string{strconv.Itoa(r.Line), r.Username, r.Status, r.Detail})
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+	return nil
+}
+
+func init() {
+	userCmd.AddCommand(userImportCmd)
+
+	// Add flags
+	userImportCmd.Flags().StringP("file", "f", "", "CSV file of users (required)")
+	userImportCmd.Flags().Bool("update-existing", false, "Update the details and add the roles of users that already exist, instead of skipping them")
+	userImportCmd.Flags().Bool("temporary-passwords", true, "Make initial passwords temporary, so users must change them at first login")
+	userImportCmd.Flags().Int("password-length", 16, "Length of generated passwords")
+	userImportCmd.Flags().String("passwords-file", "", "File for generated and temporary passwords (default: <file>-passwords.csv)")
+	userImportCmd.Flags().String("report", "", "Also write the result of every row to this CSV file")
+	userImportCmd.Flags().Int("concurrency", 4, "Number of users to import at a time")
+	userImportCmd.Flags().Bool("dry-run", false, "Show what would be done without changing anything")
+	userImportCmd.Flags().String("account", "", "Account (realm) to import into (overrides config)")
+	userImportCmd.Flags().String("server", "", "Server URL (overrides config)")
+	userImportCmd.Flags().String("jwt-token", "", "JWT token for authentication (overrides config)")
+
+	// Mark required flags
+	userImportCmd.MarkFlagRequired("file")
+}
@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/digitnxt/digit3/code/libraries/digit-library/digit"
+	"github.com/spf13/cobra"
+)
+
+// userLogoutCmd represents the user logout command
+var userLogoutCmd = &cobra.Command{
+	Use:   "logout <username>",
+	Short: "Log a user out everywhere, or end some of their sessions",
+	Long: `End all the active sessions of a user, logging them out of every client, or only
+the sessions given with --session (see 'digit user sessions'). The user can
+log in again; disable the user with 'digit update-user --enabled false' to
+keep them out.
+
+Examples:
+  digit user logout jdoe
+  digit user logout jdoe --session 3f1c2a9e-5b7d-4e2f-9a61-0c8d4b7e1f23`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Get flag values
+		sessionIDs, _ := cmd.Flags().GetStringSlice("session")
+		realm, _ := cmd.Flags().GetString("account")
+		serverURL, _ := cmd.Flags().GetString("server")
+		jwtToken, _ := cmd.Flags().GetString("jwt-token")
+
+		serverURL, jwtToken, realm, err := realmSettings(serverURL, jwtToken, realm)
+		if err != nil {
+			return err
+		}
+		user, err := mustUser(serverURL, jwtToken, realm, args[0])
+		if err != nil {
+			return err
+		}
+		sessions, err := userSessions(serverURL, jwtToken, realm, user.ID)
+		if err != nil {
+			return err
+		}
+
+		if len(sessionIDs) == 0 {
+			if _, err := digit.LogoutUser(serverURL, jwtToken, realm, user.ID); err != nil {
+				return fmt.Errorf("failed to log out %s: %w", user.Username, err)
+			}
+			fmt.Printf("✓ Logged %s out of %d sessions\n", user.Username, len(sessions))
+			return nil
+		}
+
+		// Only sessions of the user are ended, so a mistyped ID cannot end someone else's
+		owned := map[string]bool{}
+		for _, session := range sessions {
+			owned[session.ID] = true
+		}
+		failed := 0
+		for _, sessionID := range sessionIDs {
+			if !owned[sessionID] {
+				failed++
+				fmt.Printf("✗ %s: not an active session of %s\n", sessionID, user.Username)
+				continue
+			}
+			if _, err := digit.DeleteSession(serverURL, jwtToken, realm, sessionID); err != nil {
+				failed++
+				fmt.Printf("✗ %s: %v\n", sessionID, err)
+				continue
+			}
+			fmt.Printf("✓ Ended session %s of %s\n", sessionID, user.Username)
+		}
+		if failed > 0 {
+			return fmt.Errorf("%d of %d sessions could not be ended", failed, len(sessionIDs))
+		}
+		return nil
+	},
+}
+
+func init() {
+	userCmd.AddCommand(userLogoutCmd)
+
+	// Add flags
+	userLogoutCmd.Flags().StringSlice("session", nil, "End only these sessions (repeatable or comma-separated)")
+	userLogoutCmd.Flags().String("account", "", "Account (realm) (overrides config)")
+	userLogoutCmd.Flags().String("server", "", "Server URL (overrides config)")
+	userLogoutCmd.Flags().String("jwt-token", "", "JWT token for authentication (overrides config)")
+}
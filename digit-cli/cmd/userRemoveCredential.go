@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/digitnxt/digit3/code/libraries/digit-library/digit"
+	"github.com/spf13/cobra"
+)
+
+// userRemoveCredentialCmd represents the user remove-credential command
+var userRemoveCredentialCmd = &cobra.Command{
+	Use:   "remove-credential <username> <id-or-type>...",
+	Short: "Remove credentials of a user, such as an OTP device",
+	Long: `Remove credentials of a user by ID (see 'digit user credentials') or by type,
+which removes every credential of that type. Removing the otp credentials lets
+a user who lost their device log in with their password alone; add the
+CONFIGURE_TOTP required action to have them set up a new one.
+
+Examples:
+  digit user remove-credential jdoe otp
+  digit user remove-credential jdoe 9b2e4c71-0a3f-4d8e-b5c6-7f1e2d3a4b5c --yes
+  digit user remove-credential jdoe otp && digit user required-actions jdoe configure-totp`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Get flag values
+		yes, _ := cmd.Flags().GetBool("yes")
+		realm, _ := cmd.Flags().GetString("account")
+		serverURL, _ := cmd.Flags().GetString("server")
+		jwtToken, _ := cmd.Flags().GetString("jwt-token")
+
+		serverURL, jwtToken, realm, err := realmSettings(serverURL, jwtToken, realm)
+		if err != nil {
+			return err
+		}
+		user, err := mustUser(serverURL, jwtToken, realm, args[0])
+		if err != nil {
+			return err
+		}
+		credentials, err := userCredentials(serverURL, jwtToken, realm, user.ID)
+		if err != nil {
+			return err
+		}
+
+		// Resolve every argument first so nothing is removed when one of them matches nothing
+		var matched []digit.Credential
+		var unknown []string
+		seen := map[string]bool{}
+		for _, ref := range args[1:] {
+			found := false
+			for _, credential := range credentials {
+				if credential.ID != ref && !strings.EqualFold(credential.Type, ref) {
+					continue
+				}
+				found = true
+				if !seen[credential.ID] {
+					seen[credential.ID] = true
+					matched = append(matched, credential)
+				}
+			}
+			if !found {
+				unknown = append(unknown, ref)
+			}
+		}
+		if len(unknown) > 0 {
+			return fmt.Errorf("%s has no credential %s", user.Username, strings.Join(unknown, ", "))
+		}
+
+		if !yes {
+			refs := make([]string, len(matched))
+			password := false
+			for i, credential := range matched {
+				refs[i] = credential.Type + " " + credential.ID
+				password = password || credential.Type == "password"
+			}
+			warning := ""
+			if password {
+				warning = " Without a password they cannot log in until one is set."
+			}
+			fmt.Printf("Remove %d credential(s) (%s) of %s?%s [y/N]: ", len(matched), strings.Join(refs, ", "), user.Username, warning)
+			answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+			answer = strings.ToLower(strings.TrimSpace(answer))
+			if answer != "y" && answer != "yes" {
+				fmt.Println("Aborted, no credentials removed")
+				return nil
+			}
+		}
+
+		failed := 0
+		for _, credential := range matched {
+			if _, err := digit.DeleteUserCredential(serverURL, jwtToken, realm, user.ID, credential.ID); err != nil {
+				failed++
+				fmt.Printf("✗ %s %s: %v\n", credential.Type, credential.ID, err)
+				continue
+			}
+			fmt.Printf("✓ Removed %s credential %s of %s\n", credential.Type, credential.ID, user.Username)
+		}
+		if failed > 0 {
+			return fmt.Errorf("%d of %d credentials could not be removed", failed, len(matched))
+		}
+		return nil
+	},
+}
+
+func init() {
+	userCmd.AddCommand(userRemoveCredentialCmd)
+
+	// Add flags
+	userRemoveCredentialCmd.Flags().BoolP("yes", "y", false, "Remove without asking for confirmation")
+	userRemoveCredentialCmd.Flags().String("account", "", "Account (realm) (overrides config)")
+	userRemoveCredentialCmd.Flags().String("server", "", "Server URL (overrides config)")
+	userRemoveCredentialCmd.Flags().String("jwt-token", "", "JWT token for authentication (overrides config)")
+}
@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/digitnxt/digit3/code/libraries/digit-library/digit"
+	"github.com/spf13/cobra"
+)
+
+// userRequiredActionsCmd represents the user required-actions command
+var userRequiredActionsCmd = &cobra.Command{
+	Use:   "required-actions <username> [action]...",
+	Short: "Show, add or remove the actions a user must perform at next login",
+	Long: `Show the required actions of a user, or add actions the user must perform at
+their next login, such as UPDATE_PASSWORD, VERIFY_EMAIL, CONFIGURE_TOTP and
+UPDATE_PROFILE. Action names are case-insensitive and may use dashes, e.g.
+update-password. Other actions enabled in the realm are passed on as given.
+
+With --remove the actions are removed instead. With --email the user is sent
+an email with a link to perform the actions at once, without logging in.
+
+Examples:
+  digit user required-actions jdoe
+  digit user required-actions jdoe update-password configure-totp
+  digit user required-actions jdoe configure-totp --remove
+  digit user required-actions jdoe update-password --email --lifespan 24h`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Get flag values
+		remove, _ := cmd.Flags().GetBool("remove")
+		email, _ := cmd.Flags().GetBool("email")
+		lifespan, _ := cmd.Flags().GetDuration("lifespan")
+		realm, _ := cmd.Flags().GetString("account")
+		serverURL, _ := cmd.Flags().GetString("server")
+		jwtToken, _ := cmd.Flags().GetString("jwt-token")
+
+		actions := make([]string, 0, len(args)-1)
+		for _, arg := range args[1:] {
+			if action := requiredActionName(arg); !containsString(actions, action) {
+				actions = append(actions, action)
+			}
+		}
+		if (remove || email) && len(actions) == 0 {
+			return fmt.Errorf("no actions given")
+		}
+		if remove && email {
+			return fmt.Errorf("cannot use --remove and --email together")
+		}
+
+		serverURL, jwtToken, realm, err := realmSettings(serverURL, jwtToken, realm)
+		if err != nil {
+			return err
+		}
+		user, err := mustUser(serverURL, jwtToken, realm, args[0])
+		if err != nil {
+			return err
+		}
+
+		if email {
+			if user.Email == "" {
+				return fmt.Errorf("user %s has no email address", user.Username)
+			}
+			if _, err := digit.SendUserActionsEmail(serverURL, jwtToken, realm, user.ID, actions, lifespan); err != nil {
+				return fmt.Errorf("failed to send email: %w", err)
+			}
+			fmt.Printf("✓ Emailed %s a link to %s, valid for %s\n", user.Email, strings.Join(actions, ", "), lifespan)
+			return nil
+		}
+
+		current := user.RequiredActions
+		if len(actions) == 0 {
+			if len(current) == 0 {
+				fmt.Printf("%s has no required actions\n", user.Username)
+			} else {
+				fmt.Printf("Required actions of %s: %s\n", user.Username, strings.Join(current, ", "))
+			}
+			return nil
+		}
+
+		updated := append([]string{}, current...)
+		if remove {
+			updated = updated[:0]
+			for _, action := range current {
+				if !containsString(actions, action) {
+					updated = append(updated, action)
+				}
+			}
+		}
+		for _, action := range actions {
+			switch {
+			case remove && containsString(current, action):
+				fmt.Printf("- %s\n", action)
+			case remove:
+				fmt.Printf("= %s (not required)\n", action)
+			case containsString(current, action):
+				fmt.Printf("= %s (already required)\n", action)
+			default:
+				updated = append(updated, action)
+				fmt.Printf("+ %s\n", action)
+			}
+		}
+		if len(updated) == len(current) {
+			return nil
+		}
+		if _, err := digit.SetUserRequiredActions(serverURL, jwtToken, realm, user.ID, updated); err != nil {
+			return fmt.Errorf("failed to update required actions: %w", err)
+		}
+		if len(updated) == 0 {
+			fmt.Printf("✓ %s has no required actions\n", user.Username)
+		} else {
+			fmt.Printf("✓ %s must %s at next login\n", user.Username, strings.Join(updated, ", "))
+		}
+		return nil
+	},
+}
+
+// requiredActionName returns the Keycloak name of a well-known required action written in
+// any case or with dashes, e.g. update-password; other actions are returned as given
+func requiredActionName(action string) string {
+	name := strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(action), "-", "_"))
+	switch name {
+	case digit.RequiredActionUpdatePassword, digit.RequiredActionVerifyEmail, digit.RequiredActionConfigureTOTP, digit.RequiredActionUpdateProfile:
+		return name
+	}
+	return strings.TrimSpace(action)
+}
+
+func init() {
+	userCmd.AddCommand(userRequiredActionsCmd)
+
+	// Add flags
+	userRequiredActionsCmd.Flags().Bool("remove", false, "Remove the actions instead of adding them")
+	userRequiredActionsCmd.Flags().Bool("email", false, "Email the user a link to perform the actions now")
+	userRequiredActionsCmd.Flags().Duration("lifespan", 12*time.Hour, "How long the emailed link is valid")
+	userRequiredActionsCmd.Flags().String("account", "", "Account (realm) (overrides config)")
+	userRequiredActionsCmd.Flags().String("server", "", "Server URL (overrides config)")
+	userRequiredActionsCmd.Flags().String("jwt-token", "", "JWT token for authentication (overrides config)")
+}
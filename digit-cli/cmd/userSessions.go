@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/digitnxt/digit3/code/libraries/digit-library/digit"
+	"github.com/spf13/cobra"
+)
+
+// userSessionsCmd represents the user sessions command
+var userSessionsCmd = &cobra.Command{
+	Use:   "sessions <username>",
+	Short: "List the active sessions of a user",
+	Long: `List the active login sessions of a user, with the address they were started
+from, when they were last used and the clients they are logged in to. End them
+with 'digit user logout'.
+
+Examples:
+  digit user sessions jdoe
+  digit user sessions jdoe --format json`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Get flag values
+		format, _ := cmd.Flags().GetString("format")
+		realm, _ := cmd.Flags().GetString("account")
+		serverURL, _ := cmd.Flags().GetString("server")
+		jwtToken, _ := cmd.Flags().GetString("jwt-token")
+
+		if format != "table" && format != "json" {
+			return fmt.Errorf("unknown format %q, expected table or json", format)
+		}
+
+		serverURL, jwtToken, realm, err := realmSettings(serverURL, jwtToken, realm)
+		if err != nil {
+			return err
+		}
+		user, err := mustUser(serverURL, jwtToken, realm, args[0])
+		if err != nil {
+			return err
+		}
+		sessions, err := userSessions(serverURL, jwtToken, realm, user.ID)
+		if err != nil {
+			return err
+		}
+
+		if format == "json" {
+			data, err := json.MarshalIndent(sessions, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to encode sessions: %w", err)
+			}
+			fmt.Println(string(data))
+			return nil
+		}
+		if len(sessions) == 0 {
+			fmt.Printf("%s has no active sessions\n", user.Username)
+			return nil
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "SESSION\tIP ADDRESS\tSTARTED\tLAST ACCESS\tCLIENTS")
+		for _, session := range sessions {
+			var clients []string
+			for _, clientID := range session.Clients {
+				clients = append(clients, clientID)
+			}
+			sort.Strings(clients)
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", session.ID, session.IPAddress,
+				session.Started().Format("2006-01-02 15:04"), session.LastAccessed().Format("2006-01-02 15:04"), formatNames(clients))
+		}
+		w.Flush()
+		fmt.Printf("\n%d sessions\n", len(sessions))
+		return nil
+	},
+}
+
+// userSessions fetches the active sessions of a user, most recently used first
+func userSessions(serverURL, jwtToken, realm, userID string) ([]digit.UserSession, error) {
+	responseBody, err := digit.GetUserSessions(serverURL, jwtToken, realm, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch sessions: %w", err)
+	}
+	sessions, err := digit.ParseUserSessions(responseBody)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(sessions, func(i, j int) bool { return sessions[i].LastAccess > sessions[j].LastAccess })
+	return sessions, nil
+}
+
+func init() {
+	userCmd.AddCommand(userSessionsCmd)
+
+	// Add flags
+	userSessionsCmd.Flags().String("format", "table", "Output format: table or json")
+	userSessionsCmd.Flags().String("account", "", "Account (realm) (overrides config)")
+	userSessionsCmd.Flags().String("server", "", "Server URL (overrides config)")
+	userSessionsCmd.Flags().String("jwt-token", "", "JWT token for authentication (overrides config)")
+}
@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"fmt"
+
+	"digit-cli/pkg/users"
+	"github.com/digitnxt/digit3/code/libraries/digit-library/digit"
+	"github.com/spf13/cobra"
+)
+
+// userSetPasswordCmd represents the user set-password command
+var userSetPasswordCmd = &cobra.Command{
+	Use:   "set-password <username>",
+	Short: "Set a temporary or permanent password for a user",
+	Long: `Set the password of a user. The password is temporary by default, so the user
+must choose a new one at their next login; use --permanent to keep it.
+
+Without --password a random password is generated and printed once.
+
+Examples:
+  digit user set-password jdoe
+  digit user set-password jdoe --length 24
+  digit user set-password jdoe --password 'Initial-pass-1' --permanent`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Get flag values
+		password, _ := cmd.Flags().GetString("password")
+		length, _ := cmd.Flags().GetInt("length")
+		permanent, _ := cmd.Flags().GetBool("permanent")
+		realm, _ := cmd.Flags().GetString("account")
+		serverURL, _ := cmd.Flags().GetString("server")
+		jwtToken, _ := cmd.Flags().GetString("jwt-token")
+
+		generated := password == ""
+		if generated {
+			var err error
+			if password, err = users.GeneratePassword(length); err != nil {
+				return err
+			}
+		}
+
+		serverURL, jwtToken, realm, err := realmSettings(serverURL, jwtToken, realm)
+		if err != nil {
+			return err
+		}
+		user, err := mustUser(serverURL, jwtToken, realm, args[0])
+		if err != nil {
+			return err
+		}
+		if _, err := digit.SetUserPassword(serverURL, jwtToken, realm, user.ID, password, !permanent); err != nil {
+			return fmt.Errorf("failed to set password: %w", err)
+		}
+
+		if permanent {
+			fmt.Printf("✓ Set a permanent password for %s\n", user.Username)
+		} else {
+			fmt.Printf("✓ Set a temporary password for %s, to be changed at next login\n", user.Username)
+		}
+		if generated {
+			fmt.Printf("Password: %s\n", password)
+		}
+		return nil
+	},
+}
+
+func init() {
+	userCmd.AddCommand(userSetPasswordCmd)
+
+	// Add flags
+	userSetPasswordCmd.Flags().String("password", "", "Password to set (generated when empty)")
+	userSetPasswordCmd.Flags().Int("length", 16, "Length of a generated password")
+	userSetPasswordCmd.Flags().Bool("permanent", false, "Keep the password instead of requiring a new one at next login")
+	userSetPasswordCmd.Flags().String("account", "", "Account (realm) (overrides config)")
+	userSetPasswordCmd.Flags().String("server", "", "Server URL (overrides config)")
+	userSetPasswordCmd.Flags().String("jwt-token", "", "JWT token for authentication (overrides config)")
+}
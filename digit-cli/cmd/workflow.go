@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/digitnxt/digit3/code/libraries/digit-library/digit"
+	"github.com/spf13/cobra"
+)
+
+// workflowCmd represents the workflow command
+var workflowCmd = &cobra.Command{
+	Use:   "workflow",
+	Short: "Check workflow definitions",
+	Long: `Check workflow definition files before creating them with 'digit create-workflow'.
+
+The account defaults to that of the stored login ('digit config set'), or else
+the tenant of the JWT token.`,
+}
+
+// Workflow role statuses
+const (
+	workflowRoleOK        = "ok"
+	workflowRoleMissing   = "missing"
+	workflowRoleCreated   = "created"
+	workflowRoleNoMembers = "no members"
+)
+
+// workflowRoleCheck is a role used by the actions of a workflow and its state in the realm
+type workflowRoleCheck struct {
+	Role    string
+	Actions []string
+	Status  string
+	Members int
+	// Err is why a missing role could not be created
+	Err error
+}
+
+// workflowRoles maps the roles allowed to take the actions of a workflow to the names of
+// those actions
+func workflowRoles(workflowDef *WorkflowDefinition) map[string][]string {
+	roles := map[string][]string{}
+	for _, action := range workflowDef.Workflow.Actions {
+		for _, role := range action.AttributeValidation.Attributes.Roles {
+			if !containsString(roles[role], action.Name) {
+				roles[role] = append(roles[role], action.Name)
+			}
+		}
+	}
+	return roles
+}
+
+// checkWorkflowRoles checks that the roles of a workflow exist as realm roles and that someone
+// holds them, creating the missing roles when createMissing is set
+func checkWorkflowRoles(resolver *roleResolver, workflowDef *WorkflowDefinition, createMissing bool) ([]workflowRoleCheck, error) {
+	existing, err := fetchRoleList(resolver.serverURL, resolver.jwtToken, resolver.realm, "")
+	if err != nil {
+		return nil, err
+	}
+	exists := map[string]bool{}
+	for _, role := range existing {
+		exists[role.Name] = true
+	}
+
+	roles := workflowRoles(workflowDef)
+	names := make([]string, 0, len(roles))
+	for name := range roles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	checks := make([]workflowRoleCheck, 0, len(names))
+	for _, name := range names {
+		check := workflowRoleCheck{Role: name, Actions: roles[name]}
+		switch {
+		case !exists[name] && createMissing:
+			description := fmt.Sprintf("Used by workflow %s", workflowDef.Workflow.Process.Code)
+			if _, err := digit.CreateRole(resolver.serverURL, resolver.jwtToken, resolver.realm, name, description); err != nil {
+				check.Status, check.Err = workflowRoleMissing, err
+			} else {
+				check.Status = workflowRoleCreated
+			}
+		case !exists[name]:
+			check.Status = workflowRoleMissing
+		default:
+			holders, err := roleHolders(resolver, "", name, false)
+			if err != nil {
+				return nil, err
+			}
+			check.Members = len(holders)
+			check.Status = workflowRoleOK
+			if check.Members == 0 {
+				check.Status = workflowRoleNoMembers
+			}
+		}
+		checks = append(checks, check)
+	}
+	return checks, nil
+}
+
+// reportWorkflowRoles prints the checked roles of a workflow, failing when roles are missing.
+// Roles without members are only reported: nobody can take their actions yet.
+func reportWorkflowRoles(checks []workflowRoleCheck, realm string) error {
+	if len(checks) == 0 {
+		fmt.Println("The workflow actions do not restrict roles")
+		return nil
+	}
+
+	var missing []string
+	noMembers := 0
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ROLE\tSTATUS\tMEMBERS\tACTIONS")
+	for _, check := range checks {
+		members := fmt.Sprint(check.Members)
+		if check.Status == workflowRoleMissing {
+			members = "-"
+		}
+		status := check.Status
+		if check.Err != nil {
+			status = fmt.Sprintf("%s (%v)", status, check.Err)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", check.Role, status, members, formatNames(check.Actions))
+		switch check.Status {
+		case workflowRoleMissing:
+			missing = append(missing, check.Role)
+		case workflowRoleNoMembers, workflowRoleCreated:
+			noMembers++
+		}
+	}
+	w.Flush()
+
+	if noMembers > 0 {
+		fmt.Printf("\n! %d roles have no members: nobody can take their actions yet\n", noMembers)
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("%d of %d roles are missing in account %s: %s", len(missing), len(checks), realm, formatNames(missing))
+	}
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(workflowCmd)
+}
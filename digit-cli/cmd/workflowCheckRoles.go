@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// workflowCheckRolesCmd represents the workflow check-roles command
+var workflowCheckRolesCmd = &cobra.Command{
+	Use:   "check-roles",
+	Short: "Check that the roles of a workflow exist and have members",
+	Long: `Check the roles allowed to take the actions of a workflow
+(attributeValidation.attributes.roles) against the realm roles of the account.
+
+Roles that do not exist fail the check; --create-missing creates them. Roles
+nobody holds, directly or through a composite role, are reported: nobody can
+take their actions until the role is assigned.
+
+Examples:
+  digit workflow check-roles -f workflow.yaml
+  digit workflow check-roles -f workflow.yaml --create-missing
+  digit workflow check-roles -f workflow.yaml --account PGR`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Get flag values
+		filePath, _ := cmd.Flags().GetString("file")
+		createMissing, _ := cmd.Flags().GetBool("create-missing")
+		realm, _ := cmd.Flags().GetString("account")
+		serverURL, _ := cmd.Flags().GetString("server")
+		jwtToken, _ := cmd.Flags().GetString("jwt-token")
+
+		yamlData, err := os.ReadFile(filePath)
+		if err != nil {
+			return fmt.Errorf("failed to read YAML file: %w", err)
+		}
+		var workflowDef WorkflowDefinition
+		if err := yaml.Unmarshal(yamlData, &workflowDef); err != nil {
+			return fmt.Errorf("failed to parse YAML: %w", err)
+		}
+
+		serverURL, jwtToken, realm, err = realmSettings(serverURL, jwtToken, realm)
+		if err != nil {
+			return err
+		}
+		checks, err := checkWorkflowRoles(newRoleResolver(serverURL, jwtToken, realm), &workflowDef, createMissing)
+		if err != nil {
+			return err
+		}
+		return reportWorkflowRoles(checks, realm)
+	},
+}
+
+func init() {
+	workflowCmd.AddCommand(workflowCheckRolesCmd)
+
+	// Add flags
+	workflowCheckRolesCmd.Flags().StringP("file", "f", "", "Path to YAML file containing workflow definition (required)")
+	workflowCheckRolesCmd.Flags().Bool("create-missing", false, "Create the roles that do not exist as realm roles")
+	workflowCheckRolesCmd.Flags().String("account", "", "Account (realm) (overrides config)")
+	workflowCheckRolesCmd.Flags().String("server", "", "Server URL (overrides config)")
+	workflowCheckRolesCmd.Flags().String("jwt-token", "", "JWT token for authentication (overrides config)")
+
+	// Mark required flags
+	workflowCheckRolesCmd.MarkFlagRequired("file")
+}
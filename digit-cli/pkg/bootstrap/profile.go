@@ -0,0 +1,249 @@
+package bootstrap
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ProfileFile is the name of the manifest in a profile directory
+const ProfileFile = "profile.yaml"
+
+// Profile declares a whole tenant: the account and its administrator login, the account
+// configuration, roles and users, and the files of the workflows, ID generation templates,
+// notification templates, MDMS schemas and boundaries to create in it
+type Profile struct {
+	Account               Account        `yaml:"account"`
+	Login                 Login          `yaml:"login"`
+	Config                *AccountConfig `yaml:"config"`
+	Roles                 []Role         `yaml:"roles"`
+	Users                 []User         `yaml:"users"`
+	Workflows             []string       `yaml:"workflows"`
+	IdGenTemplates        []string       `yaml:"idgen-templates"`
+	NotificationTemplates []string       `yaml:"notification-templates"`
+	Schemas               []string       `yaml:"schemas"`
+	Boundaries            []string       `yaml:"boundaries"`
+
+	// Dir is the profile directory; the files above are relative to it
+	Dir string `yaml:"-"`
+}
+
+// Account is the account (tenant) to create
+type Account struct {
+	Name     string `yaml:"name"`
+	Email    string `yaml:"email"`
+	Active   *bool  `yaml:"active"`
+	ClientID string `yaml:"client-id"`
+	// Code is only needed when the server does not return the code of the new account
+	Code string `yaml:"code"`
+}
+
+// Login is the administrator login of the new account, used for every step after the account is created
+type Login struct {
+	ClientID     string `yaml:"client-id"`
+	ClientSecret string `yaml:"client-secret"`
+	Username     string `yaml:"username"`
+	Password     string `yaml:"password"`
+}
+
+// AccountConfig is the login, OTP and language configuration of the account
+type AccountConfig struct {
+	Name             string                 `yaml:"name"`
+	DefaultLoginType string                 `yaml:"default-login-type"`
+	OtpLength        int                    `yaml:"otp-length"`
+	UserBasedLogin   bool                   `yaml:"user-based-login"`
+	Languages        []string               `yaml:"languages"`
+	Attributes       map[string]interface{} `yaml:"attributes"`
+}
+
+// Role is a role to create in the account
+type Role struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description"`
+}
+
+// User is a user to create in the account, with the roles to assign to it
+type User struct {
+	Username string   `yaml:"username"`
+	Password string   `yaml:"password"`
+	Email    string   `yaml:"email"`
+	Roles    []string `yaml:"roles"`
+}
+
+// IdGenTemplate is an ID generation template file, in the format of the embedded default of
+// 'digit create-idgen-template'
+type IdGenTemplate struct {
+	TemplateCode  string `yaml:"template-code"`
+	Template      string `yaml:"template"`
+	Scope         string `yaml:"scope"`
+	Start         int    `yaml:"start"`
+	PaddingLength int    `yaml:"padding-length"`
+	PaddingChar   string `yaml:"padding-char"`
+	RandomLength  int    `yaml:"random-length"`
+	RandomCharset string `yaml:"random-charset"`
+}
+
+// envReference matches a ${VAR} reference to an environment variable in a profile value
+var envReference = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// Load reads the profile in a directory, or the given profile file. References such as
+// ${ADMIN_PASSWORD} in the string values of the manifest are read from the environment, so
+// secrets need not be stored in it; a reference to an unset variable is an error.
+func Load(path string) (*Profile, error) {
+	dir := path
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read profile: %w", err)
+	}
+	if info.IsDir() {
+		path = filepath.Join(path, ProfileFile)
+	} else {
+		dir = filepath.Dir(path)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read profile: %w", err)
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	unset := map[string]bool{}
+	expandEnv(&doc, unset)
+	if len(unset) > 0 {
+		names := make([]string, 0, len(unset))
+		for name := range unset {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("%s: environment variable(s) %s not set", path, strings.Join(names, ", "))
+	}
+	var profile Profile
+	if err := doc.Decode(&profile); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	profile.Dir = dir
+	if err := profile.validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return &profile, nil
+}
+
+// expandEnv replaces the ${VAR} references in the string values under a node with the
+// environment variables, adding the names of unset variables to unset; keys, other text
+// and other $ signs are left as they are
+func expandEnv(node *yaml.Node, unset map[string]bool) {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		if node.Tag != "!!str" {
+			return
+		}
+		node.Value = envReference.ReplaceAllStringFunc(node.Value, func(reference string) string {
+			name := envReference.FindStringSubmatch(reference)[1]
+			value, ok := os.LookupEnv(name)
+			if !ok {
+				unset[name] = true
+			}
+			return value
+		})
+	case yaml.MappingNode:
+		for i := 1; i < len(node.Content); i += 2 {
+			expandEnv(node.Content[i], unset)
+		}
+	default:
+		for _, child := range node.Content {
+			expandEnv(child, unset)
+		}
+	}
+}
+
+// validate checks the required fields and fills in the defaults
+func (p *Profile) validate() error {
+	if p.Account.Name == "" {
+		return fmt.Errorf("account.name is required")
+	}
+	if p.Account.Email == "" {
+		return fmt.Errorf("account.email is required")
+	}
+	if p.Login.Username == "" {
+		p.Login.Username = p.Account.Email
+	}
+	if p.Login.ClientID == "" || p.Login.ClientSecret == "" || p.Login.Password == "" {
+		return fmt.Errorf("login.client-id, login.client-secret and login.password are required")
+	}
+	if p.Config != nil && p.Config.OtpLength < 0 {
+		return fmt.Errorf("config.otp-length cannot be negative")
+	}
+
+	roles := map[string]bool{}
+	for i, role := range p.Roles {
+		if role.Name == "" {
+			return fmt.Errorf("roles[%d]: name is required", i)
+		}
+		if roles[role.Name] {
+			return fmt.Errorf("role %s is declared twice", role.Name)
+		}
+		roles[role.Name] = true
+	}
+	users := map[string]bool{}
+	for i, user := range p.Users {
+		if user.Username == "" || user.Password == "" || user.Email == "" {
+			return fmt.Errorf("users[%d]: username, password and email are required", i)
+		}
+		if users[user.Username] {
+			return fmt.Errorf("user %s is declared twice", user.Username)
+		}
+		users[user.Username] = true
+	}
+
+	for _, files := range [][]string{p.Workflows, p.IdGenTemplates, p.NotificationTemplates, p.Schemas, p.Boundaries} {
+		for _, file := range files {
+			if _, err := os.Stat(p.Path(file)); err != nil {
+				return fmt.Errorf("file %s: %w", file, err)
+			}
+		}
+	}
+	return nil
+}
+
+// Path returns the path of a profile file
+func (p *Profile) Path(file string) string {
+	if filepath.IsAbs(file) {
+		return file
+	}
+	return filepath.Join(p.Dir, file)
+}
+
+// StatePath returns the path of the state file recording the progress of bootstrapping
+// the profile's account, kept next to the profile
+func (p *Profile) StatePath() string {
+	name := strings.Map(func(r rune) rune {
+		if r == '/' || r == '\\' || r == ' ' {
+			return '-'
+		}
+		return r
+	}, p.Account.Name)
+	return filepath.Join(p.Dir, ".bootstrap-"+name+".yaml")
+}
+
+// LoadIdGenTemplate reads an ID generation template file of the profile
+func (p *Profile) LoadIdGenTemplate(file string) (*IdGenTemplate, error) {
+	data, err := os.ReadFile(p.Path(file))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", file, err)
+	}
+	template := IdGenTemplate{Scope: "daily", Start: 1, PaddingLength: 4, PaddingChar: "0", RandomLength: 2, RandomCharset: "A-Z0-9"}
+	if err := yaml.Unmarshal(data, &template); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", file, err)
+	}
+	if template.TemplateCode == "" || template.Template == "" {
+		return nil, fmt.Errorf("%s: template-code and template are required", file)
+	}
+	return &template, nil
+}
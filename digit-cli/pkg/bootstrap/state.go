@@ -0,0 +1,83 @@
+package bootstrap
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// State records the steps of a bootstrap that are done and the IDs they created, so that
+// a bootstrap stopped by an error can be resumed where it stopped
+type State struct {
+	Account string          `yaml:"account"`
+	Code    string          `yaml:"code,omitempty"`
+	Done    []CompletedStep `yaml:"done"`
+	// Parts records what unfinished steps created so far, such as the process and states of a
+	// workflow, so that resuming such a step does not create them again
+	Parts map[string]map[string]string `yaml:"parts,omitempty"`
+}
+
+// CompletedStep is a step that is done
+type CompletedStep struct {
+	Step string    `yaml:"step"`
+	ID   string    `yaml:"id,omitempty"`
+	At   time.Time `yaml:"at"`
+}
+
+// LoadState reads a state file; a missing file is an empty state
+func LoadState(path string) (*State, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &State{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read state file: %w", err)
+	}
+	var state State
+	if err := yaml.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to parse state file %s: %w", path, err)
+	}
+	return &state, nil
+}
+
+// Save writes the state file
+func (s *State) Save(path string) error {
+	data, err := yaml.Marshal(s)
+	if err != nil {
+		return fmt.Errorf("failed to encode state: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write state file: %w", err)
+	}
+	return nil
+}
+
+// Completed returns the record of a step when it is done
+func (s *State) Completed(step Step) (CompletedStep, bool) {
+	for _, done := range s.Done {
+		if done.Step == step.Key() {
+			return done, true
+		}
+	}
+	return CompletedStep{}, false
+}
+
+// Complete records a step as done with the ID it created
+func (s *State) Complete(step Step, id string) {
+	s.Done = append(s.Done, CompletedStep{Step: step.Key(), ID: id, At: time.Now().UTC().Truncate(time.Second)})
+	delete(s.Parts, step.Key())
+}
+
+// StepParts returns the parts an unfinished step created so far by name, with their IDs; parts
+// added to the map are saved with the state
+func (s *State) StepParts(step Step) map[string]string {
+	if s.Parts == nil {
+		s.Parts = map[string]map[string]string{}
+	}
+	if s.Parts[step.Key()] == nil {
+		s.Parts[step.Key()] = map[string]string{}
+	}
+	return s.Parts[step.Key()]
+}
@@ -0,0 +1,110 @@
+package bootstrap
+
+import "fmt"
+
+// StepKind is the kind of a bootstrap step, named after the command that performs it
+type StepKind string
+
+const (
+	StepAccount              StepKind = "create-account"
+	StepLogin                StepKind = "config set"
+	StepAccountConfig        StepKind = "account config set"
+	StepRole                 StepKind = "create-role"
+	StepUser                 StepKind = "create-user"
+	StepAssignRole           StepKind = "assign-role"
+	StepWorkflow             StepKind = "create-workflow"
+	StepIdGenTemplate        StepKind = "create-idgen-template"
+	StepNotificationTemplate StepKind = "create-notification-template"
+	StepSchema               StepKind = "create-schema"
+	StepBoundaries           StepKind = "create-boundaries"
+)
+
+// Step is one step of bootstrapping a profile
+type Step struct {
+	Kind StepKind
+	// Name is the role, user or file the step creates
+	Name string
+	// Role is the role assigned by an assign-role step
+	Role string
+}
+
+// Key identifies the step in the state file, e.g. "create-role GRO"
+func (s Step) Key() string {
+	switch {
+	case s.Name == "":
+		return string(s.Kind)
+	case s.Role != "":
+		return fmt.Sprintf("%s %s %s", s.Kind, s.Name, s.Role)
+	}
+	return fmt.Sprintf("%s %s", s.Kind, s.Name)
+}
+
+// String describes the step, e.g. "assign-role GRO to gro1"
+func (s Step) String() string {
+	if s.Role != "" {
+		return fmt.Sprintf("%s %s to %s", s.Kind, s.Role, s.Name)
+	}
+	return s.Key()
+}
+
+// Recorded reports whether the step is recorded in the state file once done; the login
+// step is repeated on every run to get a fresh token
+func (s Step) Recorded() bool {
+	return s.Kind != StepLogin
+}
+
+// Steps returns the steps of the profile in dependency order: the account, the login to it,
+// its configuration, roles, users and their roles, and then everything that needs them
+func (p *Profile) Steps() []Step {
+	steps := []Step{{Kind: StepAccount}, {Kind: StepLogin}}
+	if p.Config != nil {
+		steps = append(steps, Step{Kind: StepAccountConfig})
+	}
+	for _, role := range p.Roles {
+		steps = append(steps, Step{Kind: StepRole, Name: role.Name})
+	}
+	for _, user := range p.Users {
+		steps = append(steps, Step{Kind: StepUser, Name: user.Username})
+	}
+	for _, user := range p.Users {
+		for _, role := range user.Roles {
+			steps = append(steps, Step{Kind: StepAssignRole, Name: user.Username, Role: role})
+		}
+	}
+	for _, file := range p.Workflows {
+		steps = append(steps, Step{Kind: StepWorkflow, Name: file})
+	}
+	for _, file := range p.IdGenTemplates {
+		steps = append(steps, Step{Kind: StepIdGenTemplate, Name: file})
+	}
+	for _, file := range p.NotificationTemplates {
+		steps = append(steps, Step{Kind: StepNotificationTemplate, Name: file})
+	}
+	for _, file := range p.Schemas {
+		steps = append(steps, Step{Kind: StepSchema, Name: file})
+	}
+	for _, file := range p.Boundaries {
+		steps = append(steps, Step{Kind: StepBoundaries, Name: file})
+	}
+	return steps
+}
+
+// User returns the declared user with the given username
+func (p *Profile) User(username string) *User {
+	for i := range p.Users {
+		if p.Users[i].Username == username {
+			return &p.Users[i]
+		}
+	}
+	return nil
+}
+
+// Role returns the declared role with the given name
+func (p *Profile) Role(name string) *Role {
+	for i := range p.Roles {
+		if p.Roles[i].Name == name {
+			return &p.Roles[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package boundary
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/digitnxt/digit3/code/libraries/digit-library/geometry"
+	"gopkg.in/yaml.v3"
+)
+
+// CheckOptions controls how CheckBoundaries prepares boundaries for upload
+type CheckOptions struct {
+	Repair    bool    // close rings, drop repeated positions and fix winding order
+	Tolerance float64 // Douglas–Peucker tolerance in metres, 0 to keep every position
+}
+
+// CheckResult is the outcome of checking one boundary
+type CheckResult struct {
+	Code           string
+	Changes        []string // repairs made to the geometry
+	Issues         []geometry.Issue
+	VerticesBefore int // positions before simplifying
+	VerticesAfter  int // positions after simplifying
+}
+
+// CheckBoundaries repairs and simplifies the geometries of the boundaries in place as requested,
+// then validates them. Boundaries sharing a code are reported as errors on each of them.
+func CheckBoundaries(boundaries []Boundary, opts CheckOptions) []CheckResult {
+	codes := map[string][]int{}
+	for i, b := range boundaries {
+		codes[b.Code] = append(codes[b.Code], i+1)
+	}
+
+	results := make([]CheckResult, 0, len(boundaries))
+	for i := range boundaries {
+		b := &boundaries[i]
+		result := CheckResult{Code: b.Code}
+		if positions := codes[b.Code]; len(positions) > 1 {
+			others := make([]string, 0, len(positions)-1)
+			for _, position := range positions {
+				if position != i+1 {
+					others = append(others, fmt.Sprint(position))
+				}
+			}
+			result.Issues = append(result.Issues, geometry.Issue{Severity: geometry.Error,
+				Message: fmt.Sprintf("duplicate code, also used by boundary %s", strings.Join(others, ", "))})
+		}
+		if b.Geometry == nil {
+			result.Issues = append(result.Issues, geometry.Issue{Severity: geometry.Error, Message: "boundary has no geometry"})
+			results = append(results, result)
+			continue
+		}
+
+		if opts.Repair {
+			result.Changes = geometry.Repair(b.Geometry)
+		}
+		result.VerticesBefore = b.Geometry.VertexCount()
+		if opts.Tolerance > 0 {
+			geometry.Simplify(b.Geometry, opts.Tolerance)
+		}
+		result.VerticesAfter = b.Geometry.VertexCount()
+		result.Issues = append(result.Issues, geometry.Validate(b.Geometry)...)
+		results = append(results, result)
+	}
+	return results
+}
+
+// CheckSummary counts the boundaries with errors and with warnings only
+func CheckSummary(results []CheckResult) (withErrors, withWarnings int) {
+	for _, result := range results {
+		switch {
+		case geometry.HasErrors(result.Issues):
+			withErrors++
+		case len(result.Issues) > 0:
+			withWarnings++
+		}
+	}
+	return withErrors, withWarnings
+}
+
+// SortIssues orders issues with errors first, keeping their order otherwise
+func SortIssues(issues []geometry.Issue) {
+	sort.SliceStable(issues, func(i, j int) bool { return issues[i].Severity > issues[j].Severity })
+}
+
+// WriteBoundaries writes boundaries to a file: a GeoJSON FeatureCollection for .geojson and .json
+// files, otherwise a boundaries YAML file as read by create-boundaries
+func WriteBoundaries(path string, boundaries []Boundary) error {
+	var data []byte
+	var err error
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".geojson", ".json":
+		collection := geometry.FeatureCollection{Features: make([]geometry.Feature, 0, len(boundaries))}
+		for _, b := range boundaries {
+			properties := map[string]interface{}{"code": b.Code}
+			for key, value := range b.AdditionalDetails {
+				if key != "code" {
+					properties[key] = value
+				}
+			}
+			collection.Features = append(collection.Features, geometry.Feature{Geometry: b.Geometry, Properties: properties})
+		}
+		data, err = json.MarshalIndent(collection, "", "  ")
+	default:
+		// Round-trip through JSON so that geometries are written in their GeoJSON form
+		entries := make([]map[string]interface{}, 0, len(boundaries))
+		for _, b := range boundaries {
+			entries = append(entries, b.Map())
+		}
+		var encoded []byte
+		if encoded, err = json.Marshal(entries); err == nil {
+			var plain []interface{}
+			if err = json.Unmarshal(encoded, &plain); err == nil {
+				data, err = yaml.Marshal(map[string]interface{}{"boundary": plain})
+			}
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("failed to encode boundaries: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,286 @@
+package boundary
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/digitnxt/digit3/code/libraries/digit-library/geometry"
+)
+
+// ReadFeatures reads the features of a GeoJSON (.geojson, .json), KML (.kml, .kmz) or
+// Shapefile (.shp, or a .zip containing one) file. Layer selects the shapefile to read
+// when a zip contains more than one.
+func ReadFeatures(path, layer string) ([]geometry.Feature, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".geojson", ".json":
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read GeoJSON file: %w", err)
+		}
+		return ParseGeoJSON(data)
+	case ".kml":
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read KML file: %w", err)
+		}
+		defer f.Close()
+		return ParseKML(f)
+	case ".kmz":
+		return readKMZ(path)
+	case ".shp":
+		shp, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read shapefile: %w", err)
+		}
+		base := strings.TrimSuffix(path, filepath.Ext(path))
+		dbf, err := readSibling(base, ".dbf")
+		if err != nil {
+			return nil, err
+		}
+		prj, _ := readSibling(base, ".prj")
+		return ParseShapefile(shp, dbf, prj)
+	case ".zip":
+		return readShapefileZip(path, layer)
+	}
+	return nil, fmt.Errorf("unsupported file type %s: expected .geojson, .json, .kml, .kmz, .shp or a zipped shapefile", filepath.Ext(path))
+}
+
+// readSibling reads the file next to a shapefile with the given extension, in either case
+func readSibling(base, ext string) ([]byte, error) {
+	for _, candidate := range []string{base + ext, base + strings.ToUpper(ext)} {
+		if data, err := os.ReadFile(candidate); err == nil {
+			return data, nil
+		}
+	}
+	return nil, fmt.Errorf("shapefile %s has no %s file", filepath.Base(base), ext)
+}
+
+func readKMZ(path string) ([]geometry.Feature, error) {
+	archive, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open KMZ file: %w", err)
+	}
+	defer archive.Close()
+
+	// The main document is doc.kml by convention, otherwise the first KML file
+	var doc *zip.File
+	for _, f := range archive.File {
+		if strings.EqualFold(filepath.Ext(f.Name), ".kml") && (doc == nil || strings.EqualFold(filepath.Base(f.Name), "doc.kml")) {
+			doc = f
+		}
+	}
+	if doc == nil {
+		return nil, fmt.Errorf("KMZ file contains no KML document")
+	}
+	r, err := doc.Open()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", doc.Name, err)
+	}
+	defer r.Close()
+	return ParseKML(r)
+}
+
+func readShapefileZip(path, layer string) ([]geometry.Feature, error) {
+	archive, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open zip file: %w", err)
+	}
+	defer archive.Close()
+
+	// Index the members by layer name and extension
+	files := map[string]map[string]*zip.File{}
+	for _, f := range archive.File {
+		name := filepath.Base(f.Name)
+		if strings.HasPrefix(name, ".") || strings.HasPrefix(f.Name, "__MACOSX/") {
+			continue
+		}
+		ext := strings.ToLower(filepath.Ext(name))
+		base := strings.TrimSuffix(name, filepath.Ext(name))
+		if files[base] == nil {
+			files[base] = map[string]*zip.File{}
+		}
+		files[base][ext] = f
+	}
+	var layers []string
+	for base, members := range files {
+		if members[".shp"] != nil {
+			layers = append(layers, base)
+		}
+	}
+	sort.Strings(layers)
+
+	switch {
+	case len(layers) == 0:
+		return nil, fmt.Errorf("zip file contains no shapefile")
+	case layer == "" && len(layers) > 1:
+		return nil, fmt.Errorf("zip file contains several shapefiles (%s); choose one with --layer", strings.Join(layers, ", "))
+	case layer == "":
+		layer = layers[0]
+	case files[layer] == nil || files[layer][".shp"] == nil:
+		return nil, fmt.Errorf("zip file has no shapefile %s; it contains %s", layer, strings.Join(layers, ", "))
+	}
+
+	members := files[layer]
+	if members[".dbf"] == nil {
+		return nil, fmt.Errorf("shapefile %s has no .dbf file", layer)
+	}
+	shp, err := readZipFile(members[".shp"])
+	if err != nil {
+		return nil, err
+	}
+	dbf, err := readZipFile(members[".dbf"])
+	if err != nil {
+		return nil, err
+	}
+	var prj []byte
+	if members[".prj"] != nil {
+		if prj, err = readZipFile(members[".prj"]); err != nil {
+			return nil, err
+		}
+	}
+	return ParseShapefile(shp, dbf, prj)
+}
+
+func readZipFile(f *zip.File) ([]byte, error) {
+	r, err := f.Open()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", f.Name, err)
+	}
+	defer r.Close()
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", f.Name, err)
+	}
+	return data, nil
+}
+
+// ImportOptions controls how features are mapped to boundaries
+type ImportOptions struct {
+	CodeProperty   string   // property holding the boundary code
+	ParentProperty string   // property holding the parent boundary code, if any
+	Properties     []string // properties copied to additionalDetails; all when empty
+}
+
+// Boundary is a boundary entity: a code, its geometry and additional details
+type Boundary struct {
+	Code              string
+	Geometry          *geometry.Geometry
+	AdditionalDetails map[string]interface{}
+}
+
+// Map returns the boundary in the form the boundary API accepts
+func (b Boundary) Map() map[string]interface{} {
+	details := b.AdditionalDetails
+	if details == nil {
+		details = map[string]interface{}{}
+	}
+	return map[string]interface{}{
+		"code":              b.Code,
+		"geometry":          b.Geometry,
+		"additionalDetails": details,
+	}
+}
+
+// BoundariesFromMaps converts boundary entities as read from a boundaries YAML file to boundaries
+func BoundariesFromMaps(entries []map[string]interface{}) ([]Boundary, error) {
+	boundaries := make([]Boundary, 0, len(entries))
+	for i, entry := range entries {
+		code := PropertyString(entry["code"])
+		if code == "" {
+			return nil, fmt.Errorf("boundary %d has no code", i+1)
+		}
+		g, err := geometry.FromValue(entry["geometry"])
+		if err != nil {
+			return nil, fmt.Errorf("boundary %s: %w", code, err)
+		}
+		details, _ := entry["additionalDetails"].(map[string]interface{})
+		boundaries = append(boundaries, Boundary{Code: code, Geometry: g, AdditionalDetails: details})
+	}
+	return boundaries, nil
+}
+
+// BuildBoundaries maps features to boundaries with a code, geometry and additionalDetails.
+// When a parent property is given, the parent code of each boundary is returned by code;
+// features with an empty parent property have no parent.
+func BuildBoundaries(features []geometry.Feature, opts ImportOptions) ([]Boundary, map[string]string, error) {
+	if opts.CodeProperty == "" {
+		return nil, nil, fmt.Errorf("code property cannot be empty")
+	}
+	if len(features) == 0 {
+		return nil, nil, fmt.Errorf("file contains no features")
+	}
+
+	boundaries := make([]Boundary, 0, len(features))
+	parents := map[string]string{}
+	for i, feature := range features {
+		code := PropertyString(feature.Properties[opts.CodeProperty])
+		if code == "" && opts.CodeProperty == "id" {
+			code = PropertyString(feature.ID)
+		}
+		if code == "" {
+			return nil, nil, fmt.Errorf("feature %d has no %s property; available properties are %s",
+				i+1, opts.CodeProperty, strings.Join(propertyNames(feature.Properties), ", "))
+		}
+		if feature.Geometry == nil {
+			return nil, nil, fmt.Errorf("feature %s has no geometry", code)
+		}
+
+		details := map[string]interface{}{}
+		if len(opts.Properties) == 0 {
+			for key, value := range feature.Properties {
+				details[key] = value
+			}
+		} else {
+			for _, key := range opts.Properties {
+				if value, ok := feature.Properties[key]; ok {
+					details[key] = value
+				}
+			}
+		}
+
+		if opts.ParentProperty != "" {
+			if parent := PropertyString(feature.Properties[opts.ParentProperty]); parent != "" {
+				if parent == code {
+					return nil, nil, fmt.Errorf("feature %s is its own parent", code)
+				}
+				parents[code] = parent
+			}
+		}
+
+		boundaries = append(boundaries, Boundary{Code: code, Geometry: feature.Geometry, AdditionalDetails: details})
+	}
+	return boundaries, parents, nil
+}
+
+// PropertyString formats a property value as text; whole numbers are formatted without decimals
+func PropertyString(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return strings.TrimSpace(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	}
+	return fmt.Sprint(value)
+}
+
+func propertyNames(properties map[string]interface{}) []string {
+	names := make([]string, 0, len(properties))
+	for name := range properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	if len(names) == 0 {
+		return []string{"none"}
+	}
+	return names
+}
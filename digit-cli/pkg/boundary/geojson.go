@@ -0,0 +1,41 @@
+package boundary
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/digitnxt/digit3/code/libraries/digit-library/geometry"
+)
+
+// ParseGeoJSON parses a GeoJSON FeatureCollection, a single Feature or a list of features.
+// Coordinates must be longitude/latitude (WGS 84), the only reference system GeoJSON allows;
+// files declaring another legacy "crs" are rejected.
+func ParseGeoJSON(data []byte) ([]geometry.Feature, error) {
+	var header struct {
+		CRS struct {
+			Properties struct {
+				Name string `json:"name"`
+			} `json:"properties"`
+		} `json:"crs"`
+	}
+	// Lists of features have no header
+	if json.Unmarshal(data, &header) == nil {
+		name := header.CRS.Properties.Name
+		upper := strings.ToUpper(name)
+		if name != "" && !strings.HasSuffix(upper, "CRS84") && !strings.HasSuffix(upper, ":4326") {
+			return nil, fmt.Errorf("GeoJSON uses coordinate reference system %s; reproject it to WGS 84 (EPSG:4326) first", name)
+		}
+	}
+
+	features, err := geometry.ParseFeatures(data)
+	if err != nil {
+		return nil, err
+	}
+	for i := range features {
+		if features[i].Properties == nil {
+			features[i].Properties = map[string]interface{}{}
+		}
+	}
+	return features, nil
+}
@@ -0,0 +1,257 @@
+package boundary
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Level is one boundary type of a hierarchy. The top level has no parent.
+type Level struct {
+	BoundaryType       string
+	ParentBoundaryType string
+	Active             bool
+}
+
+// Hierarchy is a boundary hierarchy definition such as ADMIN: State > District > Ward
+type Hierarchy struct {
+	Type   string
+	Levels []Level
+}
+
+// ChainLevels builds the levels of a linear hierarchy from its boundary types, top level first
+func ChainLevels(boundaryTypes []string) []Level {
+	levels := make([]Level, 0, len(boundaryTypes))
+	parent := ""
+	for _, boundaryType := range boundaryTypes {
+		levels = append(levels, Level{BoundaryType: boundaryType, ParentBoundaryType: parent, Active: true})
+		parent = boundaryType
+	}
+	return levels
+}
+
+// OrderLevels checks that levels form a single tree of boundary types and returns them
+// ordered so that every level comes after its parent
+func OrderLevels(levels []Level) ([]Level, error) {
+	if len(levels) == 0 {
+		return nil, fmt.Errorf("hierarchy has no boundary types")
+	}
+
+	byType := map[string]Level{}
+	children := map[string][]Level{}
+	var roots []Level
+	for _, level := range levels {
+		if level.BoundaryType == "" {
+			return nil, fmt.Errorf("boundary type cannot be empty")
+		}
+		if _, ok := byType[level.BoundaryType]; ok {
+			return nil, fmt.Errorf("boundary type %s is defined more than once", level.BoundaryType)
+		}
+		byType[level.BoundaryType] = level
+		if level.ParentBoundaryType == "" {
+			roots = append(roots, level)
+		} else {
+			children[level.ParentBoundaryType] = append(children[level.ParentBoundaryType], level)
+		}
+	}
+	for _, level := range levels {
+		if level.ParentBoundaryType != "" {
+			if _, ok := byType[level.ParentBoundaryType]; !ok {
+				return nil, fmt.Errorf("boundary type %s has unknown parent %s", level.BoundaryType, level.ParentBoundaryType)
+			}
+		}
+	}
+	if len(roots) != 1 {
+		names := make([]string, 0, len(roots))
+		for _, root := range roots {
+			names = append(names, root.BoundaryType)
+		}
+		if len(names) == 0 {
+			return nil, fmt.Errorf("hierarchy has no top-level boundary type")
+		}
+		return nil, fmt.Errorf("hierarchy must have one top-level boundary type, found %s", strings.Join(names, ", "))
+	}
+
+	ordered := make([]Level, 0, len(levels))
+	queue := roots
+	for len(queue) > 0 {
+		level := queue[0]
+		queue = append(queue[1:], children[level.BoundaryType]...)
+		ordered = append(ordered, level)
+	}
+	if len(ordered) != len(levels) {
+		return nil, fmt.Errorf("hierarchy contains a cycle of parent boundary types")
+	}
+	return ordered, nil
+}
+
+// Parent returns the parent boundary type of a boundary type and whether the type is part of the hierarchy
+func (h *Hierarchy) Parent(boundaryType string) (string, bool) {
+	for _, level := range h.Levels {
+		if level.BoundaryType == boundaryType {
+			return level.ParentBoundaryType, true
+		}
+	}
+	return "", false
+}
+
+// Format returns the hierarchy as "State > District > Ward", with branches in brackets
+func (h *Hierarchy) Format() string {
+	children := map[string][]string{}
+	root := ""
+	for _, level := range h.Levels {
+		if level.ParentBoundaryType == "" {
+			root = level.BoundaryType
+		} else {
+			children[level.ParentBoundaryType] = append(children[level.ParentBoundaryType], level.BoundaryType)
+		}
+	}
+	var format func(boundaryType string) string
+	format = func(boundaryType string) string {
+		switch len(children[boundaryType]) {
+		case 0:
+			return boundaryType
+		case 1:
+			return boundaryType + " > " + format(children[boundaryType][0])
+		}
+		branches := make([]string, 0, len(children[boundaryType]))
+		for _, child := range children[boundaryType] {
+			branches = append(branches, format(child))
+		}
+		return boundaryType + " > [" + strings.Join(branches, " | ") + "]"
+	}
+	return format(root)
+}
+
+// ParseHierarchy parses a boundary hierarchy definition response. The definition may be
+// the response itself or wrapped in an object or list.
+func ParseHierarchy(responseBody string) (*Hierarchy, error) {
+	var decoded interface{}
+	if err := json.Unmarshal([]byte(responseBody), &decoded); err != nil {
+		return nil, fmt.Errorf("failed to parse boundary hierarchy response: %w", err)
+	}
+
+	obj := findObject(decoded, "boundaryHierarchy")
+	if obj == nil {
+		return nil, fmt.Errorf("boundary hierarchy response has no hierarchy definition")
+	}
+
+	hierarchy := &Hierarchy{}
+	hierarchy.Type, _ = obj["hierarchyType"].(string)
+	items, _ := obj["boundaryHierarchy"].([]interface{})
+	for _, item := range items {
+		levelObj, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		level := Level{Active: true}
+		level.BoundaryType, _ = levelObj["boundaryType"].(string)
+		level.ParentBoundaryType, _ = levelObj["parentBoundaryType"].(string)
+		if active, ok := levelObj["active"].(bool); ok {
+			level.Active = active
+		}
+		hierarchy.Levels = append(hierarchy.Levels, level)
+	}
+	if ordered, err := OrderLevels(hierarchy.Levels); err == nil {
+		hierarchy.Levels = ordered
+	}
+	return hierarchy, nil
+}
+
+// findObject returns the first object with the given key, searching wrapped lists and objects
+func findObject(value interface{}, key string) map[string]interface{} {
+	switch v := value.(type) {
+	case []interface{}:
+		for _, item := range v {
+			if obj := findObject(item, key); obj != nil {
+				return obj
+			}
+		}
+	case map[string]interface{}:
+		if _, ok := v[key]; ok {
+			return v
+		}
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			if obj := findObject(v[k], key); obj != nil {
+				return obj
+			}
+		}
+	}
+	return nil
+}
+
+// LevelSpec is a boundary type as declared in a hierarchy file
+type LevelSpec struct {
+	BoundaryType string `yaml:"boundary-type"`
+	Parent       string `yaml:"parent"`
+	Active       *bool  `yaml:"active"`
+}
+
+// RelationshipSpec is a boundary linked to its parent, as declared in a hierarchy file
+type RelationshipSpec struct {
+	Code         string `yaml:"code"`
+	BoundaryType string `yaml:"boundary-type"`
+	Parent       string `yaml:"parent"`
+}
+
+// File is the layout of a hierarchy file: a hierarchy definition and the boundaries linked into it
+type File struct {
+	HierarchyType string             `yaml:"hierarchy-type"`
+	Levels        []LevelSpec        `yaml:"levels"`
+	Relationships []RelationshipSpec `yaml:"relationships"`
+}
+
+// LoadFile reads a hierarchy file
+func LoadFile(path string) (*File, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read hierarchy file: %w", err)
+	}
+
+	var file File
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse hierarchy file: %w", err)
+	}
+	if file.HierarchyType == "" {
+		return nil, fmt.Errorf("hierarchy file has no hierarchy-type")
+	}
+	return &file, nil
+}
+
+// Hierarchy returns the hierarchy declared in the file. A level without a parent is placed
+// under the level declared before it, so a plain list of types declares a linear hierarchy.
+func (f *File) Hierarchy() (*Hierarchy, error) {
+	if len(f.Levels) == 0 {
+		return nil, fmt.Errorf("hierarchy file has no levels")
+	}
+
+	levels := make([]Level, 0, len(f.Levels))
+	previous := ""
+	for _, spec := range f.Levels {
+		parent := spec.Parent
+		if parent == "" {
+			parent = previous
+		}
+		levels = append(levels, Level{
+			BoundaryType:       spec.BoundaryType,
+			ParentBoundaryType: parent,
+			Active:             spec.Active == nil || *spec.Active,
+		})
+		previous = spec.BoundaryType
+	}
+
+	ordered, err := OrderLevels(levels)
+	if err != nil {
+		return nil, fmt.Errorf("hierarchy %s: %w", f.HierarchyType, err)
+	}
+	return &Hierarchy{Type: f.HierarchyType, Levels: ordered}, nil
+}
@@ -0,0 +1,183 @@
+package boundary
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/digitnxt/digit3/code/libraries/digit-library/geometry"
+)
+
+// kmlGeometry holds the geometries of a Placemark or MultiGeometry
+type kmlGeometry struct {
+	Points        []kmlCoordinates `xml:"Point"`
+	LineStrings   []kmlCoordinates `xml:"LineString"`
+	Polygons      []kmlPolygon     `xml:"Polygon"`
+	MultiGeometry []kmlGeometry    `xml:"MultiGeometry"`
+}
+
+type kmlCoordinates struct {
+	Coordinates string `xml:"coordinates"`
+}
+
+type kmlPolygon struct {
+	Outer string   `xml:"outerBoundaryIs>LinearRing>coordinates"`
+	Inner []string `xml:"innerBoundaryIs>LinearRing>coordinates"`
+}
+
+type kmlPlacemark struct {
+	ID           string `xml:"id,attr"`
+	Name         string `xml:"name"`
+	Description  string `xml:"description"`
+	ExtendedData struct {
+		Data []struct {
+			Name  string `xml:"name,attr"`
+			Value string `xml:"value"`
+		} `xml:"Data"`
+		SimpleData []struct {
+			Name  string `xml:"name,attr"`
+			Value string `xml:",chardata"`
+		} `xml:"SchemaData>SimpleData"`
+	} `xml:"ExtendedData"`
+	kmlGeometry
+}
+
+// ParseKML reads the Placemarks of a KML document, wherever they are nested in Documents and Folders.
+// Extended data becomes the feature's properties, together with its name and description.
+func ParseKML(r io.Reader) ([]geometry.Feature, error) {
+	decoder := xml.NewDecoder(r)
+	var features []geometry.Feature
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse KML: %w", err)
+		}
+		start, ok := token.(xml.StartElement)
+		if !ok || start.Name.Local != "Placemark" {
+			continue
+		}
+
+		var placemark kmlPlacemark
+		if err := decoder.DecodeElement(&placemark, &start); err != nil {
+			return nil, fmt.Errorf("failed to parse KML placemark %d: %w", len(features)+1, err)
+		}
+
+		feature := geometry.Feature{Properties: map[string]interface{}{}}
+		if placemark.ID != "" {
+			feature.ID = placemark.ID
+		}
+		if name := strings.TrimSpace(placemark.Name); name != "" {
+			feature.Properties["name"] = name
+		}
+		if description := strings.TrimSpace(placemark.Description); description != "" {
+			feature.Properties["description"] = description
+		}
+		for _, data := range placemark.ExtendedData.Data {
+			feature.Properties[data.Name] = strings.TrimSpace(data.Value)
+		}
+		for _, data := range placemark.ExtendedData.SimpleData {
+			feature.Properties[data.Name] = strings.TrimSpace(data.Value)
+		}
+
+		if feature.Geometry, err = placemark.kmlGeometry.geoJSON(); err != nil {
+			return nil, fmt.Errorf("KML placemark %d: %w", len(features)+1, err)
+		}
+		features = append(features, feature)
+	}
+	return features, nil
+}
+
+// geoJSON converts the geometries to a GeoJSON geometry, or nil when there are none.
+// Several polygons become a MultiPolygon; other combinations become a GeometryCollection.
+func (g kmlGeometry) geoJSON() (*geometry.Geometry, error) {
+	var geometries []geometry.Geometry
+	var polygons []geometry.Polygon
+	for _, point := range g.Points {
+		positions, err := parseKMLCoordinates(point.Coordinates)
+		if err != nil {
+			return nil, err
+		}
+		if len(positions) != 1 {
+			return nil, fmt.Errorf("point has %d positions", len(positions))
+		}
+		geometries = append(geometries, geometry.Geometry{Type: geometry.TypePoint, Point: positions[0]})
+	}
+	for _, line := range g.LineStrings {
+		positions, err := parseKMLCoordinates(line.Coordinates)
+		if err != nil {
+			return nil, err
+		}
+		geometries = append(geometries, geometry.Geometry{Type: geometry.TypeLineString, Positions: positions})
+	}
+	for _, polygon := range g.Polygons {
+		outer, err := parseKMLCoordinates(polygon.Outer)
+		if err != nil {
+			return nil, err
+		}
+		rings := geometry.Polygon{outer}
+		for _, inner := range polygon.Inner {
+			ring, err := parseKMLCoordinates(inner)
+			if err != nil {
+				return nil, err
+			}
+			rings = append(rings, ring)
+		}
+		polygons = append(polygons, rings)
+	}
+	for _, multi := range g.MultiGeometry {
+		nested, err := multi.geoJSON()
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case nested == nil:
+		case nested.Type == geometry.TypePolygon:
+			polygons = append(polygons, nested.Polygon)
+		case nested.Type == geometry.TypeMultiPolygon:
+			polygons = append(polygons, nested.MultiPolygon...)
+		default:
+			geometries = append(geometries, *nested)
+		}
+	}
+
+	switch {
+	case len(polygons) == 1 && len(geometries) == 0:
+		return &geometry.Geometry{Type: geometry.TypePolygon, Polygon: polygons[0]}, nil
+	case len(polygons) > 1 && len(geometries) == 0:
+		return &geometry.Geometry{Type: geometry.TypeMultiPolygon, MultiPolygon: polygons}, nil
+	case len(polygons) == 0 && len(geometries) == 1:
+		return &geometries[0], nil
+	case len(polygons) == 0 && len(geometries) == 0:
+		return nil, nil
+	}
+
+	collection := make([]geometry.Geometry, 0, len(geometries)+len(polygons))
+	for _, polygon := range polygons {
+		collection = append(collection, geometry.Geometry{Type: geometry.TypePolygon, Polygon: polygon})
+	}
+	collection = append(collection, geometries...)
+	return &geometry.Geometry{Type: geometry.TypeGeometryCollection, Geometries: collection}, nil
+}
+
+// parseKMLCoordinates parses whitespace-separated "lon,lat[,alt]" tuples; altitudes are dropped
+func parseKMLCoordinates(text string) (geometry.Ring, error) {
+	var positions geometry.Ring
+	for _, tuple := range strings.Fields(text) {
+		parts := strings.Split(tuple, ",")
+		if len(parts) < 2 {
+			return nil, fmt.Errorf("invalid KML coordinate %q", tuple)
+		}
+		lon, errLon := strconv.ParseFloat(parts[0], 64)
+		lat, errLat := strconv.ParseFloat(parts[1], 64)
+		if errLon != nil || errLat != nil {
+			return nil, fmt.Errorf("invalid KML coordinate %q", tuple)
+		}
+		positions = append(positions, geometry.Position{lon, lat})
+	}
+	return positions, nil
+}
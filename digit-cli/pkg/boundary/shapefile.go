@@ -0,0 +1,314 @@
+package boundary
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/digitnxt/digit3/code/libraries/digit-library/geometry"
+)
+
+// Shapefile shape types; the Z and M variants share the X/Y layout of the plain types
+const (
+	shapeNull       = 0
+	shapePoint      = 1
+	shapePolyLine   = 3
+	shapePolygon    = 5
+	shapeMultiPoint = 8
+	shapeMultiPatch = 31
+)
+
+// baseShapeTypes maps each supported shape type, including the Z (11-18) and M (21-28)
+// variants, to the plain type whose X/Y layout it shares
+var baseShapeTypes = map[int]int{
+	shapeNull:       shapeNull,
+	shapePoint:      shapePoint,
+	shapePolyLine:   shapePolyLine,
+	shapePolygon:    shapePolygon,
+	shapeMultiPoint: shapeMultiPoint,
+	11:              shapePoint,
+	13:              shapePolyLine,
+	15:              shapePolygon,
+	18:              shapeMultiPoint,
+	21:              shapePoint,
+	23:              shapePolyLine,
+	25:              shapePolygon,
+	28:              shapeMultiPoint,
+}
+
+// ParseShapefile reads the features of a shapefile from the contents of its .shp and .dbf files.
+// The optional .prj must describe geographic (longitude/latitude) coordinates, since boundaries
+// are stored in WGS 84; projected shapefiles have to be reprojected first.
+func ParseShapefile(shp, dbf, prj []byte) ([]geometry.Feature, error) {
+	if bytes.Contains(bytes.ToUpper(prj), []byte("PROJCS")) {
+		return nil, fmt.Errorf("shapefile uses projected coordinates; reproject it to WGS 84 (EPSG:4326) first")
+	}
+
+	geometries, err := parseShapes(shp)
+	if err != nil {
+		return nil, err
+	}
+	records, err := parseDBF(dbf)
+	if err != nil {
+		return nil, err
+	}
+	if len(records) != len(geometries) {
+		return nil, fmt.Errorf("shapefile has %d shapes but %d attribute records", len(geometries), len(records))
+	}
+
+	features := make([]geometry.Feature, len(geometries))
+	for i := range geometries {
+		features[i] = geometry.Feature{Geometry: geometries[i], Properties: records[i]}
+	}
+	return features, nil
+}
+
+// parseShapes reads the geometries of a .shp file; null shapes have a nil geometry
+func parseShapes(data []byte) ([]*geometry.Geometry, error) {
+	if len(data) < 100 || binary.BigEndian.Uint32(data[0:4]) != 9994 {
+		return nil, fmt.Errorf("not a valid .shp file")
+	}
+
+	var geometries []*geometry.Geometry
+	for offset := 100; offset+8 <= len(data); {
+		length := int(binary.BigEndian.Uint32(data[offset+4:offset+8])) * 2
+		start := offset + 8
+		end := start + length
+		if length < 4 || end > len(data) {
+			return nil, fmt.Errorf("shapefile record %d is truncated", len(geometries)+1)
+		}
+		shape, err := parseShape(data[start:end])
+		if err != nil {
+			return nil, fmt.Errorf("shapefile record %d: %w", len(geometries)+1, err)
+		}
+		geometries = append(geometries, shape)
+		offset = end
+	}
+	return geometries, nil
+}
+
+func parseShape(record []byte) (*geometry.Geometry, error) {
+	rawType := int(binary.LittleEndian.Uint32(record[0:4]))
+	if rawType == shapeMultiPatch {
+		return nil, fmt.Errorf("multipatch shapes are not supported")
+	}
+	shapeType, ok := baseShapeTypes[rawType]
+	if !ok {
+		return nil, fmt.Errorf("unsupported shape type %d", rawType)
+	}
+
+	point := func(at int) geometry.Position {
+		return geometry.Position{
+			math.Float64frombits(binary.LittleEndian.Uint64(record[at : at+8])),
+			math.Float64frombits(binary.LittleEndian.Uint64(record[at+8 : at+16])),
+		}
+	}
+
+	switch shapeType {
+	case shapeNull:
+		return nil, nil
+	case shapePoint:
+		if len(record) < 20 {
+			return nil, fmt.Errorf("point is truncated")
+		}
+		return &geometry.Geometry{Type: geometry.TypePoint, Point: point(4)}, nil
+	case shapeMultiPoint:
+		if len(record) < 40 {
+			return nil, fmt.Errorf("multipoint is truncated")
+		}
+		numPoints := int(binary.LittleEndian.Uint32(record[36:40]))
+		if len(record) < 40+numPoints*16 {
+			return nil, fmt.Errorf("multipoint is truncated")
+		}
+		points := make(geometry.Ring, numPoints)
+		for i := range points {
+			points[i] = point(40 + i*16)
+		}
+		return &geometry.Geometry{Type: geometry.TypeMultiPoint, Positions: points}, nil
+	case shapePolyLine, shapePolygon:
+		if len(record) < 44 {
+			return nil, fmt.Errorf("shape is truncated")
+		}
+		numParts := int(binary.LittleEndian.Uint32(record[36:40]))
+		numPoints := int(binary.LittleEndian.Uint32(record[40:44]))
+		pointsAt := 44 + numParts*4
+		if len(record) < pointsAt+numPoints*16 {
+			return nil, fmt.Errorf("shape is truncated")
+		}
+		parts := make([]geometry.Ring, numParts)
+		for i := range parts {
+			first := int(binary.LittleEndian.Uint32(record[44+i*4:]))
+			last := numPoints
+			if i+1 < numParts {
+				last = int(binary.LittleEndian.Uint32(record[44+(i+1)*4:]))
+			}
+			if first > last || last > numPoints {
+				return nil, fmt.Errorf("shape has invalid part offsets")
+			}
+			for j := first; j < last; j++ {
+				parts[i] = append(parts[i], point(pointsAt+j*16))
+			}
+		}
+		if shapeType == shapePolygon {
+			return assemblePolygons(parts), nil
+		}
+		if len(parts) == 1 {
+			return &geometry.Geometry{Type: geometry.TypeLineString, Positions: parts[0]}, nil
+		}
+		return &geometry.Geometry{Type: geometry.TypeMultiLineString, Lines: parts}, nil
+	}
+	return nil, fmt.Errorf("unsupported shape type %d", rawType)
+}
+
+// assemblePolygons groups shapefile rings into polygons. Shapefile outer rings are clockwise and
+// holes counter-clockwise; each hole goes to the outer ring containing it, and the rings are
+// reversed to the GeoJSON convention of counter-clockwise outer rings.
+func assemblePolygons(rings []geometry.Ring) *geometry.Geometry {
+	var polygons []geometry.Polygon
+	var holes []geometry.Ring
+	for _, ring := range rings {
+		if ringArea(ring) <= 0 {
+			polygons = append(polygons, geometry.Polygon{reverseRing(ring)})
+		} else {
+			holes = append(holes, reverseRing(ring))
+		}
+	}
+	if len(polygons) == 0 {
+		// Counter-clockwise outer rings from a non-conforming writer
+		for _, hole := range holes {
+			polygons = append(polygons, geometry.Polygon{reverseRing(hole)})
+		}
+		holes = nil
+	}
+	for _, hole := range holes {
+		owner := len(polygons) - 1
+		for i, polygon := range polygons {
+			if len(hole) > 0 && pointInRing(hole[0], polygon[0]) {
+				owner = i
+				break
+			}
+		}
+		polygons[owner] = append(polygons[owner], hole)
+	}
+
+	if len(polygons) == 1 {
+		return &geometry.Geometry{Type: geometry.TypePolygon, Polygon: polygons[0]}
+	}
+	return &geometry.Geometry{Type: geometry.TypeMultiPolygon, MultiPolygon: polygons}
+}
+
+// ringArea returns the signed area of a ring: positive when counter-clockwise
+func ringArea(ring geometry.Ring) float64 {
+	area := 0.0
+	for i := range ring {
+		j := (i + 1) % len(ring)
+		area += ring[i][0]*ring[j][1] - ring[j][0]*ring[i][1]
+	}
+	return area / 2
+}
+
+func reverseRing(ring geometry.Ring) geometry.Ring {
+	reversed := make(geometry.Ring, len(ring))
+	for i, position := range ring {
+		reversed[len(ring)-1-i] = position
+	}
+	return reversed
+}
+
+// pointInRing reports whether a point lies inside a ring, by ray casting
+func pointInRing(point geometry.Position, ring geometry.Ring) bool {
+	inside := false
+	for i, j := 0, len(ring)-1; i < len(ring); j, i = i, i+1 {
+		xi, yi := ring[i][0], ring[i][1]
+		xj, yj := ring[j][0], ring[j][1]
+		if (yi > point[1]) != (yj > point[1]) && point[0] < (xj-xi)*(point[1]-yi)/(yj-yi)+xi {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// parseDBF reads the attribute records of a dBASE file. Numeric fields become numbers,
+// logical fields booleans and empty fields nil; deleted records are kept so that records
+// stay aligned with shapes.
+func parseDBF(data []byte) ([]map[string]interface{}, error) {
+	if len(data) < 32 {
+		return nil, fmt.Errorf("not a valid .dbf file")
+	}
+	numRecords := int(binary.LittleEndian.Uint32(data[4:8]))
+	headerLength := int(binary.LittleEndian.Uint16(data[8:10]))
+	recordLength := int(binary.LittleEndian.Uint16(data[10:12]))
+	if headerLength > len(data) || recordLength == 0 {
+		return nil, fmt.Errorf("not a valid .dbf file")
+	}
+
+	type field struct {
+		name     string
+		kind     byte
+		length   int
+		decimals int
+	}
+	var fields []field
+	for at := 32; at+32 <= headerLength && data[at] != 0x0D; at += 32 {
+		name := string(bytes.TrimRight(data[at:at+11], "\x00 "))
+		fields = append(fields, field{name: name, kind: data[at+11], length: int(data[at+16]), decimals: int(data[at+17])})
+	}
+
+	records := make([]map[string]interface{}, 0, numRecords)
+	for i := 0; i < numRecords; i++ {
+		start := headerLength + i*recordLength
+		if start+recordLength > len(data) {
+			return nil, fmt.Errorf(".dbf file is truncated at record %d", i+1)
+		}
+		record := map[string]interface{}{}
+		at := start + 1 // deletion flag
+		for _, f := range fields {
+			if at+f.length > start+recordLength {
+				return nil, fmt.Errorf(".dbf record %d is shorter than its fields", i+1)
+			}
+			raw := strings.TrimSpace(decodeDBFText(data[at : at+f.length]))
+			at += f.length
+
+			switch {
+			case raw == "":
+				record[f.name] = nil
+			case f.kind == 'N' || f.kind == 'F':
+				if number, err := strconv.ParseFloat(raw, 64); err == nil {
+					record[f.name] = number
+				} else {
+					record[f.name] = nil
+				}
+			case f.kind == 'L':
+				switch raw {
+				case "T", "t", "Y", "y":
+					record[f.name] = true
+				case "F", "f", "N", "n":
+					record[f.name] = false
+				default:
+					record[f.name] = nil
+				}
+			default:
+				record[f.name] = raw
+			}
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// decodeDBFText decodes a text field as UTF-8, falling back to Latin-1 for older files
+func decodeDBFText(raw []byte) string {
+	raw = bytes.TrimRight(raw, "\x00")
+	if utf8.Valid(raw) {
+		return string(raw)
+	}
+	runes := make([]rune, len(raw))
+	for i, b := range raw {
+		runes[i] = rune(b)
+	}
+	return string(runes)
+}
@@ -0,0 +1,262 @@
+package boundary
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// Node is a boundary in a hierarchy tree
+type Node struct {
+	ID           string
+	Code         string
+	Name         string
+	BoundaryType string
+	Parent       string
+	Children     []*Node
+}
+
+// ParseTree parses a boundary relationship search response into the root boundaries of the tree.
+// Children may be nested boundary objects or boundary codes, and boundaries may instead refer
+// to their parent; all three are combined. Roots and children are sorted by code.
+func ParseTree(responseBody string) ([]*Node, error) {
+	var decoded interface{}
+	if err := json.Unmarshal([]byte(responseBody), &decoded); err != nil {
+		return nil, fmt.Errorf("failed to parse boundary relationship response: %w", err)
+	}
+
+	nodes := map[string]*Node{}
+	var order []string
+	node := func(code string) *Node {
+		if n, ok := nodes[code]; ok {
+			return n
+		}
+		n := &Node{Code: code}
+		nodes[code] = n
+		order = append(order, code)
+		return n
+	}
+
+	var collect func(value interface{}, parent string)
+	collect = func(value interface{}, parent string) {
+		switch v := value.(type) {
+		case []interface{}:
+			for _, item := range v {
+				collect(item, parent)
+			}
+		case string:
+			// A child given only by its code
+			if parent != "" {
+				node(v).Parent = parent
+			}
+		case map[string]interface{}:
+			code, ok := v["code"].(string)
+			if !ok {
+				keys := make([]string, 0, len(v))
+				for key := range v {
+					keys = append(keys, key)
+				}
+				sort.Strings(keys)
+				for _, key := range keys {
+					collect(v[key], "")
+				}
+				return
+			}
+			n := node(code)
+			for _, field := range []struct {
+				target *string
+				keys   []string
+			}{
+				{&n.ID, []string{"id"}},
+				{&n.Name, []string{"name"}},
+				{&n.BoundaryType, []string{"boundaryType", "type"}},
+				{&n.Parent, []string{"parent"}},
+			} {
+				for _, key := range field.keys {
+					if value, ok := v[key].(string); ok && value != "" && *field.target == "" {
+						*field.target = value
+					}
+				}
+			}
+			if parent != "" && n.Parent == "" {
+				n.Parent = parent
+			}
+			collect(v["children"], code)
+		}
+	}
+	collect(decoded, "")
+
+	var roots []*Node
+	for _, code := range order {
+		n := nodes[code]
+		if parent, ok := nodes[n.Parent]; ok && n.Parent != code {
+			parent.Children = append(parent.Children, n)
+		} else {
+			roots = append(roots, n)
+		}
+	}
+	sortNodes(roots)
+	return roots, nil
+}
+
+func sortNodes(nodes []*Node) {
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].Code < nodes[j].Code })
+	for _, n := range nodes {
+		sortNodes(n.Children)
+	}
+}
+
+// Find returns the boundary with the given code, or nil
+func Find(roots []*Node, code string) *Node {
+	for _, n := range roots {
+		if n.Code == code {
+			return n
+		}
+		if found := Find(n.Children, code); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// RenderTree writes the boundaries as an indented tree, e.g.
+//
+//	KA [State]
+//	├── BLR Bengaluru [District]
+//	│   └── W001 [Ward]
+//	└── MYS [District]
+//
+// A maxDepth of 0 prints the whole tree.
+func RenderTree(w io.Writer, roots []*Node, maxDepth int) {
+	var render func(nodes []*Node, prefix string, depth int)
+	render = func(nodes []*Node, prefix string, depth int) {
+		if maxDepth > 0 && depth >= maxDepth {
+			return
+		}
+		for i, n := range nodes {
+			branch, indent := "├── ", "│   "
+			if i == len(nodes)-1 {
+				branch, indent = "└── ", "    "
+			}
+			fmt.Fprintf(w, "%s%s%s\n", prefix, branch, nodeLabel(n))
+			render(n.Children, prefix+indent, depth+1)
+		}
+	}
+	for _, root := range roots {
+		fmt.Fprintln(w, nodeLabel(root))
+		render(root.Children, "", 1)
+	}
+}
+
+func nodeLabel(n *Node) string {
+	label := n.Code
+	if n.Name != "" && n.Name != n.Code {
+		label += " " + n.Name
+	}
+	if n.BoundaryType != "" {
+		label += " [" + n.BoundaryType + "]"
+	}
+	return label
+}
+
+// Flatten returns every boundary in the tree by code
+func Flatten(roots []*Node) map[string]*Node {
+	nodes := map[string]*Node{}
+	var walk func(nodes []*Node)
+	walk = func(list []*Node) {
+		for _, n := range list {
+			nodes[n.Code] = n
+			walk(n.Children)
+		}
+	}
+	walk(roots)
+	return nodes
+}
+
+// CountNodes returns the number of boundaries in the tree
+func CountNodes(roots []*Node) int {
+	count := 0
+	for _, n := range roots {
+		count += 1 + CountNodes(n.Children)
+	}
+	return count
+}
+
+// OrderRelationships checks that each boundary is declared once and returns the relationships
+// ordered so that a boundary comes after its parent when the parent is declared too
+func OrderRelationships(specs []RelationshipSpec) ([]RelationshipSpec, error) {
+	byCode := map[string]RelationshipSpec{}
+	for i, spec := range specs {
+		if spec.Code == "" {
+			return nil, fmt.Errorf("relationship %d: code is required", i+1)
+		}
+		if spec.BoundaryType == "" {
+			return nil, fmt.Errorf("boundary %s: boundary-type is required", spec.Code)
+		}
+		if _, ok := byCode[spec.Code]; ok {
+			return nil, fmt.Errorf("boundary %s is declared more than once", spec.Code)
+		}
+		byCode[spec.Code] = spec
+	}
+
+	ordered := make([]RelationshipSpec, 0, len(specs))
+	state := map[string]int{} // 1 while visiting, 2 once ordered
+	var visit func(spec RelationshipSpec) error
+	visit = func(spec RelationshipSpec) error {
+		switch state[spec.Code] {
+		case 1:
+			return fmt.Errorf("boundary %s is its own ancestor", spec.Code)
+		case 2:
+			return nil
+		}
+		state[spec.Code] = 1
+		if parent, ok := byCode[spec.Parent]; ok {
+			if err := visit(parent); err != nil {
+				return err
+			}
+		}
+		state[spec.Code] = 2
+		ordered = append(ordered, spec)
+		return nil
+	}
+	for _, spec := range specs {
+		if err := visit(spec); err != nil {
+			return nil, err
+		}
+	}
+	return ordered, nil
+}
+
+// CheckRelationships checks relationships against a hierarchy: every boundary type must be part of it,
+// top-level boundaries must not have a parent, other boundaries must, and a parent must be of the
+// parent boundary type. Parents are looked up among the relationships and then among linked, the
+// boundary types of boundaries already in the hierarchy by code. All violations are returned together.
+func CheckRelationships(hierarchy *Hierarchy, specs []RelationshipSpec, linked map[string]string) error {
+	types := map[string]string{}
+	for code, boundaryType := range linked {
+		types[code] = boundaryType
+	}
+	for _, spec := range specs {
+		types[spec.Code] = spec.BoundaryType
+	}
+
+	var errs []error
+	for _, spec := range specs {
+		parentType, ok := hierarchy.Parent(spec.BoundaryType)
+		switch {
+		case !ok:
+			errs = append(errs, fmt.Errorf("boundary %s: boundary type %s is not part of hierarchy %s", spec.Code, spec.BoundaryType, hierarchy.Type))
+		case parentType == "" && spec.Parent != "":
+			errs = append(errs, fmt.Errorf("boundary %s: %s is the top level of hierarchy %s and cannot have a parent", spec.Code, spec.BoundaryType, hierarchy.Type))
+		case parentType != "" && spec.Parent == "":
+			errs = append(errs, fmt.Errorf("boundary %s: a %s needs a parent %s", spec.Code, spec.BoundaryType, parentType))
+		case spec.Parent != "" && types[spec.Parent] == "":
+			errs = append(errs, fmt.Errorf("boundary %s: parent %s is not linked into hierarchy %s", spec.Code, spec.Parent, hierarchy.Type))
+		case spec.Parent != "" && types[spec.Parent] != parentType:
+			errs = append(errs, fmt.Errorf("boundary %s: parent %s is a %s, but a %s must be under a %s", spec.Code, spec.Parent, types[spec.Parent], spec.BoundaryType, parentType))
+		}
+	}
+	return errors.Join(errs...)
+}
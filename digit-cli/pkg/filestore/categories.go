@@ -0,0 +1,88 @@
+package filestore
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// CategorySpec is a document category as declared in a categories file
+type CategorySpec struct {
+	Type           string   `yaml:"type"`
+	Code           string   `yaml:"code"`
+	AllowedFormats []string `yaml:"allowed-formats"`
+	MinSize        string   `yaml:"min-size"`
+	MaxSize        string   `yaml:"max-size"`
+	Sensitive      bool     `yaml:"sensitive"`
+	Active         *bool    `yaml:"active"`
+	Description    string   `yaml:"description"`
+}
+
+// CategoriesFile is the layout of a categories file
+type CategoriesFile struct {
+	DocumentCategories []CategorySpec `yaml:"document-categories"`
+}
+
+// LoadCategorySpecs reads the document categories declared in a YAML file
+func LoadCategorySpecs(path string) ([]CategorySpec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read categories file: %w", err)
+	}
+
+	var file CategoriesFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse categories file: %w", err)
+	}
+	if len(file.DocumentCategories) == 0 {
+		return nil, fmt.Errorf("categories file has no document-categories")
+	}
+
+	seen := map[string]bool{}
+	for i, spec := range file.DocumentCategories {
+		if spec.Code == "" {
+			return nil, fmt.Errorf("document category %d: code is required", i+1)
+		}
+		if spec.Type == "" {
+			return nil, fmt.Errorf("document category %s: type is required", spec.Code)
+		}
+		if len(spec.AllowedFormats) == 0 {
+			return nil, fmt.Errorf("document category %s: allowed-formats is required", spec.Code)
+		}
+		if seen[spec.Code] {
+			return nil, fmt.Errorf("document category %s is declared more than once", spec.Code)
+		}
+		seen[spec.Code] = true
+		for j, format := range spec.AllowedFormats {
+			file.DocumentCategories[i].AllowedFormats[j] = strings.ToLower(strings.TrimSpace(format))
+		}
+	}
+	return file.DocumentCategories, nil
+}
+
+// Sizes returns the parsed minimum and maximum sizes of the category in bytes
+func (s CategorySpec) Sizes() (int64, int64, error) {
+	var minSize, maxSize int64
+	var err error
+	if s.MinSize != "" {
+		if minSize, err = ParseSize(s.MinSize); err != nil {
+			return 0, 0, fmt.Errorf("document category %s: min-size: %w", s.Code, err)
+		}
+	}
+	if s.MaxSize != "" {
+		if maxSize, err = ParseSize(s.MaxSize); err != nil {
+			return 0, 0, fmt.Errorf("document category %s: max-size: %w", s.Code, err)
+		}
+	}
+	if maxSize > 0 && minSize > maxSize {
+		return 0, 0, fmt.Errorf("document category %s: min-size %s is larger than max-size %s", s.Code, s.MinSize, s.MaxSize)
+	}
+	return minSize, maxSize, nil
+}
+
+// IsActive reports whether the category should be active; categories are active unless declared otherwise
+func (s CategorySpec) IsActive() bool {
+	return s.Active == nil || *s.Active
+}
@@ -0,0 +1,195 @@
+package filestore
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FileInfo is the metadata of a stored file
+type FileInfo struct {
+	ID          string
+	Name        string
+	Category    string
+	ContentType string
+	Size        int64
+	Checksum    string
+}
+
+// ParseFileInfo extracts file metadata from an upload or metadata response.
+// The response may be the file object itself or an object wrapping a list of files,
+// in which case the first file is used.
+func ParseFileInfo(responseBody string) (*FileInfo, error) {
+	var decoded interface{}
+	if err := json.Unmarshal([]byte(responseBody), &decoded); err != nil {
+		return nil, fmt.Errorf("failed to parse filestore response: %w", err)
+	}
+
+	obj := firstObject(decoded)
+	if obj == nil {
+		return nil, fmt.Errorf("unexpected filestore response")
+	}
+
+	info := &FileInfo{
+		ID:          firstString(obj, "fileStoreId", "fileId", "id"),
+		Name:        firstString(obj, "fileName", "name"),
+		Category:    firstString(obj, "category", "documentCategory", "categoryCode"),
+		ContentType: firstString(obj, "contentType", "mimeType"),
+		Checksum:    firstString(obj, "checksum", "sha256", "md5", "etag"),
+	}
+	for _, key := range []string{"size", "fileSize", "contentLength"} {
+		switch v := obj[key].(type) {
+		case float64:
+			info.Size = int64(v)
+		case string:
+			info.Size, _ = strconv.ParseInt(v, 10, 64)
+		}
+		if info.Size > 0 {
+			break
+		}
+	}
+	if info.ID == "" {
+		return nil, fmt.Errorf("filestore response has no file ID")
+	}
+	return info, nil
+}
+
+// firstObject returns the object itself, or the first object of the first list it wraps
+func firstObject(value interface{}) map[string]interface{} {
+	switch v := value.(type) {
+	case []interface{}:
+		if len(v) > 0 {
+			return firstObject(v[0])
+		}
+	case map[string]interface{}:
+		for _, key := range []string{"fileStoreId", "fileId", "id"} {
+			if _, ok := v[key]; ok {
+				return v
+			}
+		}
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			switch v[key].(type) {
+			case []interface{}, map[string]interface{}:
+				if obj := firstObject(v[key]); obj != nil {
+					return obj
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func firstString(obj map[string]interface{}, keys ...string) string {
+	for _, key := range keys {
+		if value, ok := obj[key].(string); ok && value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// ChecksumMatches compares a checksum reported by the server with the local SHA-256 and MD5 digests
+// (hex encoded). The algorithm is inferred from the length of the reported value; known is false
+// when the reported checksum is empty or in an unrecognised format.
+func ChecksumMatches(reported, sha256Hex, md5Hex string) (match, known bool) {
+	reported = strings.ToLower(strings.Trim(reported, `" `))
+	if i := strings.Index(reported, ":"); i >= 0 {
+		reported = reported[i+1:]
+	}
+	switch len(reported) {
+	case 64:
+		return reported == sha256Hex, true
+	case 32:
+		return reported == md5Hex, true
+	}
+	return false, false
+}
+
+// ParseSize parses a size such as 500KB, 10 MB, 1.5GB or 2048 into bytes.
+// Units are binary (1KB = 1024 bytes), case-insensitive, and a number without a unit is in bytes.
+func ParseSize(value string) (int64, error) {
+	text := strings.ToUpper(strings.TrimSpace(value))
+	number := strings.TrimRight(text, "KMGTIB ")
+	unit := strings.TrimSpace(text[len(number):])
+	multipliers := map[string]int64{
+		"": 1, "B": 1,
+		"K": 1 << 10, "KB": 1 << 10, "KIB": 1 << 10,
+		"M": 1 << 20, "MB": 1 << 20, "MIB": 1 << 20,
+		"G": 1 << 30, "GB": 1 << 30, "GIB": 1 << 30,
+		"T": 1 << 40, "TB": 1 << 40, "TIB": 1 << 40,
+	}
+	multiplier, ok := multipliers[unit]
+	if !ok || number == "" {
+		return 0, fmt.Errorf("invalid size %q, expected a number with an optional unit such as 500KB or 10MB", value)
+	}
+	amount, err := strconv.ParseFloat(number, 64)
+	if err != nil || amount < 0 {
+		return 0, fmt.Errorf("invalid size %q, expected a number with an optional unit such as 500KB or 10MB", value)
+	}
+	return int64(amount * float64(multiplier)), nil
+}
+
+// FormatSize formats a byte count with binary units, e.g. 1536 as "1.5 KB"
+func FormatSize(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+	value := float64(size) / unit
+	for _, suffix := range []string{"KB", "MB", "GB"} {
+		if value < unit {
+			return strconv.FormatFloat(value, 'f', 1, 64) + " " + suffix
+		}
+		value /= unit
+	}
+	return strconv.FormatFloat(value, 'f', 1, 64) + " TB"
+}
+
+// Progress prints the progress of a transfer to Out. Bytes are counted as they are written to it,
+// so it can be combined with the destination in an io.MultiWriter or wrapped around a reader with Reader.
+type Progress struct {
+	Out   io.Writer
+	Label string
+	Total int64 // total size in bytes, or 0 when unknown
+
+	done    int64
+	printed time.Time
+}
+
+// Write counts the transferred bytes and updates the progress line at most every 100ms
+func (p *Progress) Write(buf []byte) (int, error) {
+	p.done += int64(len(buf))
+	if time.Since(p.printed) >= 100*time.Millisecond {
+		p.printed = time.Now()
+		p.print()
+	}
+	return len(buf), nil
+}
+
+// Reader returns a reader that reports progress as r is read
+func (p *Progress) Reader(r io.Reader) io.Reader {
+	return io.TeeReader(r, p)
+}
+
+// Finish prints the final progress line
+func (p *Progress) Finish() {
+	p.print()
+	fmt.Fprintln(p.Out)
+}
+
+func (p *Progress) print() {
+	if p.Total > 0 {
+		fmt.Fprintf(p.Out, "\r%s %3d%% (%s / %s)", p.Label, p.done*100/p.Total, FormatSize(p.done), FormatSize(p.Total))
+	} else {
+		fmt.Fprintf(p.Out, "\r%s %s", p.Label, FormatSize(p.done))
+	}
+}
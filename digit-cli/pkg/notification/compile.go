@@ -0,0 +1,82 @@
+package notification
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ContentSlot is the partial name a layout uses to mark where the template content goes
+const ContentSlot = "content"
+
+// partialTag matches mustache partial tags such as {{> header}}
+var partialTag = regexp.MustCompile(`\{\{>\s*([^}\s]+)\s*\}\}`)
+
+// ExpandPartials replaces every {{> name}} tag with the named partial, expanding nested partials.
+// The result contains only syntax the notification service understands.
+func ExpandPartials(content string, partials map[string]string) (string, error) {
+	return expandPartials(content, partials, nil)
+}
+
+func expandPartials(content string, partials map[string]string, chain []string) (string, error) {
+	var expandErr error
+	expanded := partialTag.ReplaceAllStringFunc(content, func(tag string) string {
+		if expandErr != nil {
+			return tag
+		}
+		name := partialTag.FindStringSubmatch(tag)[1]
+		for _, seen := range chain {
+			if seen == name {
+				expandErr = fmt.Errorf("partial %s includes itself (%s)", name, strings.Join(append(chain, name), " -> "))
+				return tag
+			}
+		}
+		partial, ok := partials[name]
+		if !ok {
+			expandErr = fmt.Errorf("partial %s is not defined", name)
+			return tag
+		}
+		result, err := expandPartials(partial, partials, append(chain, name))
+		if err != nil {
+			expandErr = err
+			return tag
+		}
+		return result
+	})
+	if expandErr != nil {
+		return "", expandErr
+	}
+	return expanded, nil
+}
+
+// Compile builds the final template content: partials are expanded in the content and, when a
+// layout is given, the content is placed at the layout's {{> content}} slot.
+func Compile(layout, content string, partials map[string]string) (string, error) {
+	if _, reserved := partials[ContentSlot]; reserved {
+		return "", fmt.Errorf("partial name %q is reserved for the layout content slot", ContentSlot)
+	}
+
+	body, err := ExpandPartials(content, partials)
+	if err != nil {
+		return "", err
+	}
+	if layout == "" {
+		return body, nil
+	}
+
+	hasSlot := false
+	for _, match := range partialTag.FindAllStringSubmatch(layout, -1) {
+		if match[1] == ContentSlot {
+			hasSlot = true
+		}
+	}
+	if !hasSlot {
+		return "", fmt.Errorf("layout has no {{> %s}} slot", ContentSlot)
+	}
+	withSlot := make(map[string]string, len(partials)+1)
+	for name, partial := range partials {
+		withSlot[name] = partial
+	}
+	withSlot[ContentSlot] = body
+	return ExpandPartials(layout, withSlot)
+}
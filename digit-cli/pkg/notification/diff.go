@@ -0,0 +1,116 @@
+package notification
+
+import (
+	"fmt"
+	"strings"
+)
+
+// UnifiedDiff returns a line-based unified diff of a and b with the given number of context lines.
+// It returns an empty string when the texts are equal.
+func UnifiedDiff(fromLabel, toLabel, a, b string, context int) string {
+	if a == b {
+		return ""
+	}
+	linesA := splitLines(a)
+	linesB := splitLines(b)
+
+	// Longest common subsequence table, filled from the end
+	lcs := make([][]int, len(linesA)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(linesB)+1)
+	}
+	for i := len(linesA) - 1; i >= 0; i-- {
+		for j := len(linesB) - 1; j >= 0; j-- {
+			if linesA[i] == linesB[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	// Walk the table into an edit script
+	type edit struct {
+		op   byte // ' ', '-' or '+'
+		text string
+		a, b int // line indexes in a and b before this edit
+	}
+	var edits []edit
+	i, j := 0, 0
+	for i < len(linesA) || j < len(linesB) {
+		switch {
+		case i < len(linesA) && j < len(linesB) && linesA[i] == linesB[j]:
+			edits = append(edits, edit{' ', linesA[i], i, j})
+			i++
+			j++
+		case j >= len(linesB) || (i < len(linesA) && lcs[i+1][j] >= lcs[i][j+1]):
+			edits = append(edits, edit{'-', linesA[i], i, j})
+			i++
+		default:
+			edits = append(edits, edit{'+', linesB[j], i, j})
+			j++
+		}
+	}
+
+	var out strings.Builder
+	fmt.Fprintf(&out, "--- %s\n+++ %s\n", fromLabel, toLabel)
+	for start := 0; start < len(edits); {
+		if edits[start].op == ' ' {
+			start++
+			continue
+		}
+		// Extend the hunk while changes are within 2*context lines of each other
+		first := start - context
+		if first < 0 {
+			first = 0
+		}
+		last := start
+		for k := start; k < len(edits); k++ {
+			if edits[k].op != ' ' {
+				last = k
+			} else if k-last > 2*context {
+				break
+			}
+		}
+		end := last + context + 1
+		if end > len(edits) {
+			end = len(edits)
+		}
+
+		countA, countB := 0, 0
+		for _, e := range edits[first:end] {
+			if e.op != '+' {
+				countA++
+			}
+			if e.op != '-' {
+				countB++
+			}
+		}
+		fmt.Fprintf(&out, "@@ -%s +%s @@\n", hunkRange(edits[first].a, countA), hunkRange(edits[first].b, countB))
+		for _, e := range edits[first:end] {
+			fmt.Fprintf(&out, "%c%s\n", e.op, e.text)
+		}
+		start = end
+	}
+	return out.String()
+}
+
+// hunkRange formats a unified diff range; start is zero-based
+func hunkRange(start, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", start)
+	}
+	if count == 1 {
+		return fmt.Sprintf("%d", start+1)
+	}
+	return fmt.Sprintf("%d,%d", start+1, count)
+}
+
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
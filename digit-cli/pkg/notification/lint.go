@@ -0,0 +1,486 @@
+package notification
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Severity represents how serious a lint finding is
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityInfo    Severity = "info"
+)
+
+// Finding represents a single lint result with its location in the template
+type Finding struct {
+	Severity Severity
+	Field    string // subject, content or variables
+	Line     int
+	Message  string
+}
+
+// String formats the finding as "field:line: severity: message"
+func (f Finding) String() string {
+	if f.Line > 0 {
+		return fmt.Sprintf("%s:%d: %s: %s", f.Field, f.Line, f.Severity, f.Message)
+	}
+	return fmt.Sprintf("%s: %s: %s", f.Field, f.Severity, f.Message)
+}
+
+// Template is the part of a template configuration the linter inspects
+type Template struct {
+	Type      string
+	Subject   string
+	Content   string
+	HTML      bool
+	Variables []string // declared placeholder names; nil when not declared
+}
+
+// HasErrors reports whether any finding has error severity
+func HasErrors(findings []Finding) bool {
+	for _, f := range findings {
+		if f.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// Lint checks a notification template for placeholder, HTML and SMS problems
+func Lint(t Template) []Finding {
+	l := &templateLinter{}
+
+	isSMS := strings.EqualFold(t.Type, "SMS")
+	if strings.TrimSpace(t.Content) == "" {
+		l.add(SeverityError, "content", 0, "content is empty")
+	}
+	if !isSMS && strings.TrimSpace(t.Subject) == "" {
+		l.add(SeverityError, "subject", 0, "subject is empty")
+	}
+
+	subjectNodes, err := Parse(t.Subject)
+	if err != nil {
+		l.addParseError("subject", err)
+	}
+	contentNodes, err := Parse(t.Content)
+	if err != nil {
+		l.addParseError("content", err)
+	}
+
+	// Unused variables cannot be judged when a field failed to parse
+	parsed := (subjectNodes != nil || t.Subject == "") && (contentNodes != nil || t.Content == "")
+	l.lintVariables(t.Variables, map[string][]*Node{"subject": subjectNodes, "content": contentNodes}, parsed)
+
+	if t.HTML {
+		if isSMS {
+			l.add(SeverityWarning, "content", 0, "SMS templates cannot contain HTML; set html: false")
+		}
+		l.lintHTML(t.Content)
+	} else if looksLikeHTML(t.Content) {
+		l.add(SeverityWarning, "content", 0, "content looks like HTML but html is false")
+	}
+
+	if isSMS && contentNodes != nil {
+		l.lintSMS(contentNodes)
+	}
+
+	fieldOrder := map[string]int{"subject": 0, "content": 1, "variables": 2}
+	sort.SliceStable(l.findings, func(i, j int) bool {
+		if l.findings[i].Field != l.findings[j].Field {
+			return fieldOrder[l.findings[i].Field] < fieldOrder[l.findings[j].Field]
+		}
+		return l.findings[i].Line < l.findings[j].Line
+	})
+	return l.findings
+}
+
+type templateLinter struct {
+	findings []Finding
+}
+
+func (l *templateLinter) add(sev Severity, field string, line int, format string, args ...interface{}) {
+	l.findings = append(l.findings, Finding{Severity: sev, Field: field, Line: line, Message: fmt.Sprintf(format, args...)})
+}
+
+// addParseError records a parse error, moving its "line N:" prefix into the finding location
+func (l *templateLinter) addParseError(field string, err error) {
+	var line int
+	var message string
+	if n, _ := fmt.Sscanf(err.Error(), "line %d:", &line); n == 1 {
+		message = strings.TrimSpace(strings.SplitN(err.Error(), ":", 2)[1])
+	} else {
+		message = err.Error()
+	}
+	l.add(SeverityError, field, line, "%s", message)
+}
+
+// lintVariables compares the placeholders used in the template with the declared variable list.
+// Names used inside sections resolve against the section items and are not checked.
+func (l *templateLinter) lintVariables(declared []string, fields map[string][]*Node, checkUnused bool) {
+	used := map[string]bool{}
+	for _, field := range []string{"subject", "content"} {
+		for _, node := range fields[field] {
+			name := placeholderName(node)
+			if name == "" {
+				continue
+			}
+			if declared != nil && !declaredVariable(declared, name) && !used[name] {
+				l.add(SeverityError, field, node.Line, "placeholder %s is not declared in variables", displayName(node))
+			}
+			used[name] = true
+		}
+	}
+
+	if declared == nil {
+		if len(used) > 0 {
+			l.add(SeverityWarning, "variables", 0, "no variables declared; add a variables list to check placeholders")
+		}
+		return
+	}
+	if !checkUnused {
+		return
+	}
+	for _, name := range declared {
+		found := false
+		for usedName := range used {
+			if strings.EqualFold(usedName, name) {
+				found = true
+			}
+		}
+		if !found {
+			l.add(SeverityWarning, "variables", 0, "variable %s is declared but not used", name)
+		}
+	}
+}
+
+// placeholderName returns the top-level payload field a node reads, or "" for text
+func placeholderName(node *Node) string {
+	switch node.Kind {
+	case NodeVariable, NodeSection, NodeInverted:
+		if node.Name == "." {
+			return ""
+		}
+		return strings.SplitN(node.Name, ".", 2)[0]
+	case NodeMarker:
+		return node.Name
+	}
+	return ""
+}
+
+func displayName(node *Node) string {
+	if node.Kind == NodeMarker {
+		return "[" + node.Name + "]"
+	}
+	return "{{" + node.Name + "}}"
+}
+
+// declaredVariable matches case-insensitively so [USERNAME] markers can be declared as username
+func declaredVariable(declared []string, name string) bool {
+	for _, d := range declared {
+		if strings.EqualFold(d, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func looksLikeHTML(content string) bool {
+	lower := strings.ToLower(content)
+	return strings.Contains(lower, "<html") || strings.Contains(lower, "<body") || strings.Contains(lower, "<br") || strings.Contains(lower, "</")
+}
+
+// voidElements never have closing tags
+var voidElements = map[string]bool{
+	"area": true, "base": true, "br": true, "col": true, "embed": true, "hr": true, "img": true,
+	"input": true, "link": true, "meta": true, "source": true, "track": true, "wbr": true,
+}
+
+// optionalClose lists elements whose closing tag may be omitted
+var optionalClose = map[string]bool{
+	"p": true, "li": true, "dt": true, "dd": true, "option": true, "tr": true, "td": true, "th": true,
+	"thead": true, "tbody": true, "tfoot": true, "colgroup": true,
+}
+
+// blockedElements are removed or disabled by most email clients
+var blockedElements = map[string]string{
+	"script": "scripts are stripped by email clients",
+	"iframe": "iframes are blocked by email clients",
+	"object": "embedded objects are blocked by email clients",
+	"embed":  "embedded content is blocked by email clients",
+	"form":   "forms are disabled by many email clients",
+	"video":  "video is not supported by most email clients",
+	"audio":  "audio is not supported by most email clients",
+}
+
+var (
+	tagNamePattern  = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9-]*`)
+	attrPattern     = regexp.MustCompile(`([^\s"'=<>/]+)(?:\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s"'=<>` + "`" + `]+)))?`)
+	cssImportURL    = regexp.MustCompile(`(?i)@import\s+(?:url\()?\s*["']?(https?:)?//`)
+	cssRemoteURL    = regexp.MustCompile(`(?i)url\(\s*["']?(https?:)?//`)
+	remoteURLPrefix = regexp.MustCompile(`(?i)^(https?:)?//`)
+)
+
+type openElement struct {
+	name string
+	line int
+}
+
+// lintHTML checks that the HTML is well formed and flags resources email clients block
+func (l *templateLinter) lintHTML(content string) {
+	var stack []openElement
+	line := 1
+	i := 0
+	advance := func(to int) {
+		line += strings.Count(content[i:to], "\n")
+		i = to
+	}
+
+	for i < len(content) {
+		next := strings.IndexByte(content[i:], '<')
+		if next < 0 {
+			break
+		}
+		advance(i + next)
+		rest := content[i:]
+
+		switch {
+		case strings.HasPrefix(rest, "<!--"):
+			end := strings.Index(rest, "-->")
+			if end < 0 {
+				l.add(SeverityError, "content", line, "unterminated comment")
+				return
+			}
+			advance(i + end + 3)
+			continue
+		case strings.HasPrefix(rest, "<!") || strings.HasPrefix(rest, "<?"):
+			end := strings.IndexByte(rest, '>')
+			if end < 0 {
+				l.add(SeverityError, "content", line, "unterminated declaration")
+				return
+			}
+			advance(i + end + 1)
+			continue
+		}
+
+		closing := strings.HasPrefix(rest, "</")
+		nameStart := 1
+		if closing {
+			nameStart = 2
+		}
+		name := strings.ToLower(tagNamePattern.FindString(rest[nameStart:]))
+		if name == "" {
+			l.add(SeverityWarning, "content", line, "unescaped '<'; use &lt;")
+			advance(i + 1)
+			continue
+		}
+
+		end := tagEnd(rest)
+		if end < 0 {
+			l.add(SeverityError, "content", line, "tag <%s> is not terminated", name)
+			return
+		}
+		tagLine := line
+		tag := rest[:end+1]
+		advance(i + end + 1)
+
+		if closing {
+			stack = l.closeElement(stack, name, tagLine)
+			continue
+		}
+
+		attrs := l.parseAttributes(tag[nameStart+len(name):len(tag)-1], name, tagLine)
+		l.lintElement(name, attrs, tagLine)
+
+		// Opening some elements implicitly closes an open sibling
+		if len(stack) > 0 {
+			top := stack[len(stack)-1].name
+			if (name == top && (name == "p" || name == "li" || name == "option" || name == "dt" || name == "dd")) ||
+				((name == "td" || name == "th") && (top == "td" || top == "th")) {
+				stack = stack[:len(stack)-1]
+			} else if name == "tr" {
+				for len(stack) > 0 && (stack[len(stack)-1].name == "td" || stack[len(stack)-1].name == "th" || stack[len(stack)-1].name == "tr") {
+					stack = stack[:len(stack)-1]
+				}
+			}
+		}
+
+		selfClosing := strings.HasSuffix(strings.TrimSpace(tag[:len(tag)-1]), "/")
+		if voidElements[name] || selfClosing {
+			continue
+		}
+
+		// Raw text elements end at their closing tag
+		if name == "script" || name == "style" {
+			closeIdx := strings.Index(strings.ToLower(content[i:]), "</"+name)
+			if closeIdx < 0 {
+				l.add(SeverityError, "content", tagLine, "<%s> opened here is never closed", name)
+				return
+			}
+			if name == "style" {
+				l.lintCSS(content[i:i+closeIdx], line)
+			}
+			advance(i + closeIdx)
+		}
+		stack = append(stack, openElement{name: name, line: tagLine})
+	}
+
+	for _, open := range stack {
+		if !optionalClose[open.name] {
+			l.add(SeverityError, "content", open.line, "<%s> is never closed", open.name)
+		}
+	}
+}
+
+// tagEnd returns the index of the '>' ending the tag at the start of s, honouring quoted attributes
+func tagEnd(s string) int {
+	var quote byte
+	for j := 1; j < len(s); j++ {
+		c := s[j]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '>':
+			return j
+		case c == '<':
+			return -1
+		}
+	}
+	return -1
+}
+
+// closeElement pops the stack for a closing tag and reports mismatches
+func (l *templateLinter) closeElement(stack []openElement, name string, line int) []openElement {
+	if voidElements[name] {
+		l.add(SeverityWarning, "content", line, "</%s> is not needed; <%s> is a void element", name, name)
+		return stack
+	}
+	for j := len(stack) - 1; j >= 0; j-- {
+		if stack[j].name != name {
+			continue
+		}
+		for _, open := range stack[j+1:] {
+			if !optionalClose[open.name] {
+				l.add(SeverityError, "content", open.line, "<%s> is not closed before </%s> on line %d", open.name, name, line)
+			}
+		}
+		return stack[:j]
+	}
+	l.add(SeverityError, "content", line, "unexpected closing tag </%s>", name)
+	return stack
+}
+
+// parseAttributes returns the attributes of a tag, reporting duplicates
+func (l *templateLinter) parseAttributes(source, tag string, line int) map[string]string {
+	attrs := map[string]string{}
+	for _, match := range attrPattern.FindAllStringSubmatch(source, -1) {
+		name := strings.ToLower(match[1])
+		if name == "/" {
+			continue
+		}
+		if _, dup := attrs[name]; dup {
+			l.add(SeverityWarning, "content", line, "duplicate attribute %s on <%s>", name, tag)
+		}
+		attrs[name] = match[2] + match[3] + match[4]
+	}
+	return attrs
+}
+
+// lintElement flags elements and attributes that email clients block
+func (l *templateLinter) lintElement(name string, attrs map[string]string, line int) {
+	if reason, blocked := blockedElements[name]; blocked {
+		severity := SeverityWarning
+		if name == "script" || name == "iframe" || name == "object" || name == "embed" {
+			severity = SeverityError
+		}
+		l.add(severity, "content", line, "<%s>: %s", name, reason)
+	}
+
+	switch name {
+	case "link":
+		if strings.Contains(strings.ToLower(attrs["rel"]), "stylesheet") {
+			l.add(SeverityWarning, "content", line, "external stylesheet %s is blocked by most email clients; inline the CSS", attrs["href"])
+		}
+	case "img":
+		if remoteURLPrefix.MatchString(attrs["src"]) {
+			l.add(SeverityWarning, "content", line, "remote image %s is hidden until the recipient allows images", attrs["src"])
+		}
+		if _, ok := attrs["alt"]; !ok {
+			l.add(SeverityWarning, "content", line, "<img> has no alt text")
+		}
+	case "base":
+		l.add(SeverityWarning, "content", line, "<base> is ignored or stripped by most email clients")
+	}
+
+	for attr, value := range attrs {
+		if strings.HasPrefix(strings.ToLower(strings.TrimSpace(value)), "javascript:") {
+			l.add(SeverityError, "content", line, "javascript: URL in %s attribute is blocked by email clients", attr)
+		}
+		if strings.HasPrefix(attr, "on") && len(attr) > 2 {
+			l.add(SeverityError, "content", line, "event handler attribute %s is stripped by email clients", attr)
+		}
+	}
+	if style, ok := attrs["style"]; ok {
+		l.lintCSS(style, line)
+	}
+}
+
+// lintCSS flags remote resources referenced from CSS
+func (l *templateLinter) lintCSS(css string, line int) {
+	if cssImportURL.MatchString(css) {
+		l.add(SeverityWarning, "content", line, "CSS @import of a remote stylesheet is blocked by most email clients")
+	} else if cssRemoteURL.MatchString(css) {
+		l.add(SeverityWarning, "content", line, "remote url() in CSS (images or fonts) is blocked by many email clients")
+	}
+}
+
+// lintSMS reports the encoding and segment count of an SMS template.
+// Placeholder values are not known, so only the literal text is measured.
+func (l *templateLinter) lintSMS(nodes []*Node) {
+	var text strings.Builder
+	placeholders := 0
+	var walk func(nodes []*Node)
+	walk = func(nodes []*Node) {
+		for _, node := range nodes {
+			switch node.Kind {
+			case NodeText:
+				text.WriteString(node.Text)
+			case NodeSection, NodeInverted:
+				placeholders++
+				walk(node.Children)
+			default:
+				placeholders++
+			}
+		}
+	}
+	walk(nodes)
+
+	info := AnalyzeSMS(text.String())
+	suffix := ""
+	if placeholders > 0 {
+		suffix = fmt.Sprintf(" excluding %d placeholder(s)", placeholders)
+	}
+	l.add(SeverityInfo, "content", 0, "%s encoding, %d characters, %d segment(s)%s", info.Encoding, info.Length, info.Segments, suffix)
+	if info.Encoding == EncodingUCS2 {
+		l.add(SeverityWarning, "content", 0, "characters %s are not in the GSM-7 alphabet, so the SMS is sent as UCS-2 (70 characters per segment)", quoteRunes(info.NonGSM))
+	}
+	if info.Segments > 1 {
+		l.add(SeverityWarning, "content", 0, "message is sent as %d segments", info.Segments)
+	}
+}
+
+func quoteRunes(runes []rune) string {
+	quoted := make([]string, len(runes))
+	for i, r := range runes {
+		quoted[i] = fmt.Sprintf("%q", r)
+	}
+	return strings.Join(quoted, " ")
+}
@@ -0,0 +1,297 @@
+package notification
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// NodeKind identifies the kind of a parsed template node
+type NodeKind int
+
+const (
+	NodeText NodeKind = iota
+	NodeVariable
+	NodeSection
+	NodeInverted
+	NodeMarker
+)
+
+// Node is a single element of a parsed notification template.
+// Templates use the server's mustache syntax ({{name}}, {{{raw}}}, {{#list}}...{{/list}},
+// {{^empty}}...{{/empty}}, {{! comment}}) and legacy [NAME] markers.
+type Node struct {
+	Kind     NodeKind
+	Name     string // variable, section or marker name
+	Text     string // literal text for NodeText
+	Raw      bool   // variable is not HTML-escaped
+	Line     int
+	Children []*Node
+}
+
+// markerPattern matches legacy [USERNAME]-style markers
+var markerPattern = regexp.MustCompile(`\[([A-Z][A-Z0-9_]*)\]`)
+
+// Parse parses template content into a node tree
+func Parse(content string) ([]*Node, error) {
+	root := &Node{Kind: NodeSection}
+	stack := []*Node{root}
+	line := 1
+
+	addText := func(text string) {
+		parent := stack[len(stack)-1]
+		for len(text) > 0 {
+			loc := markerPattern.FindStringSubmatchIndex(text)
+			if loc == nil {
+				parent.Children = append(parent.Children, &Node{Kind: NodeText, Text: text, Line: line})
+				line += strings.Count(text, "\n")
+				return
+			}
+			if loc[0] > 0 {
+				parent.Children = append(parent.Children, &Node{Kind: NodeText, Text: text[:loc[0]], Line: line})
+				line += strings.Count(text[:loc[0]], "\n")
+			}
+			parent.Children = append(parent.Children, &Node{Kind: NodeMarker, Name: text[loc[2]:loc[3]], Text: text[loc[0]:loc[1]], Line: line})
+			text = text[loc[1]:]
+		}
+	}
+
+	rest := content
+	for {
+		open := strings.Index(rest, "{{")
+		if open < 0 {
+			addText(rest)
+			break
+		}
+		addText(rest[:open])
+		rest = rest[open:]
+
+		raw := strings.HasPrefix(rest, "{{{")
+		closer := "}}"
+		if raw {
+			closer = "}}}"
+		}
+		end := strings.Index(rest, closer)
+		if end < 0 {
+			return nil, fmt.Errorf("line %d: unclosed tag", line)
+		}
+		tagLen := 2
+		if raw {
+			tagLen = 3
+		}
+		tag := strings.TrimSpace(rest[tagLen:end])
+		tagLine := line
+		line += strings.Count(rest[:end+len(closer)], "\n")
+		rest = rest[end+len(closer):]
+
+		parent := stack[len(stack)-1]
+		if raw {
+			if tag == "" {
+				return nil, fmt.Errorf("line %d: empty tag", tagLine)
+			}
+			parent.Children = append(parent.Children, &Node{Kind: NodeVariable, Name: tag, Raw: true, Line: tagLine})
+			continue
+		}
+		if tag == "" {
+			return nil, fmt.Errorf("line %d: empty tag", tagLine)
+		}
+
+		switch tag[0] {
+		case '!':
+			// Comment
+		case '#', '^':
+			kind := NodeSection
+			if tag[0] == '^' {
+				kind = NodeInverted
+			}
+			name := strings.TrimSpace(tag[1:])
+			if name == "" {
+				return nil, fmt.Errorf("line %d: section without a name", tagLine)
+			}
+			section := &Node{Kind: kind, Name: name, Line: tagLine}
+			parent.Children = append(parent.Children, section)
+			stack = append(stack, section)
+		case '/':
+			name := strings.TrimSpace(tag[1:])
+			if len(stack) == 1 {
+				return nil, fmt.Errorf("line %d: closing tag {{/%s}} without an opening section", tagLine, name)
+			}
+			if parent.Name != name {
+				return nil, fmt.Errorf("line %d: closing tag {{/%s}} does not match {{#%s}} opened on line %d", tagLine, name, parent.Name, parent.Line)
+			}
+			stack = stack[:len(stack)-1]
+		case '&':
+			name := strings.TrimSpace(tag[1:])
+			parent.Children = append(parent.Children, &Node{Kind: NodeVariable, Name: name, Raw: true, Line: tagLine})
+		case '>', '=':
+			return nil, fmt.Errorf("line %d: unsupported tag {{%s}}", tagLine, tag)
+		default:
+			parent.Children = append(parent.Children, &Node{Kind: NodeVariable, Name: tag, Line: tagLine})
+		}
+	}
+
+	if len(stack) > 1 {
+		open := stack[len(stack)-1]
+		return nil, fmt.Errorf("line %d: section {{#%s}} is never closed", open.Line, open.Name)
+	}
+	return root.Children, nil
+}
+
+// Result holds the output of rendering a template
+type Result struct {
+	Output  string
+	Missing []string // placeholders with no value in the payload
+}
+
+// Render renders template content with the given payload.
+// When escapeHTML is true, {{name}} values are HTML-escaped as the server does for HTML templates.
+func Render(content string, payload map[string]interface{}, escapeHTML bool) (*Result, error) {
+	nodes, err := Parse(content)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &renderer{escapeHTML: escapeHTML, missing: map[string]bool{}}
+	var out strings.Builder
+	r.render(&out, nodes, []interface{}{payload})
+
+	result := &Result{Output: out.String()}
+	for name := range r.missing {
+		result.Missing = append(result.Missing, name)
+	}
+	sort.Strings(result.Missing)
+	return result, nil
+}
+
+type renderer struct {
+	escapeHTML bool
+	missing    map[string]bool
+}
+
+func (r *renderer) render(out *strings.Builder, nodes []*Node, stack []interface{}) {
+	for _, node := range nodes {
+		switch node.Kind {
+		case NodeText:
+			out.WriteString(node.Text)
+		case NodeMarker:
+			value, ok := lookupMarker(node.Name, stack[0])
+			if !ok {
+				r.missing["["+node.Name+"]"] = true
+				out.WriteString(node.Text)
+				continue
+			}
+			r.write(out, value, false)
+		case NodeVariable:
+			value, ok := lookup(node.Name, stack)
+			if !ok {
+				r.missing[node.Name] = true
+				continue
+			}
+			r.write(out, value, node.Raw)
+		case NodeSection:
+			value, ok := lookup(node.Name, stack)
+			if !ok {
+				r.missing[node.Name] = true
+				continue
+			}
+			switch v := value.(type) {
+			case []interface{}:
+				for _, item := range v {
+					r.render(out, node.Children, append(stack, item))
+				}
+			default:
+				if truthy(v) {
+					r.render(out, node.Children, append(stack, v))
+				}
+			}
+		case NodeInverted:
+			value, _ := lookup(node.Name, stack)
+			if !truthy(value) {
+				r.render(out, node.Children, stack)
+			}
+		}
+	}
+}
+
+func (r *renderer) write(out *strings.Builder, value interface{}, raw bool) {
+	text := formatValue(value)
+	if r.escapeHTML && !raw {
+		text = html.EscapeString(text)
+	}
+	out.WriteString(text)
+}
+
+// lookup resolves a dotted name against the context stack, innermost first
+func lookup(name string, stack []interface{}) (interface{}, bool) {
+	if name == "." {
+		return stack[len(stack)-1], true
+	}
+	parts := strings.Split(name, ".")
+	for i := len(stack) - 1; i >= 0; i-- {
+		ctx, ok := stack[i].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		value, ok := ctx[parts[0]]
+		if !ok {
+			continue
+		}
+		for _, part := range parts[1:] {
+			nested, isMap := value.(map[string]interface{})
+			if !isMap {
+				return nil, false
+			}
+			if value, ok = nested[part]; !ok {
+				return nil, false
+			}
+		}
+		return value, true
+	}
+	return nil, false
+}
+
+// lookupMarker resolves a [NAME] marker against the payload, matching keys case-insensitively
+func lookupMarker(name string, payload interface{}) (interface{}, bool) {
+	ctx, ok := payload.(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+	if value, ok := ctx[name]; ok {
+		return value, true
+	}
+	for key, value := range ctx {
+		if strings.EqualFold(key, name) {
+			return value, true
+		}
+	}
+	return nil, false
+}
+
+func truthy(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	case string:
+		return v != ""
+	case []interface{}:
+		return len(v) > 0
+	}
+	return true
+}
+
+func formatValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	return fmt.Sprint(value)
+}
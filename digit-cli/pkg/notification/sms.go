@@ -0,0 +1,77 @@
+package notification
+
+import (
+	"unicode/utf16"
+)
+
+// SMS character encodings
+const (
+	EncodingGSM7 = "GSM-7"
+	EncodingUCS2 = "UCS-2"
+)
+
+// gsm7Basic is the GSM 03.38 default alphabet; each character takes one septet
+const gsm7Basic = "@£$¥èéùìòÇ\nØø\rÅåΔ_ΦΓΛΩΠΨΣΘΞÆæßÉ !\"#¤%&'()*+,-./0123456789:;<=>?" +
+	"¡ABCDEFGHIJKLMNOPQRSTUVWXYZÄÖÑÜ§¿abcdefghijklmnopqrstuvwxyzäöñüà"
+
+// gsm7Extension characters are sent with an escape prefix and take two septets
+const gsm7Extension = "\f^{}\\[~]|€"
+
+// SMSInfo describes how an SMS text is encoded and split into segments
+type SMSInfo struct {
+	Encoding string
+	Length   int    // septets for GSM-7, UTF-16 code units for UCS-2
+	Segments int    // number of SMS parts the message is split into
+	NonGSM   []rune // characters that forced UCS-2 encoding
+}
+
+// AnalyzeSMS determines the encoding, length and segment count of an SMS text
+func AnalyzeSMS(text string) SMSInfo {
+	info := SMSInfo{Encoding: EncodingGSM7}
+	seen := map[rune]bool{}
+	septets := 0
+	for _, r := range text {
+		switch {
+		case containsRune(gsm7Basic, r):
+			septets++
+		case containsRune(gsm7Extension, r):
+			septets += 2
+		default:
+			if !seen[r] {
+				seen[r] = true
+				info.NonGSM = append(info.NonGSM, r)
+			}
+		}
+	}
+
+	if len(info.NonGSM) > 0 {
+		info.Encoding = EncodingUCS2
+		info.Length = len(utf16.Encode([]rune(text)))
+		info.Segments = segments(info.Length, 70, 67)
+	} else {
+		info.Length = septets
+		info.Segments = segments(info.Length, 160, 153)
+	}
+	return info
+}
+
+// segments returns how many parts a message of the given length needs;
+// concatenated parts lose room to the user data header
+func segments(length, single, multipart int) int {
+	if length == 0 {
+		return 0
+	}
+	if length <= single {
+		return 1
+	}
+	return (length + multipart - 1) / multipart
+}
+
+func containsRune(set string, r rune) bool {
+	for _, c := range set {
+		if c == r {
+			return true
+		}
+	}
+	return false
+}
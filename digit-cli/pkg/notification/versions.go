@@ -0,0 +1,219 @@
+package notification
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TemplateVersion is one version of a notification template as returned by the template search API
+type TemplateVersion struct {
+	TemplateID string
+	Version    string
+	Type       string
+	Subject    string
+	Content    string
+	HTML       bool
+	Created    time.Time
+	Modified   time.Time
+}
+
+// ParseTemplateVersions extracts the template versions from a template search response.
+// The response may be a list of templates or an object wrapping one; timestamps are read
+// from auditDetails or top-level createdTime/lastModifiedTime fields when present.
+func ParseTemplateVersions(responseBody string) ([]TemplateVersion, error) {
+	var decoded interface{}
+	if err := json.Unmarshal([]byte(responseBody), &decoded); err != nil {
+		return nil, fmt.Errorf("failed to parse template search response: %w", err)
+	}
+
+	items, ok := decoded.([]interface{})
+	if !ok {
+		wrapper, isObject := decoded.(map[string]interface{})
+		if !isObject {
+			return nil, fmt.Errorf("unexpected template search response")
+		}
+		keys := make([]string, 0, len(wrapper))
+		for key := range wrapper {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if list, isList := wrapper[key].([]interface{}); isList {
+				items = list
+				break
+			}
+		}
+		if items == nil {
+			if _, hasID := wrapper["templateId"]; !hasID {
+				return nil, fmt.Errorf("unexpected template search response")
+			}
+			// A single template
+			items = []interface{}{wrapper}
+		}
+	}
+
+	versions := make([]TemplateVersion, 0, len(items))
+	for i, item := range items {
+		obj, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("template %d is not an object", i)
+		}
+		v := TemplateVersion{
+			TemplateID: stringField(obj, "templateId"),
+			Version:    stringField(obj, "version"),
+			Type:       stringField(obj, "type"),
+			Subject:    stringField(obj, "subject"),
+			Content:    stringField(obj, "content"),
+		}
+		if v.Version == "" {
+			return nil, fmt.Errorf("template %d has no version", i)
+		}
+		v.HTML, _ = obj["isHTML"].(bool)
+
+		audit, _ := obj["auditDetails"].(map[string]interface{})
+		for _, source := range []map[string]interface{}{audit, obj} {
+			if v.Created.IsZero() {
+				v.Created = timeField(source, "createdTime", "createdAt")
+			}
+			if v.Modified.IsZero() {
+				v.Modified = timeField(source, "lastModifiedTime", "lastModifiedAt", "updatedAt")
+			}
+		}
+		versions = append(versions, v)
+	}
+	return versions, nil
+}
+
+// SortVersions orders template versions from oldest to newest by version number
+func SortVersions(versions []TemplateVersion) {
+	sort.SliceStable(versions, func(i, j int) bool {
+		return CompareVersions(versions[i].Version, versions[j].Version) < 0
+	})
+}
+
+// CompareVersions compares two version strings such as 1.2.10 and 1.10.0. As in semantic
+// versioning, a version with a -suffix is a pre-release that sorts before the same version
+// without one, so 2.0.0-beta comes before 2.0.0. Otherwise numeric parts are compared as
+// numbers and sort before text parts, which are compared as text; a leading "v" is ignored.
+func CompareVersions(a, b string) int {
+	releaseA, preA, hasPreA := strings.Cut(strings.TrimPrefix(a, "v"), "-")
+	releaseB, preB, hasPreB := strings.Cut(strings.TrimPrefix(b, "v"), "-")
+	if c := compareVersionParts(releaseA, releaseB); c != 0 {
+		return c
+	}
+	switch {
+	case hasPreA && !hasPreB:
+		return -1
+	case !hasPreA && hasPreB:
+		return 1
+	}
+	return compareVersionParts(preA, preB)
+}
+
+// compareVersionParts compares the separated parts of two versions in order
+func compareVersionParts(a, b string) int {
+	partsA := strings.FieldsFunc(a, isVersionSeparator)
+	partsB := strings.FieldsFunc(b, isVersionSeparator)
+	for i := 0; i < len(partsA) || i < len(partsB); i++ {
+		if i >= len(partsA) {
+			return -1
+		}
+		if i >= len(partsB) {
+			return 1
+		}
+		numA, errA := strconv.Atoi(partsA[i])
+		numB, errB := strconv.Atoi(partsB[i])
+		switch {
+		case errA == nil && errB == nil:
+			if numA != numB {
+				if numA < numB {
+					return -1
+				}
+				return 1
+			}
+		case errA == nil:
+			// Numeric parts sort before text parts
+			return -1
+		case errB == nil:
+			return 1
+		default:
+			if c := strings.Compare(partsA[i], partsB[i]); c != 0 {
+				return c
+			}
+		}
+	}
+	return 0
+}
+
+// localeSuffix matches a locale of the ll_CC form, e.g. en_IN. Bare language codes are not
+// matched, since suffixes such as -rc and -dev mark pre-releases.
+var localeSuffix = regexp.MustCompile(`^[a-z]{2,3}_[A-Z]{2}$`)
+
+// SplitLocale splits a version into its base version and the locale appended by the "version"
+// locale mode, e.g. 1.2.0-en_IN into 1.2.0 and en_IN. A suffix is a locale when it is one of
+// locales, the locales of the template configuration, or has the ll_CC form; other versions
+// have no locale.
+func SplitLocale(version string, locales []string) (string, string) {
+	for _, locale := range locales {
+		if base := strings.TrimSuffix(version, "-"+locale); base != version && base != "" {
+			return base, locale
+		}
+	}
+	i := strings.LastIndex(version, "-")
+	if i <= 0 || !localeSuffix.MatchString(version[i+1:]) {
+		return version, ""
+	}
+	return version[:i], version[i+1:]
+}
+
+// GroupByLocale groups template versions by their locale, as read by SplitLocale, and orders
+// each group from oldest to newest by base version. Versions without a locale are grouped under "".
+func GroupByLocale(versions []TemplateVersion, locales []string) map[string][]TemplateVersion {
+	groups := map[string][]TemplateVersion{}
+	bases := map[string]string{}
+	for _, v := range versions {
+		base, locale := SplitLocale(v.Version, locales)
+		bases[v.Version] = base
+		groups[locale] = append(groups[locale], v)
+	}
+	for _, group := range groups {
+		sort.SliceStable(group, func(i, j int) bool {
+			return CompareVersions(bases[group[i].Version], bases[group[j].Version]) < 0
+		})
+	}
+	return groups
+}
+
+func isVersionSeparator(r rune) bool {
+	return r == '.' || r == '-' || r == '_' || r == '+'
+}
+
+func stringField(obj map[string]interface{}, key string) string {
+	value, _ := obj[key].(string)
+	return value
+}
+
+// timeField reads the first present timestamp field, given as epoch milliseconds or RFC 3339 text
+func timeField(obj map[string]interface{}, keys ...string) time.Time {
+	for _, key := range keys {
+		switch v := obj[key].(type) {
+		case float64:
+			if v > 0 {
+				return time.UnixMilli(int64(v))
+			}
+		case string:
+			if t, err := time.Parse(time.RFC3339, v); err == nil {
+				return t
+			}
+			if ms, err := strconv.ParseInt(v, 10, 64); err == nil && ms > 0 {
+				return time.UnixMilli(ms)
+			}
+		}
+	}
+	return time.Time{}
+}
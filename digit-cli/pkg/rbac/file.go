@@ -0,0 +1,177 @@
+package rbac
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// File declares the roles of a realm and who holds them:
+//
+//	roles:
+//	  - name: SUPERVISOR
+//	    description: Supervises grievance officers
+//	    composites: [GRO, CSR]
+//	client-roles:
+//	  dashboard:
+//	    - name: reports-viewer
+//	users:
+//	  jdoe: [SUPERVISOR, dashboard/reports-viewer]
+//	groups:
+//	  /departments/pgr: [GRO]
+//
+// Realm roles are written by name and client roles as <clientId>/<role>.
+type File struct {
+	Roles       []RoleSpec            `yaml:"roles"`
+	ClientRoles map[string][]RoleSpec `yaml:"client-roles"`
+	// Users maps usernames to the roles assigned to them directly
+	Users map[string][]string `yaml:"users"`
+	// Groups maps group paths to the roles mapped to them
+	Groups map[string][]string `yaml:"groups"`
+}
+
+// RoleSpec is a declared role. An empty description is left as it is in the realm.
+type RoleSpec struct {
+	Name        string   `yaml:"name"`
+	Description string   `yaml:"description"`
+	Composites  []string `yaml:"composites"`
+}
+
+// Load reads and validates an RBAC file
+func Load(path string) (*File, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read RBAC file: %w", err)
+	}
+	var file File
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse RBAC file %s: %w", path, err)
+	}
+
+	// Keycloak stores usernames in lower case, so JDoe and jdoe are the same user
+	users := map[string][]string{}
+	for username, roles := range file.Users {
+		key := strings.ToLower(username)
+		if _, ok := users[key]; ok {
+			return nil, fmt.Errorf("invalid RBAC file %s: user %s is declared more than once", path, key)
+		}
+		users[key] = roles
+	}
+	file.Users = users
+	if err := file.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid RBAC file %s: %w", path, err)
+	}
+	return &file, nil
+}
+
+// Validate checks that roles are declared once, that role references are well formed and that
+// no composite role includes itself
+func (f *File) Validate() error {
+	var problems []string
+	seen := map[string]bool{}
+	for _, role := range f.DeclaredRoles() {
+		if role.Name == "" {
+			problems = append(problems, "a role has no name")
+			continue
+		}
+		if strings.Contains(role.Name, "/") {
+			problems = append(problems, fmt.Sprintf("role name %q cannot contain /", role.Name))
+		}
+		if seen[role.Ref] {
+			problems = append(problems, fmt.Sprintf("role %s is declared more than once", role.Ref))
+		}
+		seen[role.Ref] = true
+		for _, composite := range role.Composites {
+			if composite == role.Ref {
+				problems = append(problems, fmt.Sprintf("role %s cannot include itself", role.Ref))
+			}
+			if !validRef(composite) {
+				problems = append(problems, fmt.Sprintf("role %s includes invalid role %q", role.Ref, composite))
+			}
+		}
+	}
+	for _, username := range sortedKeys(f.Users) {
+		for _, ref := range f.Users[username] {
+			if !validRef(ref) {
+				problems = append(problems, fmt.Sprintf("user %s has invalid role %q", username, ref))
+			}
+		}
+	}
+	for _, path := range sortedKeys(f.Groups) {
+		if !strings.HasPrefix(path, "/") {
+			problems = append(problems, fmt.Sprintf("group %q must be a path starting with /", path))
+		}
+		for _, ref := range f.Groups[path] {
+			if !validRef(ref) {
+				problems = append(problems, fmt.Sprintf("group %s has invalid role %q", path, ref))
+			}
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("%s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// DeclaredRole is a role of the roles or client-roles section
+type DeclaredRole struct {
+	RoleSpec
+	// Ref is the role as <role>, or <clientId>/<role> for client roles
+	Ref string
+}
+
+// DeclaredRoles returns the declared realm roles followed by the client roles, sorted by client
+func (f *File) DeclaredRoles() []DeclaredRole {
+	var roles []DeclaredRole
+	for _, role := range f.Roles {
+		roles = append(roles, DeclaredRole{RoleSpec: role, Ref: role.Name})
+	}
+	for _, clientID := range sortedKeys(f.ClientRoles) {
+		for _, role := range f.ClientRoles[clientID] {
+			roles = append(roles, DeclaredRole{RoleSpec: role, Ref: clientID + "/" + role.Name})
+		}
+	}
+	return roles
+}
+
+// ManagedRoles returns the sorted references of the roles the file manages: the declared roles
+// and every role they include or that is assigned. Assignments of other roles are left alone.
+func (f *File) ManagedRoles() []string {
+	managed := map[string]bool{}
+	for _, role := range f.DeclaredRoles() {
+		managed[role.Ref] = true
+		for _, composite := range role.Composites {
+			managed[composite] = true
+		}
+	}
+	for _, refs := range f.Users {
+		for _, ref := range refs {
+			managed[ref] = true
+		}
+	}
+	for _, refs := range f.Groups {
+		for _, ref := range refs {
+			managed[ref] = true
+		}
+	}
+	return sortedKeys(managed)
+}
+
+// validRef reports whether a role reference is <role> or <clientId>/<role>
+func validRef(ref string) bool {
+	i := strings.LastIndex(ref, "/")
+	return ref != "" && i != 0 && i != len(ref)-1
+}
+
+// sortedKeys returns the keys of a map in order
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,176 @@
+package rbac
+
+import (
+	"fmt"
+)
+
+// Live is the current state of the managed roles of a realm
+type Live struct {
+	// Roles holds the managed roles that exist, by reference
+	Roles map[string]LiveRole
+	// Users holds the declared users and the holders of managed roles, by username
+	Users map[string]Holder
+	// Groups holds the declared groups and the groups holding managed roles, by path
+	Groups map[string]Holder
+}
+
+// LiveRole is an existing role
+type LiveRole struct {
+	Description string
+	// Composites holds the references of the roles the role includes
+	Composites []string
+}
+
+// Holder is a user or group and the managed roles assigned to it directly
+type Holder struct {
+	Exists bool
+	Roles  map[string]bool
+}
+
+// Change kinds
+const (
+	KindRole      = "role"
+	KindComposite = "composite"
+	KindUser      = "user"
+	KindGroup     = "group"
+)
+
+// Change actions
+const (
+	ActionAdd    = "+"
+	ActionUpdate = "~"
+	ActionRemove = "-"
+	// ActionProblem is a difference the file cannot resolve, such as a missing user
+	ActionProblem = "!"
+)
+
+// Change is a difference between an RBAC file and the realm, and the change that resolves it
+type Change struct {
+	Kind   string
+	Action string
+	// Subject is the role for role and composite changes, or the username or group path
+	Subject string
+	// Role is the role included, assigned or removed
+	Role string
+	// Description is the new description of a role
+	Description string
+	// Problem explains a problem change
+	Problem string
+}
+
+// String describes the change, e.g. "+ user jdoe has GRO"
+func (c Change) String() string {
+	switch {
+	case c.Action == ActionProblem:
+		return fmt.Sprintf("! %s %s: %s", c.Kind, c.Subject, c.Problem)
+	case c.Kind == KindRole && c.Description != "":
+		return fmt.Sprintf("%s role %s (%s)", c.Action, c.Subject, c.Description)
+	case c.Kind == KindRole:
+		return fmt.Sprintf("%s role %s", c.Action, c.Subject)
+	case c.Kind == KindComposite:
+		return fmt.Sprintf("%s role %s includes %s", c.Action, c.Subject, c.Role)
+	}
+	return fmt.Sprintf("%s %s %s has %s", c.Action, c.Kind, c.Subject, c.Role)
+}
+
+// Prune reports whether the change removes something the file does not declare
+func (c Change) Prune() bool {
+	return c.Action == ActionRemove
+}
+
+// Plan compares an RBAC file with the realm and returns the changes that make the realm match
+// the file, in the order they must be applied: roles, composites, then assignments, with the
+// removals of undeclared composites and assignments last. Roles are never removed.
+func Plan(file *File, live *Live) []Change {
+	var changes, removals []Change
+	declared := map[string]bool{}
+	for _, role := range file.DeclaredRoles() {
+		declared[role.Ref] = true
+		existing, ok := live.Roles[role.Ref]
+		switch {
+		case !ok:
+			changes = append(changes, Change{Kind: KindRole, Action: ActionAdd, Subject: role.Ref, Description: role.Description})
+		case role.Description != "" && role.Description != existing.Description:
+			changes = append(changes, Change{Kind: KindRole, Action: ActionUpdate, Subject: role.Ref, Description: role.Description})
+		}
+	}
+
+	// A role that is used but neither declared nor existing cannot be included or assigned
+	missing := map[string]bool{}
+	for _, ref := range file.ManagedRoles() {
+		if _, ok := live.Roles[ref]; !ok && !declared[ref] {
+			missing[ref] = true
+			changes = append(changes, Change{Kind: KindRole, Action: ActionProblem, Subject: ref, Problem: "does not exist and is not declared"})
+		}
+	}
+
+	for _, role := range file.DeclaredRoles() {
+		current := map[string]bool{}
+		for _, composite := range live.Roles[role.Ref].Composites {
+			current[composite] = true
+		}
+		wanted := map[string]bool{}
+		for _, composite := range role.Composites {
+			wanted[composite] = true
+			if !current[composite] && !missing[composite] {
+				changes = append(changes, Change{Kind: KindComposite, Action: ActionAdd, Subject: role.Ref, Role: composite})
+			}
+		}
+		for _, composite := range sortedKeys(current) {
+			if !wanted[composite] {
+				removals = append(removals, Change{Kind: KindComposite, Action: ActionRemove, Subject: role.Ref, Role: composite})
+			}
+		}
+	}
+
+	assignments := func(kind string, declaredHolders map[string][]string, live map[string]Holder) {
+		for _, name := range sortedKeys(declaredHolders) {
+			holder := live[name]
+			if !holder.Exists {
+				changes = append(changes, Change{Kind: kind, Action: ActionProblem, Subject: name, Problem: "does not exist"})
+				continue
+			}
+			added := map[string]bool{}
+			for _, ref := range declaredHolders[name] {
+				if !holder.Roles[ref] && !missing[ref] && !added[ref] {
+					added[ref] = true
+					changes = append(changes, Change{Kind: kind, Action: ActionAdd, Subject: name, Role: ref})
+				}
+			}
+		}
+		for _, name := range sortedKeys(live) {
+			for _, ref := range sortedKeys(live[name].Roles) {
+				if live[name].Roles[ref] && !contains(declaredHolders[name], ref) {
+					removals = append(removals, Change{Kind: kind, Action: ActionRemove, Subject: name, Role: ref})
+				}
+			}
+		}
+	}
+	assignments(KindUser, file.Users, live.Users)
+	assignments(KindGroup, file.Groups, live.Groups)
+
+	return append(changes, removals...)
+}
+
+// Summary counts the changes by action, e.g. "2 to add, 1 to update, 3 to remove"
+func Summary(changes []Change) string {
+	counts := map[string]int{}
+	for _, change := range changes {
+		counts[change.Action]++
+	}
+	summary := fmt.Sprintf("%d to add, %d to update, %d to remove", counts[ActionAdd], counts[ActionUpdate], counts[ActionRemove])
+	if counts[ActionProblem] > 0 {
+		summary += fmt.Sprintf(", %d problems", counts[ActionProblem])
+	}
+	return summary
+}
+
+// contains reports whether a list holds a value
+func contains(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,561 @@
+package registry
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Draft202012 is the meta-schema URI expected in registry schema definitions
+const Draft202012 = "https://json-schema.org/draft/2020-12/schema"
+
+// Severity represents how serious a lint finding is
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// Finding represents a single lint result with its location in the source document
+type Finding struct {
+	Severity Severity
+	Path     string
+	Line     int
+	Column   int
+	Message  string
+}
+
+// String formats the finding as "line:column: severity: path: message"
+func (f Finding) String() string {
+	return fmt.Sprintf("%d:%d: %s: %s: %s", f.Line, f.Column, f.Severity, f.Path, f.Message)
+}
+
+// indexMethodTypes lists the JSON Schema types each supported index method can serve
+var indexMethodTypes = map[string][]string{
+	"btree": {"string", "integer", "number", "boolean"},
+	"hash":  {"string", "integer", "number", "boolean"},
+	"gin":   {"string", "array", "object"},
+}
+
+// jsonSchemaTypes lists the primitive types defined by JSON Schema
+var jsonSchemaTypes = map[string]bool{
+	"null": true, "boolean": true, "object": true, "array": true,
+	"number": true, "string": true, "integer": true,
+}
+
+// knownFormats lists the format values defined by draft 2020-12
+var knownFormats = map[string]bool{
+	"date-time": true, "date": true, "time": true, "duration": true,
+	"email": true, "idn-email": true, "hostname": true, "idn-hostname": true,
+	"ipv4": true, "ipv6": true, "uri": true, "uri-reference": true,
+	"iri": true, "iri-reference": true, "uuid": true, "uri-template": true,
+	"json-pointer": true, "relative-json-pointer": true, "regex": true,
+}
+
+// schemaKeywords lists every keyword understood by draft 2020-12 (core, applicator,
+// validation, meta-data, format, content and unevaluated vocabularies)
+var schemaKeywords = map[string]bool{
+	"$schema": true, "$id": true, "$ref": true, "$anchor": true, "$dynamicRef": true,
+	"$dynamicAnchor": true, "$vocabulary": true, "$comment": true, "$defs": true,
+	"allOf": true, "anyOf": true, "oneOf": true, "not": true, "if": true, "then": true,
+	"else": true, "dependentSchemas": true, "prefixItems": true, "items": true,
+	"contains": true, "properties": true, "patternProperties": true,
+	"additionalProperties": true, "propertyNames": true, "unevaluatedItems": true,
+	"unevaluatedProperties": true, "type": true, "enum": true, "const": true,
+	"multipleOf": true, "maximum": true, "exclusiveMaximum": true, "minimum": true,
+	"exclusiveMinimum": true, "maxLength": true, "minLength": true, "pattern": true,
+	"maxItems": true, "minItems": true, "uniqueItems": true, "maxContains": true,
+	"minContains": true, "maxProperties": true, "minProperties": true, "required": true,
+	"dependentRequired": true, "title": true, "description": true, "default": true,
+	"deprecated": true, "readOnly": true, "writeOnly": true, "examples": true,
+	"format": true, "contentEncoding": true, "contentMediaType": true, "contentSchema": true,
+}
+
+// linter accumulates findings while walking a schema document
+type linter struct {
+	findings []Finding
+}
+
+func (l *linter) add(sev Severity, node *yaml.Node, path, format string, args ...interface{}) {
+	f := Finding{Severity: sev, Path: path, Message: fmt.Sprintf(format, args...)}
+	if node != nil {
+		f.Line = node.Line
+		f.Column = node.Column
+	}
+	l.findings = append(l.findings, f)
+}
+
+// LintSchema lints a registry schema document (YAML or JSON).
+// The document may either be a registry schema file with schemaCode and definition
+// keys, or a bare JSON Schema definition.
+// Returns the findings sorted by location and any error encountered while parsing.
+func LintSchema(data []byte) ([]Finding, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse schema: %w", err)
+	}
+	if len(doc.Content) == 0 {
+		return nil, fmt.Errorf("schema document is empty")
+	}
+
+	l := &linter{}
+	root := resolve(doc.Content[0])
+	if root.Kind != yaml.MappingNode {
+		l.add(SeverityError, root, "$", "schema document must be a mapping")
+		return l.findings, nil
+	}
+
+	definition, defPath := root, "$"
+	if _, def := mappingValue(root, "definition"); def != nil {
+		definition, defPath = resolve(def), "definition"
+		if key, code := mappingValue(root, "schemaCode"); code == nil {
+			l.add(SeverityError, root, "schemaCode", "schemaCode is required")
+		} else if code.Kind != yaml.ScalarNode || strings.TrimSpace(code.Value) == "" {
+			l.add(SeverityError, key, "schemaCode", "schemaCode must be a non-empty string")
+		}
+	}
+
+	if definition.Kind != yaml.MappingNode {
+		l.add(SeverityError, definition, defPath, "definition must be a mapping")
+		return l.findings, nil
+	}
+
+	l.lintRoot(definition, defPath)
+	l.lintSchema(definition, defPath, true)
+	l.lintIndexes(definition, defPath)
+
+	sort.SliceStable(l.findings, func(i, j int) bool {
+		if l.findings[i].Line != l.findings[j].Line {
+			return l.findings[i].Line < l.findings[j].Line
+		}
+		return l.findings[i].Column < l.findings[j].Column
+	})
+	return l.findings, nil
+}
+
+// HasErrors reports whether any finding has error severity
+func HasErrors(findings []Finding) bool {
+	for _, f := range findings {
+		if f.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// lintRoot checks the keywords that only matter at the top of a registry definition
+func (l *linter) lintRoot(node *yaml.Node, path string) {
+	key, schemaURI := mappingValue(node, "$schema")
+	if schemaURI == nil {
+		l.add(SeverityWarning, node, path, "$schema is not set; expected %q", Draft202012)
+	} else if strings.TrimSuffix(schemaURI.Value, "#") != Draft202012 {
+		l.add(SeverityError, key, path+".$schema", "unsupported $schema %q; registry schemas must use %q", schemaURI.Value, Draft202012)
+	}
+
+	if _, t := mappingValue(node, "type"); t == nil || t.Value != "object" {
+		l.add(SeverityError, node, path+".type", "registry definition must have type \"object\"")
+	}
+	if _, props := mappingValue(node, "properties"); props == nil {
+		l.add(SeverityError, node, path+".properties", "registry definition must declare properties")
+	}
+}
+
+// lintSchema validates a (sub)schema against the draft 2020-12 keyword rules
+func (l *linter) lintSchema(node *yaml.Node, path string, isRoot bool) {
+	node = resolve(node)
+	if node.Kind == yaml.ScalarNode && node.Tag == "!!bool" {
+		return // true/false are valid schemas
+	}
+	if node.Kind != yaml.MappingNode {
+		l.add(SeverityError, node, path, "schema must be an object or boolean")
+		return
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], resolve(node.Content[i+1])
+		kw := key.Value
+		kwPath := path + "." + kw
+
+		switch kw {
+		case "$schema":
+			if !isRoot {
+				l.add(SeverityWarning, key, kwPath, "$schema is only meaningful at the schema root")
+			}
+			l.expectString(value, kwPath)
+		case "$id", "$ref", "$anchor", "$dynamicRef", "$dynamicAnchor", "$comment",
+			"title", "description", "contentEncoding", "contentMediaType":
+			l.expectString(value, kwPath)
+		case "type":
+			l.lintType(value, kwPath)
+		case "properties", "patternProperties", "$defs", "dependentSchemas":
+			if value.Kind != yaml.MappingNode {
+				l.add(SeverityError, value, kwPath, "%s must be a mapping of schemas", kw)
+				continue
+			}
+			for j := 0; j+1 < len(value.Content); j += 2 {
+				name := value.Content[j].Value
+				if kw == "patternProperties" {
+					if _, err := regexp.Compile(name); err != nil {
+						l.add(SeverityError, value.Content[j], kwPath, "invalid pattern %q: %v", name, err)
+					}
+				}
+				l.lintSchema(value.Content[j+1], kwPath+"."+name, false)
+			}
+		case "additionalProperties", "items", "contains", "propertyNames", "not",
+			"if", "then", "else", "unevaluatedItems", "unevaluatedProperties", "contentSchema":
+			if kw == "items" && value.Kind == yaml.SequenceNode {
+				l.add(SeverityError, value, kwPath, "array form of items was removed in draft 2020-12; use prefixItems")
+				continue
+			}
+			l.lintSchema(value, kwPath, false)
+		case "allOf", "anyOf", "oneOf", "prefixItems":
+			if value.Kind != yaml.SequenceNode || len(value.Content) == 0 {
+				l.add(SeverityError, value, kwPath, "%s must be a non-empty array of schemas", kw)
+				continue
+			}
+			for j, sub := range value.Content {
+				l.lintSchema(sub, fmt.Sprintf("%s[%d]", kwPath, j), false)
+			}
+		case "required":
+			l.expectUniqueStrings(value, kwPath)
+		case "dependentRequired":
+			if value.Kind != yaml.MappingNode {
+				l.add(SeverityError, value, kwPath, "dependentRequired must be a mapping of string arrays")
+				continue
+			}
+			for j := 0; j+1 < len(value.Content); j += 2 {
+				l.expectUniqueStrings(value.Content[j+1], kwPath+"."+value.Content[j].Value)
+			}
+		case "enum":
+			if value.Kind != yaml.SequenceNode || len(value.Content) == 0 {
+				l.add(SeverityError, value, kwPath, "enum must be a non-empty array")
+			}
+		case "multipleOf":
+			if !isNumber(value) || strings.HasPrefix(value.Value, "-") || isZero(value) {
+				l.add(SeverityError, value, kwPath, "multipleOf must be a number greater than 0")
+			}
+		case "maximum", "minimum", "exclusiveMaximum", "exclusiveMinimum":
+			if !isNumber(value) {
+				l.add(SeverityError, value, kwPath, "%s must be a number", kw)
+			}
+		case "maxLength", "minLength", "maxItems", "minItems", "maxContains",
+			"minContains", "maxProperties", "minProperties":
+			if value.Tag != "!!int" || strings.HasPrefix(value.Value, "-") {
+				l.add(SeverityError, value, kwPath, "%s must be a non-negative integer", kw)
+			}
+		case "pattern":
+			if l.expectString(value, kwPath) {
+				if _, err := regexp.Compile(value.Value); err != nil {
+					l.add(SeverityError, value, kwPath, "invalid pattern %q: %v", value.Value, err)
+				}
+			}
+		case "format":
+			if l.expectString(value, kwPath) && !knownFormats[value.Value] {
+				l.add(SeverityWarning, value, kwPath, "unknown format %q will not be validated", value.Value)
+			}
+		case "uniqueItems", "deprecated", "readOnly", "writeOnly":
+			if value.Tag != "!!bool" {
+				l.add(SeverityError, value, kwPath, "%s must be a boolean", kw)
+			}
+		case "definitions":
+			l.add(SeverityWarning, key, kwPath, "definitions was renamed to $defs in draft 2020-12")
+		case "dependencies":
+			l.add(SeverityWarning, key, kwPath, "dependencies was split into dependentRequired and dependentSchemas in draft 2020-12")
+		case "x-indexes":
+			if !isRoot {
+				l.add(SeverityWarning, key, kwPath, "x-indexes is only honoured at the definition root")
+			}
+		default:
+			if !schemaKeywords[kw] && !strings.HasPrefix(kw, "x-") {
+				l.add(SeverityWarning, key, kwPath, "unknown keyword %q", kw)
+			}
+		}
+	}
+
+	l.lintBounds(node, path)
+	l.lintRequired(node, path)
+}
+
+// lintType checks the type keyword holds valid, unique JSON Schema type names
+func (l *linter) lintType(value *yaml.Node, path string) {
+	switch value.Kind {
+	case yaml.ScalarNode:
+		if !jsonSchemaTypes[value.Value] {
+			l.add(SeverityError, value, path, "unknown type %q", value.Value)
+		}
+	case yaml.SequenceNode:
+		seen := make(map[string]bool)
+		for _, t := range value.Content {
+			if !jsonSchemaTypes[t.Value] {
+				l.add(SeverityError, t, path, "unknown type %q", t.Value)
+			}
+			if seen[t.Value] {
+				l.add(SeverityError, t, path, "duplicate type %q", t.Value)
+			}
+			seen[t.Value] = true
+		}
+	default:
+		l.add(SeverityError, value, path, "type must be a string or an array of strings")
+	}
+}
+
+// lintBounds checks that paired min/max keywords are not contradictory
+func (l *linter) lintBounds(node *yaml.Node, path string) {
+	pairs := [][2]string{
+		{"minLength", "maxLength"}, {"minItems", "maxItems"},
+		{"minProperties", "maxProperties"}, {"minimum", "maximum"},
+		{"minContains", "maxContains"},
+	}
+	for _, pair := range pairs {
+		_, lo := mappingValue(node, pair[0])
+		_, hi := mappingValue(node, pair[1])
+		if lo == nil || hi == nil || !isNumber(lo) || !isNumber(hi) {
+			continue
+		}
+		var loVal, hiVal float64
+		if lo.Decode(&loVal) == nil && hi.Decode(&hiVal) == nil && loVal > hiVal {
+			l.add(SeverityError, lo, path+"."+pair[0], "%s (%v) is greater than %s (%v)", pair[0], loVal, pair[1], hiVal)
+		}
+	}
+}
+
+// lintRequired checks that every required field is declared in properties
+func (l *linter) lintRequired(node *yaml.Node, path string) {
+	_, required := mappingValue(node, "required")
+	_, props := mappingValue(node, "properties")
+	if required == nil || required.Kind != yaml.SequenceNode {
+		return
+	}
+	if props == nil || resolve(props).Kind != yaml.MappingNode {
+		if len(required.Content) > 0 {
+			l.add(SeverityError, required, path+".required", "required lists fields but no properties are declared")
+		}
+		return
+	}
+	props = resolve(props)
+	for _, field := range required.Content {
+		if _, p := mappingValue(props, field.Value); p == nil {
+			l.add(SeverityError, field, path+".required", "required field %q does not exist in properties", field.Value)
+		}
+	}
+}
+
+// lintIndexes checks the x-indexes extension against the declared properties
+func (l *linter) lintIndexes(definition *yaml.Node, path string) {
+	key, indexes := mappingValue(definition, "x-indexes")
+	if indexes == nil {
+		return
+	}
+	path += ".x-indexes"
+	indexes = resolve(indexes)
+	if indexes.Kind != yaml.SequenceNode {
+		l.add(SeverityError, key, path, "x-indexes must be an array")
+		return
+	}
+
+	names := make(map[string]int)
+	targets := make(map[string]int)
+	for i, entry := range indexes.Content {
+		entry = resolve(entry)
+		entryPath := fmt.Sprintf("%s[%d]", path, i)
+		if entry.Kind != yaml.MappingNode {
+			l.add(SeverityError, entry, entryPath, "index must be a mapping with fieldPath and method")
+			continue
+		}
+
+		for j := 0; j+1 < len(entry.Content); j += 2 {
+			switch k := entry.Content[j].Value; k {
+			case "name", "fieldPath", "method", "unique":
+			default:
+				l.add(SeverityWarning, entry.Content[j], entryPath+"."+k, "unknown index property %q", k)
+			}
+		}
+
+		if nameKey, name := mappingValue(entry, "name"); name != nil {
+			if prev, dup := names[name.Value]; dup {
+				l.add(SeverityError, name, entryPath+".name", "index name %q is already used by x-indexes[%d]", name.Value, prev)
+			} else {
+				names[name.Value] = i
+			}
+			if strings.TrimSpace(name.Value) == "" {
+				l.add(SeverityError, nameKey, entryPath+".name", "index name must not be empty")
+			}
+		}
+
+		_, fieldPath := mappingValue(entry, "fieldPath")
+		if fieldPath == nil || strings.TrimSpace(fieldPath.Value) == "" {
+			l.add(SeverityError, entry, entryPath+".fieldPath", "fieldPath is required")
+			continue
+		}
+
+		method := ""
+		_, methodNode := mappingValue(entry, "method")
+		if methodNode == nil {
+			l.add(SeverityWarning, entry, entryPath+".method", "method is not set; the server default will be used")
+		} else {
+			method = strings.ToLower(methodNode.Value)
+			if _, ok := indexMethodTypes[method]; !ok {
+				l.add(SeverityError, methodNode, entryPath+".method", "unsupported index method %q (supported: btree, hash, gin)", methodNode.Value)
+				method = ""
+			}
+		}
+
+		target := fieldPath.Value + "|" + method
+		if prev, dup := targets[target]; dup {
+			l.add(SeverityWarning, fieldPath, entryPath+".fieldPath", "duplicates the index defined at x-indexes[%d]", prev)
+		} else {
+			targets[target] = i
+		}
+
+		field, err := lookupField(definition, fieldPath.Value)
+		if err != nil {
+			l.add(SeverityError, fieldPath, entryPath+".fieldPath", "%v", err)
+			continue
+		}
+		if method == "" {
+			continue
+		}
+		types := schemaTypes(field)
+		if len(types) == 0 {
+			l.add(SeverityWarning, fieldPath, entryPath+".method", "field %q has no declared type; cannot check %s suitability", fieldPath.Value, method)
+			continue
+		}
+		for _, t := range types {
+			if t == "null" {
+				continue
+			}
+			if !contains(indexMethodTypes[method], t) {
+				l.add(SeverityError, methodNode, entryPath+".method", "%s index is not suitable for %s field %q (%s)", method, t, fieldPath.Value, suggestMethod(t))
+			}
+		}
+	}
+}
+
+// lookupField resolves a dotted fieldPath through nested properties and array items
+func lookupField(definition *yaml.Node, fieldPath string) (*yaml.Node, error) {
+	current := definition
+	walked := ""
+	for _, segment := range strings.Split(fieldPath, ".") {
+		// Descend through arrays to reach their item schema
+		for contains(schemaTypes(current), "array") {
+			_, items := mappingValue(current, "items")
+			if items == nil {
+				break
+			}
+			current = resolve(items)
+		}
+		_, props := mappingValue(current, "properties")
+		if props == nil {
+			return nil, fmt.Errorf("fieldPath %q does not exist in properties (%q has no properties)", fieldPath, walked)
+		}
+		_, next := mappingValue(resolve(props), segment)
+		if next == nil {
+			return nil, fmt.Errorf("fieldPath %q does not exist in properties", fieldPath)
+		}
+		current = resolve(next)
+		if walked != "" {
+			walked += "."
+		}
+		walked += segment
+	}
+	return current, nil
+}
+
+// schemaTypes returns the declared type names of a schema node
+func schemaTypes(node *yaml.Node) []string {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	_, t := mappingValue(node, "type")
+	if t == nil {
+		return nil
+	}
+	t = resolve(t)
+	if t.Kind == yaml.ScalarNode {
+		return []string{t.Value}
+	}
+	var types []string
+	for _, item := range t.Content {
+		types = append(types, item.Value)
+	}
+	return types
+}
+
+func suggestMethod(fieldType string) string {
+	switch fieldType {
+	case "array", "object":
+		return "use gin"
+	default:
+		return "use btree"
+	}
+}
+
+func (l *linter) expectString(value *yaml.Node, path string) bool {
+	if value.Kind != yaml.ScalarNode || value.Tag != "!!str" {
+		l.add(SeverityError, value, path, "must be a string")
+		return false
+	}
+	return true
+}
+
+func (l *linter) expectUniqueStrings(value *yaml.Node, path string) {
+	if value.Kind != yaml.SequenceNode {
+		l.add(SeverityError, value, path, "must be an array of strings")
+		return
+	}
+	seen := make(map[string]bool)
+	for _, item := range value.Content {
+		if item.Kind != yaml.ScalarNode || item.Tag != "!!str" {
+			l.add(SeverityError, item, path, "must contain only strings")
+			continue
+		}
+		if seen[item.Value] {
+			l.add(SeverityError, item, path, "duplicate entry %q", item.Value)
+		}
+		seen[item.Value] = true
+	}
+}
+
+// mappingValue returns the key and value nodes for key in a mapping node
+func mappingValue(node *yaml.Node, key string) (*yaml.Node, *yaml.Node) {
+	node = resolve(node)
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil, nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i], resolve(node.Content[i+1])
+		}
+	}
+	return nil, nil
+}
+
+// resolve follows YAML aliases to the node they reference
+func resolve(node *yaml.Node) *yaml.Node {
+	for node != nil && node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	return node
+}
+
+func isNumber(node *yaml.Node) bool {
+	return node.Kind == yaml.ScalarNode && (node.Tag == "!!int" || node.Tag == "!!float")
+}
+
+func isZero(node *yaml.Node) bool {
+	var v float64
+	return node.Decode(&v) == nil && v == 0
+}
+
+func contains(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,357 @@
+package registry
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// MigrationSpec represents the YAML structure of a registry migration file
+type MigrationSpec struct {
+	SchemaCode string                 `yaml:"schemaCode"`
+	Definition map[string]interface{} `yaml:"definition"`
+	Transforms Transform              `yaml:"transforms"`
+}
+
+// Transform describes the declarative changes applied to every record.
+// Field names may be dotted paths into nested objects (e.g. "address.city").
+// Steps run in the order: rename, convert, defaults, remove.
+type Transform struct {
+	Rename   map[string]string      `yaml:"rename"`
+	Convert  map[string]string      `yaml:"convert"`
+	Defaults map[string]interface{} `yaml:"defaults"`
+	Remove   []string               `yaml:"remove"`
+}
+
+// supportedConversions lists the target types accepted in transforms.convert
+var supportedConversions = map[string]bool{
+	"string":  true,
+	"integer": true,
+	"number":  true,
+	"boolean": true,
+}
+
+// LoadMigrationSpec parses a migration file and checks that its transforms are well-formed
+func LoadMigrationSpec(data []byte) (*MigrationSpec, error) {
+	var spec MigrationSpec
+	if err := yaml.Unmarshal(data, &spec); err != nil {
+		return nil, fmt.Errorf("failed to parse YAML: %w", err)
+	}
+	if spec.Definition == nil {
+		return nil, fmt.Errorf("definition is required in migration file")
+	}
+
+	// Round-trip through JSON so values have the same types as records fetched from the server
+	definition, err := normalize(spec.Definition)
+	if err != nil {
+		return nil, fmt.Errorf("invalid definition: %w", err)
+	}
+	spec.Definition = definition.(map[string]interface{})
+	if spec.Transforms.Defaults != nil {
+		defaults, err := normalize(spec.Transforms.Defaults)
+		if err != nil {
+			return nil, fmt.Errorf("invalid defaults: %w", err)
+		}
+		spec.Transforms.Defaults = defaults.(map[string]interface{})
+	}
+
+	for from, to := range spec.Transforms.Rename {
+		if from == "" || to == "" {
+			return nil, fmt.Errorf("rename entries cannot have empty field names")
+		}
+	}
+	for field, target := range spec.Transforms.Convert {
+		if !supportedConversions[target] {
+			return nil, fmt.Errorf("unsupported conversion %q for field %q (use string, integer, number or boolean)", target, field)
+		}
+	}
+	return &spec, nil
+}
+
+// Apply runs the transforms against a copy of the record.
+// Returns the transformed record and a description of every change made.
+func (t Transform) Apply(record map[string]interface{}) (map[string]interface{}, []string, error) {
+	copied, err := normalize(record)
+	if err != nil {
+		return nil, nil, err
+	}
+	out := copied.(map[string]interface{})
+	var changes []string
+
+	for _, from := range sortedKeys(t.Rename) {
+		to := t.Rename[from]
+		value, ok := getPath(out, from)
+		if !ok {
+			continue
+		}
+		if _, exists := getPath(out, to); exists {
+			return nil, nil, fmt.Errorf("cannot rename %s to %s: target field already exists", from, to)
+		}
+		deletePath(out, from)
+		if err := setPath(out, to, value); err != nil {
+			return nil, nil, err
+		}
+		changes = append(changes, fmt.Sprintf("renamed %s to %s", from, to))
+	}
+
+	for _, field := range sortedKeys(t.Convert) {
+		target := t.Convert[field]
+		value, ok := getPath(out, field)
+		if !ok || value == nil {
+			continue
+		}
+		converted, err := convertValue(value, target)
+		if err != nil {
+			return nil, nil, fmt.Errorf("cannot convert %s: %w", field, err)
+		}
+		if !equalJSON(value, converted) || jsonType(value) != jsonType(converted) {
+			if err := setPath(out, field, converted); err != nil {
+				return nil, nil, err
+			}
+			changes = append(changes, fmt.Sprintf("converted %s to %s", field, target))
+		}
+	}
+
+	for _, field := range sortedKeys(t.Defaults) {
+		if _, ok := getPath(out, field); ok {
+			continue
+		}
+		if err := setPath(out, field, t.Defaults[field]); err != nil {
+			return nil, nil, err
+		}
+		changes = append(changes, fmt.Sprintf("defaulted %s", field))
+	}
+
+	for _, field := range t.Remove {
+		if deletePath(out, field) {
+			changes = append(changes, fmt.Sprintf("removed %s", field))
+		}
+	}
+
+	return out, changes, nil
+}
+
+func convertValue(value interface{}, target string) (interface{}, error) {
+	switch target {
+	case "string":
+		switch v := value.(type) {
+		case string:
+			return v, nil
+		case float64:
+			return strconv.FormatFloat(v, 'f', -1, 64), nil
+		case bool:
+			return strconv.FormatBool(v), nil
+		}
+	case "integer":
+		switch v := value.(type) {
+		case float64:
+			if v != math.Trunc(v) {
+				return nil, fmt.Errorf("%v is not a whole number", v)
+			}
+			return v, nil
+		case string:
+			n, err := strconv.ParseInt(strings.TrimSpace(v), 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("%q is not an integer", v)
+			}
+			return float64(n), nil
+		case bool:
+			if v {
+				return float64(1), nil
+			}
+			return float64(0), nil
+		}
+	case "number":
+		switch v := value.(type) {
+		case float64:
+			return v, nil
+		case string:
+			n, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+			if err != nil {
+				return nil, fmt.Errorf("%q is not a number", v)
+			}
+			return n, nil
+		}
+	case "boolean":
+		switch v := value.(type) {
+		case bool:
+			return v, nil
+		case string:
+			b, err := strconv.ParseBool(strings.TrimSpace(v))
+			if err != nil {
+				return nil, fmt.Errorf("%q is not a boolean", v)
+			}
+			return b, nil
+		case float64:
+			return v != 0, nil
+		}
+	}
+	return nil, fmt.Errorf("%s value cannot be converted to %s", jsonType(value), target)
+}
+
+// getPath looks up a dotted field path in a record
+func getPath(record map[string]interface{}, path string) (interface{}, bool) {
+	parts := strings.Split(path, ".")
+	current := record
+	for i, part := range parts {
+		value, ok := current[part]
+		if !ok {
+			return nil, false
+		}
+		if i == len(parts)-1 {
+			return value, true
+		}
+		next, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		current = next
+	}
+	return nil, false
+}
+
+// setPath sets a dotted field path, creating intermediate objects as needed
+func setPath(record map[string]interface{}, path string, value interface{}) error {
+	parts := strings.Split(path, ".")
+	current := record
+	for _, part := range parts[:len(parts)-1] {
+		next, ok := current[part]
+		if !ok {
+			created := map[string]interface{}{}
+			current[part] = created
+			current = created
+			continue
+		}
+		nested, ok := next.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("cannot set %s: %s is not an object", path, part)
+		}
+		current = nested
+	}
+	current[parts[len(parts)-1]] = value
+	return nil
+}
+
+// deletePath removes a dotted field path and reports whether it existed
+func deletePath(record map[string]interface{}, path string) bool {
+	parts := strings.Split(path, ".")
+	current := record
+	for _, part := range parts[:len(parts)-1] {
+		nested, ok := current[part].(map[string]interface{})
+		if !ok {
+			return false
+		}
+		current = nested
+	}
+	last := parts[len(parts)-1]
+	if _, ok := current[last]; !ok {
+		return false
+	}
+	delete(current, last)
+	return true
+}
+
+// normalize deep-copies a value through JSON so numbers become float64 and maps become map[string]interface{}
+func normalize(value interface{}) (interface{}, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var out interface{}
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Record is a registry data record as returned by the registry data search
+type Record struct {
+	ID   string
+	Data map[string]interface{}
+}
+
+// ParseRecords extracts records from a registry data search response.
+// The response may be a bare array or an object wrapping the array in its first array field;
+// each record carries its ID in registryId or id and its payload in data.
+func ParseRecords(responseBody string) ([]Record, error) {
+	var decoded interface{}
+	if err := json.Unmarshal([]byte(responseBody), &decoded); err != nil {
+		return nil, fmt.Errorf("failed to parse registry data response: %w", err)
+	}
+
+	items, ok := decoded.([]interface{})
+	if !ok {
+		wrapper, isObject := decoded.(map[string]interface{})
+		if !isObject {
+			return nil, fmt.Errorf("unexpected registry data response")
+		}
+		for _, key := range sortedKeys(wrapper) {
+			if list, isList := wrapper[key].([]interface{}); isList {
+				items = list
+				break
+			}
+		}
+		if items == nil {
+			// A single record
+			items = []interface{}{wrapper}
+		}
+	}
+
+	records := make([]Record, 0, len(items))
+	for i, item := range items {
+		obj, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("record %d is not an object", i)
+		}
+		id, _ := obj["registryId"].(string)
+		if id == "" {
+			id, _ = obj["id"].(string)
+		}
+		if id == "" {
+			return nil, fmt.Errorf("record %d has no registryId", i)
+		}
+		data, ok := obj["data"].(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("record %s has no data object", id)
+		}
+		records = append(records, Record{ID: id, Data: data})
+	}
+	return records, nil
+}
+
+// SchemaVersion extracts the version from a registry schema search response, if present
+func SchemaVersion(responseBody string) (int, bool) {
+	var decoded interface{}
+	if err := json.Unmarshal([]byte(responseBody), &decoded); err != nil {
+		return 0, false
+	}
+	if list, ok := decoded.([]interface{}); ok && len(list) > 0 {
+		decoded = list[len(list)-1]
+	}
+	obj, ok := decoded.(map[string]interface{})
+	if !ok {
+		return 0, false
+	}
+	switch v := obj["version"].(type) {
+	case float64:
+		return int(v), true
+	case string:
+		n, err := strconv.Atoi(v)
+		return n, err == nil
+	}
+	return 0, false
+}
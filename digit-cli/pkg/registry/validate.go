@@ -0,0 +1,350 @@
+package registry
+
+import (
+	"fmt"
+	"math"
+	"net/mail"
+	"reflect"
+	"regexp"
+	"sort"
+	"time"
+)
+
+// ValidateData validates a registry record against a JSON Schema definition.
+// Only the keywords needed for registry records are evaluated ($ref is not followed).
+// Returns one message per violation, prefixed with the JSON path of the offending value.
+func ValidateData(schema map[string]interface{}, data interface{}) []string {
+	var violations []string
+	validateValue(schema, data, "$", &violations)
+	return violations
+}
+
+func validateValue(schema map[string]interface{}, value interface{}, path string, violations *[]string) {
+	fail := func(format string, args ...interface{}) {
+		*violations = append(*violations, path+": "+fmt.Sprintf(format, args...))
+	}
+
+	if types := typeList(schema["type"]); len(types) > 0 {
+		matched := false
+		for _, t := range types {
+			if matchesType(t, value) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			fail("expected %v, got %s", joinTypes(types), jsonType(value))
+			return
+		}
+	}
+
+	if enum, ok := schema["enum"].([]interface{}); ok {
+		found := false
+		for _, candidate := range enum {
+			if equalJSON(candidate, value) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			fail("value %v is not one of %v", value, enum)
+		}
+	}
+	if c, ok := schema["const"]; ok && !equalJSON(c, value) {
+		fail("value %v does not equal const %v", value, c)
+	}
+
+	for _, sub := range schemaList(schema["allOf"]) {
+		validateValue(sub, value, path, violations)
+	}
+	if anyOf := schemaList(schema["anyOf"]); len(anyOf) > 0 && countMatches(anyOf, value) == 0 {
+		fail("value does not match any schema in anyOf")
+	}
+	if oneOf := schemaList(schema["oneOf"]); len(oneOf) > 0 {
+		if n := countMatches(oneOf, value); n != 1 {
+			fail("value matches %d schemas in oneOf, expected exactly 1", n)
+		}
+	}
+	if not, ok := schema["not"].(map[string]interface{}); ok && countMatches([]map[string]interface{}{not}, value) == 1 {
+		fail("value must not match the schema in not")
+	}
+
+	switch v := value.(type) {
+	case string:
+		validateString(schema, v, fail)
+	case float64, int, int64:
+		validateNumber(schema, toFloat(v), fail)
+	case []interface{}:
+		validateArray(schema, v, path, violations, fail)
+	case map[string]interface{}:
+		validateObject(schema, v, path, violations, fail)
+	}
+}
+
+func validateString(schema map[string]interface{}, v string, fail func(string, ...interface{})) {
+	length := len([]rune(v))
+	if min, ok := numberKeyword(schema, "minLength"); ok && float64(length) < min {
+		fail("length %d is shorter than minLength %v", length, min)
+	}
+	if max, ok := numberKeyword(schema, "maxLength"); ok && float64(length) > max {
+		fail("length %d is longer than maxLength %v", length, max)
+	}
+	if pattern, ok := schema["pattern"].(string); ok {
+		if re, err := regexp.Compile(pattern); err == nil && !re.MatchString(v) {
+			fail("value %q does not match pattern %q", v, pattern)
+		}
+	}
+	if format, ok := schema["format"].(string); ok && !matchesFormat(format, v) {
+		fail("value %q is not a valid %s", v, format)
+	}
+}
+
+func validateNumber(schema map[string]interface{}, v float64, fail func(string, ...interface{})) {
+	if min, ok := numberKeyword(schema, "minimum"); ok && v < min {
+		fail("value %v is less than minimum %v", v, min)
+	}
+	if max, ok := numberKeyword(schema, "maximum"); ok && v > max {
+		fail("value %v is greater than maximum %v", v, max)
+	}
+	if min, ok := numberKeyword(schema, "exclusiveMinimum"); ok && v <= min {
+		fail("value %v must be greater than %v", v, min)
+	}
+	if max, ok := numberKeyword(schema, "exclusiveMaximum"); ok && v >= max {
+		fail("value %v must be less than %v", v, max)
+	}
+	if m, ok := numberKeyword(schema, "multipleOf"); ok && m > 0 {
+		if q := v / m; math.Abs(q-math.Round(q)) > 1e-9 {
+			fail("value %v is not a multiple of %v", v, m)
+		}
+	}
+}
+
+func validateArray(schema map[string]interface{}, v []interface{}, path string, violations *[]string, fail func(string, ...interface{})) {
+	if min, ok := numberKeyword(schema, "minItems"); ok && float64(len(v)) < min {
+		fail("array has %d items, fewer than minItems %v", len(v), min)
+	}
+	if max, ok := numberKeyword(schema, "maxItems"); ok && float64(len(v)) > max {
+		fail("array has %d items, more than maxItems %v", len(v), max)
+	}
+	if unique, _ := schema["uniqueItems"].(bool); unique {
+		for i := range v {
+			for j := i + 1; j < len(v); j++ {
+				if equalJSON(v[i], v[j]) {
+					fail("items %d and %d are equal but uniqueItems is set", i, j)
+				}
+			}
+		}
+	}
+	prefix := schemaList(schema["prefixItems"])
+	for i, item := range v {
+		itemPath := fmt.Sprintf("%s[%d]", path, i)
+		if i < len(prefix) {
+			validateValue(prefix[i], item, itemPath, violations)
+		} else if items, ok := schema["items"].(map[string]interface{}); ok {
+			validateValue(items, item, itemPath, violations)
+		} else if items, ok := schema["items"].(bool); ok && !items {
+			fail("unexpected item at index %d", i)
+		}
+	}
+}
+
+func validateObject(schema map[string]interface{}, v map[string]interface{}, path string, violations *[]string, fail func(string, ...interface{})) {
+	if required, ok := schema["required"].([]interface{}); ok {
+		for _, field := range required {
+			name, _ := field.(string)
+			if _, present := v[name]; !present {
+				fail("required field %q is missing", name)
+			}
+		}
+	}
+	if min, ok := numberKeyword(schema, "minProperties"); ok && float64(len(v)) < min {
+		fail("object has %d properties, fewer than minProperties %v", len(v), min)
+	}
+	if max, ok := numberKeyword(schema, "maxProperties"); ok && float64(len(v)) > max {
+		fail("object has %d properties, more than maxProperties %v", len(v), max)
+	}
+
+	properties, _ := schema["properties"].(map[string]interface{})
+	keys := make([]string, 0, len(v))
+	for key := range v {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		fieldPath := path + "." + key
+		if propSchema, declared := properties[key]; declared {
+			if sub, ok := propSchema.(map[string]interface{}); ok {
+				validateValue(sub, v[key], fieldPath, violations)
+			} else if allowed, ok := propSchema.(bool); ok && !allowed {
+				fail("property %q is not allowed", key)
+			}
+			continue
+		}
+		switch additional := schema["additionalProperties"].(type) {
+		case bool:
+			if !additional {
+				fail("additional property %q is not allowed", key)
+			}
+		case map[string]interface{}:
+			validateValue(additional, v[key], fieldPath, violations)
+		}
+	}
+}
+
+// countMatches returns how many of the schemas accept the value
+func countMatches(schemas []map[string]interface{}, value interface{}) int {
+	n := 0
+	for _, sub := range schemas {
+		var v []string
+		validateValue(sub, value, "$", &v)
+		if len(v) == 0 {
+			n++
+		}
+	}
+	return n
+}
+
+func matchesType(t string, value interface{}) bool {
+	switch t {
+	case "null":
+		return value == nil
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "number":
+		switch value.(type) {
+		case float64, int, int64:
+			return true
+		}
+	case "integer":
+		switch n := value.(type) {
+		case int, int64:
+			return true
+		case float64:
+			return n == math.Trunc(n)
+		}
+	case "array":
+		_, ok := value.([]interface{})
+		return ok
+	case "object":
+		_, ok := value.(map[string]interface{})
+		return ok
+	}
+	return false
+}
+
+// matchesFormat checks the formats the registry relies on; unknown formats always pass
+func matchesFormat(format, v string) bool {
+	switch format {
+	case "date":
+		_, err := time.Parse("2006-01-02", v)
+		return err == nil
+	case "date-time":
+		_, err := time.Parse(time.RFC3339, v)
+		return err == nil
+	case "time":
+		_, err := time.Parse("15:04:05Z07:00", v)
+		return err == nil
+	case "email":
+		_, err := mail.ParseAddress(v)
+		return err == nil
+	case "uuid":
+		return uuidPattern.MatchString(v)
+	}
+	return true
+}
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+func jsonType(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case int, int64:
+		return "integer"
+	case float64:
+		if v == math.Trunc(v) {
+			return "integer"
+		}
+		return "number"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+func typeList(raw interface{}) []string {
+	switch t := raw.(type) {
+	case string:
+		return []string{t}
+	case []interface{}:
+		var types []string
+		for _, item := range t {
+			if s, ok := item.(string); ok {
+				types = append(types, s)
+			}
+		}
+		return types
+	}
+	return nil
+}
+
+func joinTypes(types []string) string {
+	if len(types) == 1 {
+		return types[0]
+	}
+	return fmt.Sprintf("one of %v", types)
+}
+
+func schemaList(raw interface{}) []map[string]interface{} {
+	list, _ := raw.([]interface{})
+	var schemas []map[string]interface{}
+	for _, item := range list {
+		if sub, ok := item.(map[string]interface{}); ok {
+			schemas = append(schemas, sub)
+		}
+	}
+	return schemas
+}
+
+func numberKeyword(schema map[string]interface{}, key string) (float64, bool) {
+	switch n := schema[key].(type) {
+	case float64, int, int64:
+		return toFloat(n), true
+	}
+	return 0, false
+}
+
+func toFloat(value interface{}) float64 {
+	switch n := value.(type) {
+	case float64:
+		return n
+	case int:
+		return float64(n)
+	case int64:
+		return float64(n)
+	}
+	return 0
+}
+
+// equalJSON compares two decoded JSON values, treating numeric types as equal by value
+func equalJSON(a, b interface{}) bool {
+	switch a.(type) {
+	case float64, int, int64:
+		switch b.(type) {
+		case float64, int, int64:
+			return toFloat(a) == toFloat(b)
+		}
+		return false
+	}
+	return reflect.DeepEqual(a, b)
+}
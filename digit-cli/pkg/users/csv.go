@@ -0,0 +1,219 @@
+package users
+
+import (
+	"crypto/rand"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"strings"
+)
+
+// ImportRow is a user read from a CSV import file
+type ImportRow struct {
+	// Line is the line of the row in the file, for reporting
+	Line      int
+	Username  string
+	Email     string
+	FirstName string
+	LastName  string
+	// Enabled is nil when the file has no enabled column or the cell is empty
+	Enabled *bool
+	Roles   []string
+	// Groups holds the paths of the groups the user is a member of, e.g. /departments/pgr
+	Groups   []string
+	Password string
+}
+
+// importColumns maps the normalized CSV column names to the field they fill
+var importColumns = map[string]string{
+	"username":  "username",
+	"email":     "email",
+	"firstname": "firstName",
+	"lastname":  "lastName",
+	"enabled":   "enabled",
+	"roles":     "roles",
+	"role":      "roles",
+	"groups":    "groups",
+	"group":     "groups",
+	"password":  "password",
+}
+
+// exportOnlyColumns are the normalized columns of 'digit user list --format csv' that describe
+// the user as stored and are ignored on import, so an export can be imported again
+var exportOnlyColumns = map[string]bool{
+	"id":              true,
+	"emailverified":   true,
+	"created":         true,
+	"requiredactions": true,
+}
+
+// ReadImportCSV reads the users of a CSV file with a header row. The columns are username
+// (required), email, firstName, lastName, enabled, roles, groups and password, in any order
+// and case; first_name and first-name are read as firstName. Roles and groups are separated
+// by ; or |, and groups are written by path. The id, emailVerified, created and requiredActions
+// columns of a CSV export are ignored.
+func ReadImportCSV(path string) ([]ImportRow, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open CSV file: %w", err)
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.TrimLeadingSpace = true
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+	columns := make([]string, len(header))
+	hasUsername := false
+	for i, name := range header {
+		normalized := strings.NewReplacer("_", "", "-", "", " ", "").Replace(strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff"))))
+		if exportOnlyColumns[normalized] {
+			continue
+		}
+		field, ok := importColumns[normalized]
+		if !ok {
+			return nil, fmt.Errorf("unknown CSV column %q; expected username, email, firstName, lastName, enabled, roles, groups and password", name)
+		}
+		columns[i] = field
+		hasUsername = hasUsername || field == "username"
+	}
+	if !hasUsername {
+		return nil, fmt.Errorf("CSV file must have a username column")
+	}
+
+	var rows []ImportRow
+	seen := map[string]int{}
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CSV file: %w", err)
+		}
+
+		row := ImportRow{Line: line}
+		for i, value := range record {
+			value = strings.TrimSpace(value)
+			switch columns[i] {
+			case "username":
+				row.Username = value
+			case "email":
+				row.Email = value
+			case "firstName":
+				row.FirstName = value
+			case "lastName":
+				row.LastName = value
+			case "password":
+				row.Password = value
+			case "roles":
+				row.Roles = splitList(value)
+			case "groups":
+				for _, group := range splitList(value) {
+					row.Groups = append(row.Groups, "/"+strings.Trim(group, "/"))
+				}
+			case "enabled":
+				if value == "" {
+					continue
+				}
+				enabled, err := parseFlag(value)
+				if err != nil {
+					return nil, fmt.Errorf("line %d: %w", line, err)
+				}
+				row.Enabled = &enabled
+			}
+		}
+
+		if row.Username == "" {
+			return nil, fmt.Errorf("line %d: username is empty", line)
+		}
+		if row.Email != "" && !strings.Contains(row.Email, "@") {
+			return nil, fmt.Errorf("line %d: invalid email %q", line, row.Email)
+		}
+		// Keycloak stores usernames in lower case, so JDoe and jdoe are the same user
+		key := strings.ToLower(row.Username)
+		if previous, ok := seen[key]; ok {
+			return nil, fmt.Errorf("line %d: user %s is already on line %d", line, row.Username, previous)
+		}
+		seen[key] = line
+		rows = append(rows, row)
+	}
+
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("CSV file has no users")
+	}
+	return rows, nil
+}
+
+// splitList splits a cell of values separated by ; or |
+func splitList(value string) []string {
+	var values []string
+	for _, item := range strings.FieldsFunc(value, func(r rune) bool { return r == ';' || r == '|' }) {
+		if item = strings.TrimSpace(item); item != "" {
+			values = append(values, item)
+		}
+	}
+	return values
+}
+
+// parseFlag reads an enabled cell such as true, false, yes, no, 1 or 0
+func parseFlag(value string) (bool, error) {
+	switch strings.ToLower(value) {
+	case "true", "yes", "y", "1":
+		return true, nil
+	case "false", "no", "n", "0":
+		return false, nil
+	}
+	return false, fmt.Errorf("invalid enabled value %q, expected true or false", value)
+}
+
+// passwordClasses are the characters of generated passwords; every class is used at least once
+// so that the passwords meet common Keycloak password policies
+var passwordClasses = []string{
+	"ABCDEFGHJKLMNPQRSTUVWXYZ",
+	"abcdefghijkmnopqrstuvwxyz",
+	"23456789",
+	"!@#%^*-_=+",
+}
+
+// GeneratePassword returns a random password of the given length from crypto/rand
+func GeneratePassword(length int) (string, error) {
+	if length < len(passwordClasses) {
+		return "", fmt.Errorf("password length must be at least %d", len(passwordClasses))
+	}
+	all := strings.Join(passwordClasses, "")
+	password := make([]byte, length)
+	for i := range password {
+		class := all
+		if i < len(passwordClasses) {
+			class = passwordClasses[i]
+		}
+		c, err := randomIndex(len(class))
+		if err != nil {
+			return "", err
+		}
+		password[i] = class[c]
+	}
+	// Shuffle so the character classes are not at fixed positions
+	for i := len(password) - 1; i > 0; i-- {
+		j, err := randomIndex(i + 1)
+		if err != nil {
+			return "", err
+		}
+		password[i], password[j] = password[j], password[i]
+	}
+	return string(password), nil
+}
+
+// randomIndex returns a random number in [0, n)
+func randomIndex(n int) (int, error) {
+	value, err := rand.Int(rand.Reader, big.NewInt(int64(n)))
+	if err != nil {
+		return 0, fmt.Errorf("failed to generate password: %w", err)
+	}
+	return int(value.Int64()), nil
+}
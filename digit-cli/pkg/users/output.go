@@ -0,0 +1,165 @@
+package users
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/digitnxt/digit3/code/libraries/digit-library/digit"
+)
+
+// Output formats of user listings
+const (
+	FormatTable = "table"
+	FormatJSON  = "json"
+	FormatCSV   = "csv"
+)
+
+// Record is a user as listed and exported, with its realm roles when they were fetched
+type Record struct {
+	digit.User
+	CreatedAt  string   `json:"created,omitempty"`
+	RealmRoles []string `json:"realmRoles,omitempty"`
+}
+
+// NewRecord returns the record of a user
+func NewRecord(user digit.User, realmRoles []string) Record {
+	record := Record{User: user, RealmRoles: realmRoles}
+	if created := user.Created(); !created.IsZero() {
+		record.CreatedAt = created.UTC().Format(time.RFC3339)
+	}
+	return record
+}
+
+// Writer writes user records one at a time, so that listings of any size can be streamed
+type Writer interface {
+	Write(record Record) error
+	// Close ends the output; nothing is written after it
+	Close() error
+}
+
+// NewWriter returns a writer of the format; withRoles adds the realm roles to table and CSV output
+func NewWriter(w io.Writer, format string, withRoles bool) (Writer, error) {
+	switch format {
+	case FormatTable:
+		return &tableWriter{w: tabwriter.NewWriter(w, 0, 0, 2, ' ', 0), withRoles: withRoles}, nil
+	case FormatJSON:
+		return &jsonWriter{w: w}, nil
+	case FormatCSV:
+		cw := csv.NewWriter(w)
+		header := []string{"id", "username", "email", "firstName", "lastName", "enabled", "emailVerified", "created", "requiredActions"}
+		if withRoles {
+			header = append(header, "roles")
+		}
+		if err := cw.Write(header); err != nil {
+			return nil, fmt.Errorf("failed to write CSV header: %w", err)
+		}
+		return &csvWriter{w: cw, withRoles: withRoles}, nil
+	}
+	return nil, fmt.Errorf("unknown format %q, expected %s, %s or %s", format, FormatTable, FormatJSON, FormatCSV)
+}
+
+// tableWriter aligns records in columns; the rows are written on Close, once their widths
+// are known, and without records nothing is written
+type tableWriter struct {
+	w         *tabwriter.Writer
+	withRoles bool
+	count     int
+}
+
+func (t *tableWriter) Write(record Record) error {
+	if t.count == 0 {
+		header := "USERNAME\tEMAIL\tNAME\tENABLED\tCREATED"
+		if t.withRoles {
+			header += "\tROLES"
+		}
+		fmt.Fprintln(t.w, header)
+	}
+	t.count++
+	name := strings.TrimSpace(record.FirstName + " " + record.LastName)
+	created := "-"
+	if !record.Created().IsZero() {
+		created = record.Created().Format("2006-01-02")
+	}
+	row := fmt.Sprintf("%s\t%s\t%s\t%t\t%s", record.Username, dash(record.Email), dash(name), record.Enabled, created)
+	if t.withRoles {
+		row += "\t" + dash(strings.Join(record.RealmRoles, ", "))
+	}
+	_, err := fmt.Fprintln(t.w, row)
+	return err
+}
+
+func (t *tableWriter) Close() error {
+	return t.w.Flush()
+}
+
+// jsonWriter writes an indented JSON array, one element at a time
+type jsonWriter struct {
+	w     io.Writer
+	count int
+}
+
+func (j *jsonWriter) Write(record Record) error {
+	data, err := json.MarshalIndent(record, "  ", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode user %s: %w", record.Username, err)
+	}
+	separator := ",\n  "
+	if j.count == 0 {
+		separator = "[\n  "
+	}
+	j.count++
+	_, err = fmt.Fprintf(j.w, "%s%s", separator, data)
+	return err
+}
+
+func (j *jsonWriter) Close() error {
+	end := "\n]\n"
+	if j.count == 0 {
+		end = "[]\n"
+	}
+	_, err := io.WriteString(j.w, end)
+	return err
+}
+
+// csvWriter writes a CSV row per record, with roles separated by ; as read by ReadImportCSV
+type csvWriter struct {
+	w         *csv.Writer
+	withRoles bool
+}
+
+func (c *csvWriter) Write(record Record) error {
+	row := []string{
+		record.ID,
+		record.Username,
+		record.Email,
+		record.FirstName,
+		record.LastName,
+		strconv.FormatBool(record.Enabled),
+		strconv.FormatBool(record.EmailVerified),
+		record.CreatedAt,
+		strings.Join(record.RequiredActions, ";"),
+	}
+	if c.withRoles {
+		row = append(row, strings.Join(record.RealmRoles, ";"))
+	}
+	return c.w.Write(row)
+}
+
+func (c *csvWriter) Close() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// dash returns - for an empty table cell
+func dash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}